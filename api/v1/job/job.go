@@ -0,0 +1,74 @@
+package job
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pingcap/errors"
+	"github.com/romberli/go-util/constant"
+
+	"github.com/romberli/db-operator/module/implement/job"
+	"github.com/romberli/db-operator/pkg/message"
+	"github.com/romberli/db-operator/pkg/resp"
+
+	msgJob "github.com/romberli/db-operator/pkg/message/job"
+)
+
+const (
+	jobIDParam = "id"
+)
+
+// @Tags job
+// @Summary get the scheduled jobs
+// @Accept	application/json
+// @Param	token	body string true "token"
+// @Produce application/json
+// @Success 200 {string} string "[{"id": 1, "job_name": "cleanup-operation-history", "job_type": 4, "cron_expr": "0 3 * * *", "job_param": "{\"retention_days\": 30}", "enabled": 1, "last_run_time": "2024-01-01T03:00:00+08:00", "next_run_time": "2024-01-02T03:00:00+08:00", "del_flag": 0, "create_time": "2023-12-01T10:00:00+08:00", "last_update_time": "2024-01-01T03:00:00+08:00"}]"
+// @Router	/api/v1/job [get]
+func GetJobs(c *gin.Context) {
+	jobs, err := job.NewJobRepoWithDefault().GetJobs()
+	if err != nil {
+		resp.ResponseNOK(c, msgJob.ErrJobGetJobs, err)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(jobs)
+	if err != nil {
+		resp.ResponseNOK(c, message.ErrMarshalData, errors.Trace(err))
+		return
+	}
+
+	resp.ResponseOK(c, string(jsonBytes), msgJob.InfoJobGetJobs)
+}
+
+// @Tags job
+// @Summary get the run histories of the job, the latest run comes first
+// @Accept	application/json
+// @Param	token	body string true "token"
+// @Param	id		path int	true "job id"
+// @Produce application/json
+// @Success 200 {string} string "[{"id": 1, "job_id": 1, "scheduled_time": "2024-01-01T03:00:00+08:00", "owner": "192-168-137-11:12345", "status": 2, "message": "operation histories purged. purged_num: 10, before: 2023-12-02 03:00:00", "start_time": "2024-01-01T03:00:05+08:00", "end_time": "2024-01-01T03:00:06+08:00", "del_flag": 0, "create_time": "2024-01-01T03:00:05+08:00", "last_update_time": "2024-01-01T03:00:06+08:00"}]"
+// @Router	/api/v1/job/:id/history [get]
+func GetJobHistories(c *gin.Context) {
+	jobIDStr := c.Param(jobIDParam)
+	jobID, err := strconv.Atoi(jobIDStr)
+	if err != nil || jobID <= constant.ZeroInt {
+		resp.ResponseNOK(c, msgJob.ErrJobNotValidJobID, jobIDStr)
+		return
+	}
+
+	histories, err := job.NewJobRepoWithDefault().GetJobHistories(jobID)
+	if err != nil {
+		resp.ResponseNOK(c, msgJob.ErrJobGetJobHistories, err, jobID)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(histories)
+	if err != nil {
+		resp.ResponseNOK(c, message.ErrMarshalData, errors.Trace(err))
+		return
+	}
+
+	resp.ResponseOK(c, string(jsonBytes), msgJob.InfoJobGetJobHistories, jobID)
+}
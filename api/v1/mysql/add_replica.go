@@ -0,0 +1,87 @@
+package mysql
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hashicorp/go-version"
+	"github.com/pingcap/errors"
+	"github.com/romberli/go-util/linux"
+
+	"github.com/romberli/db-operator/module/implement/mysql"
+	"github.com/romberli/db-operator/pkg/message"
+	"github.com/romberli/db-operator/pkg/resp"
+
+	jsonmysql "github.com/romberli/db-operator/pkg/json/mysql"
+	msgMySQL "github.com/romberli/db-operator/pkg/message/mysql"
+)
+
+const (
+	addReplicaMessage = `{"operation_id": %d, "version": "%s", "mode": %d, "source_addr": "%s", "addrs": %s, "message": "add mysql replica started"}`
+)
+
+// @Tags mysql
+// @Summary install new replicas and provision them from the existing source with the clone plugin asynchronously, it returns the operation id immediately
+// @Accept	application/json
+// @Param	token	 			body string 			   true  "token"
+// @Param 	mode 				body int  				   true  "mode, only async replication and semi-sync replication are supported"
+// @Param	sourceAddr			body string 			   true  "source_addr"
+// @Param   addrs 				body []string 			   true  "addrs"
+// @Param   mysqlServerParam	body *parameter.MySQLServer true  "mysql_server_param"
+// @Param	pmmClientParam		body *parameter.PMMClient   true  "pmm_client_param"
+// @Param	rollbackOnFailure	body bool 				   false "rollback the partially applied changes of the failed instance, default is true"
+// @Produce application/json
+// @Success 200 {string} string "{"operation_id": 3, "version": "8.0.32", "mode": 1, "source_addr": "192.168.137.11:3306", "addrs": ["192.168.137.13:3306"], "message": "add mysql replica started"}"
+// @Router	/api/v1/mysql/add-replica [post]
+func AddReplica(c *gin.Context) {
+	data, err := c.GetRawData()
+	if err != nil {
+		resp.ResponseNOK(c, message.ErrGetRawData, errors.Trace(err))
+		return
+	}
+
+	addReplica := jsonmysql.NewAddReplicaWithDefault()
+	err = addReplica.Unmarshal(data)
+	if err != nil {
+		resp.ResponseNOK(c, message.ErrUnmarshalRawData, errors.Trace(err))
+		return
+	}
+	mysqlVersion, err := version.NewVersion(addReplica.MySQLServerParam.Version)
+	if err != nil {
+		resp.ResponseNOK(c, msgMySQL.ErrMySQLNotValidConfigMySQLVersion, errors.Trace(err))
+		return
+	}
+	err = linux.SortAddrs(addReplica.Addrs)
+	if err != nil {
+		resp.ResponseNOK(c, message.ErrSortAddrs, err, addReplica.Addrs)
+		return
+	}
+
+	e := mysql.NewEngineWithDefault(
+		mysqlVersion,
+		addReplica.Mode,
+		addReplica.Addrs,
+		addReplica.MySQLServerParam,
+		addReplica.PMMClientParam,
+	)
+	e.SetRollbackOnFailure(addReplica.RollbackOnFailure)
+
+	jsonBytes, err := json.Marshal(addReplica.Addrs)
+	if err != nil {
+		resp.ResponseNOK(c, message.ErrMarshalData, errors.Trace(err))
+		return
+	}
+	jsonStr := string(jsonBytes)
+
+	s := mysql.NewServiceWithDefault(e)
+	operationID, err := s.AddReplica(addReplica.SourceAddr)
+	if err != nil {
+		resp.ResponseNOK(c, msgMySQL.ErrMySQLServiceAddReplica, err,
+			addReplica.MySQLServerParam.Version, addReplica.Mode, addReplica.SourceAddr, jsonStr)
+		return
+	}
+
+	resp.ResponseOK(c, fmt.Sprintf(addReplicaMessage, operationID, addReplica.MySQLServerParam.Version, addReplica.Mode, addReplica.SourceAddr, jsonStr),
+		msgMySQL.InfoMySQLServiceAddReplica, operationID, addReplica.MySQLServerParam.Version, addReplica.Mode, addReplica.SourceAddr, jsonStr)
+}
@@ -0,0 +1,115 @@
+package mysql
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hashicorp/go-version"
+	"github.com/pingcap/errors"
+	"github.com/romberli/go-util/linux"
+
+	"github.com/romberli/db-operator/module/implement/mysql"
+	"github.com/romberli/db-operator/module/implement/mysql/mode"
+	"github.com/romberli/db-operator/module/implement/mysql/parameter"
+	"github.com/romberli/db-operator/pkg/message"
+	"github.com/romberli/db-operator/pkg/resp"
+
+	jsonmysql "github.com/romberli/db-operator/pkg/json/mysql"
+	msgMySQL "github.com/romberli/db-operator/pkg/message/mysql"
+)
+
+const (
+	backupMessage = `{"operation_id": %d, "backup_type": %d, "backup_method": %d, "addr": "%s", "message": "backup mysql server started"}`
+)
+
+// @Tags mysql
+// @Summary take a physical backup of the mysql instance into the backup dir asynchronously, it returns the operation id immediately
+// @Accept	application/json
+// @Param	token	 			body string 			   true  "token"
+// @Param   addr 				body string 			   true  "addr of the instance, formatted as host:port"
+// @Param   backup_type 		body int 				   false "1: full backup, 2: incremental backup based on the latest successful xtrabackup backup set, default: 1"
+// @Param   backup_method 		body int 				   false "1: xtrabackup, 2: clone plugin which only supports the full backup, default: 1"
+// @Param   mysqlServerParam	body *parameter.MySQLServer false "mysql_server_param"
+// @Produce application/json
+// @Success 200 {string} string "{"operation_id": 6, "backup_type": 1, "backup_method": 1, "addr": "192.168.137.11:3306", "message": "backup mysql server started"}"
+// @Router	/api/v1/mysql/backup [post]
+func Backup(c *gin.Context) {
+	data, err := c.GetRawData()
+	if err != nil {
+		resp.ResponseNOK(c, message.ErrGetRawData, errors.Trace(err))
+		return
+	}
+
+	backup := jsonmysql.NewBackupWithDefault()
+	err = backup.Unmarshal(data)
+	if err != nil {
+		resp.ResponseNOK(c, message.ErrUnmarshalRawData, errors.Trace(err))
+		return
+	}
+	mysqlVersion, err := version.NewVersion(backup.MySQLServerParam.Version)
+	if err != nil {
+		resp.ResponseNOK(c, msgMySQL.ErrMySQLNotValidConfigMySQLVersion, errors.Trace(err))
+		return
+	}
+	addrs := []string{backup.Addr}
+	err = linux.SortAddrs(addrs)
+	if err != nil {
+		resp.ResponseNOK(c, message.ErrSortAddrs, err, addrs)
+		return
+	}
+
+	e := mysql.NewEngineWithDefault(
+		mysqlVersion,
+		mode.Standalone,
+		addrs,
+		backup.MySQLServerParam,
+		parameter.NewPMMClientWithDefault(),
+	)
+	s := mysql.NewServiceWithDefault(e)
+	operationID, err := s.Backup(backup.BackupType, backup.BackupMethod)
+	if err != nil {
+		resp.ResponseNOK(c, msgMySQL.ErrMySQLServiceBackup, err, backup.BackupType, backup.BackupMethod, backup.Addr)
+		return
+	}
+
+	resp.ResponseOK(c, fmt.Sprintf(backupMessage, operationID, backup.BackupType, backup.BackupMethod, backup.Addr),
+		msgMySQL.InfoMySQLServiceBackup, operationID, backup.BackupType, backup.BackupMethod, backup.Addr)
+}
+
+// @Tags mysql
+// @Summary get the backup sets of the mysql instance, the newest backup set comes first
+// @Accept	application/json
+// @Param	token	body string	true "token"
+// @Param	addr	path string	true "addr of the instance, formatted as host:port"
+// @Produce application/json
+// @Success 200 {string} string "[{"id": 1, "operation_id": 6, "host_ip": "192.168.137.11", "port_num": 3306, "backup_type": 1, "backup_method": 1, "base_backup_id": 0, "backup_dir": "/data/mysql/backup/3306/2024-01-01-00-00-00_full", "from_lsn": 0, "to_lsn": 19013468, "gtid_set": "", "backup_size": 74682368, "checksum": "d41d8cd98f00b204e9800998ecf8427e", "status": 2, "message": "backup mysql server completed."}]"
+// @Router	/api/v1/mysql/instance/:addr/backup [get]
+func GetBackups(c *gin.Context) {
+	addr := c.Param(addrParam)
+	hostIP, portNumStr, err := net.SplitHostPort(addr)
+	if err != nil {
+		resp.ResponseNOK(c, msgMySQL.ErrMySQLServiceGetBackups, errors.Trace(err), addr)
+		return
+	}
+	portNum, err := strconv.Atoi(portNumStr)
+	if err != nil {
+		resp.ResponseNOK(c, msgMySQL.ErrMySQLServiceGetBackups, errors.Trace(err), addr)
+		return
+	}
+	backups, err := mysql.NewDBORepoWithDefault().GetBackups(hostIP, portNum)
+	if err != nil {
+		resp.ResponseNOK(c, msgMySQL.ErrMySQLServiceGetBackups, err, addr)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(backups)
+	if err != nil {
+		resp.ResponseNOK(c, message.ErrMarshalData, errors.Trace(err))
+		return
+	}
+
+	resp.ResponseOK(c, string(jsonBytes), msgMySQL.InfoMySQLServiceGetBackups, addr)
+}
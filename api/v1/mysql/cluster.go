@@ -0,0 +1,111 @@
+package mysql
+
+import (
+	"encoding/json"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hashicorp/go-version"
+	"github.com/pingcap/errors"
+
+	"github.com/romberli/db-operator/module/implement/mysql"
+	"github.com/romberli/db-operator/module/implement/mysql/mode"
+	"github.com/romberli/db-operator/module/implement/mysql/parameter"
+	"github.com/romberli/db-operator/pkg/message"
+	"github.com/romberli/db-operator/pkg/resp"
+
+	msgMySQL "github.com/romberli/db-operator/pkg/message/mysql"
+)
+
+const (
+	clusterNameParam = "name"
+)
+
+// @Tags mysql
+// @Summary get all the registered clusters
+// @Accept	application/json
+// @Param	token	body string true "token"
+// @Produce application/json
+// @Success 200 {string} string "[{"id": 1, "cluster_name": "cluster01", "mode": 2, "version": "8.0.32", "del_flag": 0, "create_time": "2023-10-01T10:00:00+08:00", "last_update_time": "2023-10-01T10:05:00+08:00"}]"
+// @Router	/api/v1/mysql/cluster [get]
+func GetClusters(c *gin.Context) {
+	clusters, err := mysql.NewClusterRepoWithDefault().GetClusters()
+	if err != nil {
+		resp.ResponseNOK(c, msgMySQL.ErrMySQLServiceGetClusters, err)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(clusters)
+	if err != nil {
+		resp.ResponseNOK(c, message.ErrMarshalData, errors.Trace(err))
+		return
+	}
+
+	resp.ResponseOK(c, string(jsonBytes), msgMySQL.InfoMySQLServiceGetClusters)
+}
+
+// @Tags mysql
+// @Summary get the cluster and its instances
+// @Accept	application/json
+// @Param	token	body string true "token"
+// @Param	name	path string	true "cluster name"
+// @Produce application/json
+// @Success 200 {string} string "{"id": 1, "cluster_name": "cluster01", "mode": 2, "version": "8.0.32", "del_flag": 0, "create_time": "2023-10-01T10:00:00+08:00", "last_update_time": "2023-10-01T10:05:00+08:00", "instances": [{"id": 1, "cluster_id": 1, "host_ip": "192.168.137.11", "port_num": 3306, "role": 1, "server_id": 3306137011, "data_dir_base": "/data/mysql/data/mysql3306", "log_dir_base": "/data/mysql/log/mysql3306", "pmm_service_name": "host01-3306", "del_flag": 0, "create_time": "2023-10-01T10:05:00+08:00", "last_update_time": "2023-10-01T10:05:00+08:00"}]}"
+// @Router	/api/v1/mysql/cluster/:name [get]
+func GetCluster(c *gin.Context) {
+	clusterName := c.Param(clusterNameParam)
+	clusterDetail, err := mysql.NewClusterRepoWithDefault().GetClusterDetail(clusterName)
+	if err != nil {
+		resp.ResponseNOK(c, msgMySQL.ErrMySQLServiceGetCluster, err, clusterName)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(clusterDetail)
+	if err != nil {
+		resp.ResponseNOK(c, message.ErrMarshalData, errors.Trace(err))
+		return
+	}
+
+	resp.ResponseOK(c, string(jsonBytes), msgMySQL.InfoMySQLServiceGetCluster, clusterName)
+}
+
+// @Tags mysql
+// @Summary get the live status of all the instances of the cluster, the instance which could not be connected is reported as down
+// @Accept	application/json
+// @Param	token	body string true "token"
+// @Param	name	path string	true "cluster name"
+// @Produce application/json
+// @Success 200 {string} string "{"cluster_name": "cluster01", "mode": 2, "instances": [{"addr": "192.168.137.11:3306", "is_up": true, "error": "", "version": "8.0.32", "read_only": false, "super_read_only": false, "is_replica": false, "io_thread_running": "", "sql_thread_running": "", "seconds_behind_source": "", "last_io_error": "", "last_sql_error": "", "gtid_executed": "3e11fa47-71ca-11e1-9e33-c80aa9429562:1-5", "semi_sync_source_status": "", "semi_sync_replica_status": ""}]}"
+// @Router	/api/v1/mysql/cluster/:name/status [get]
+func GetClusterStatus(c *gin.Context) {
+	clusterName := c.Param(clusterNameParam)
+	clusterDetail, err := mysql.NewClusterRepoWithDefault().GetClusterDetail(clusterName)
+	if err != nil {
+		resp.ResponseNOK(c, msgMySQL.ErrMySQLServiceGetClusterStatus, err, clusterName)
+		return
+	}
+	mysqlVersion, err := version.NewVersion(clusterDetail.Version)
+	if err != nil {
+		resp.ResponseNOK(c, msgMySQL.ErrMySQLNotValidConfigMySQLVersion, errors.Trace(err))
+		return
+	}
+	addrs := make([]string, len(clusterDetail.Instances))
+	for i, instance := range clusterDetail.Instances {
+		addrs[i] = instance.GetAddr()
+	}
+
+	e := mysql.NewEngineWithDefault(
+		mysqlVersion,
+		mode.Mode(clusterDetail.Mode),
+		addrs,
+		parameter.NewMySQLServerWithDefault(),
+		nil,
+	)
+
+	jsonBytes, err := json.Marshal(e.GetClusterStatus(clusterName))
+	if err != nil {
+		resp.ResponseNOK(c, message.ErrMarshalData, errors.Trace(err))
+		return
+	}
+
+	resp.ResponseOK(c, string(jsonBytes), msgMySQL.InfoMySQLServiceGetClusterStatus, clusterName)
+}
@@ -0,0 +1,83 @@
+package mysql
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hashicorp/go-version"
+	"github.com/pingcap/errors"
+	"github.com/romberli/go-util/linux"
+
+	"github.com/romberli/db-operator/module/implement/mysql"
+	"github.com/romberli/db-operator/pkg/message"
+	"github.com/romberli/db-operator/pkg/resp"
+
+	jsonmysql "github.com/romberli/db-operator/pkg/json/mysql"
+	msgMySQL "github.com/romberli/db-operator/pkg/message/mysql"
+)
+
+const (
+	failoverMessage = `{"operation_id": %d, "mode": %d, "source_addr": "%s", "addrs": %s, "message": "failover started"}`
+)
+
+// @Tags mysql
+// @Summary promote the most advanced surviving replica to be the new source when the source is dead asynchronously, it returns the operation id immediately
+// @Accept	application/json
+// @Param	token	 			body string 			   true  "token"
+// @Param 	mode 				body int  				   true  "mode, only async replication and semi-sync replication are supported"
+// @Param	sourceAddr			body string 			   true  "source_addr, the dead source which will be fenced after the failover"
+// @Param   addrs 				body []string 			   true  "all the members of the cluster, including the dead source"
+// @Param   mysqlServerParam	body *parameter.MySQLServer false "mysql_server_param"
+// @Produce application/json
+// @Success 200 {string} string "{"operation_id": 5, "mode": 1, "source_addr": "192.168.137.11:3306", "addrs": ["192.168.137.11:3306", "192.168.137.12:3306", "192.168.137.13:3306"], "message": "failover started"}"
+// @Router	/api/v1/mysql/failover [post]
+func Failover(c *gin.Context) {
+	data, err := c.GetRawData()
+	if err != nil {
+		resp.ResponseNOK(c, message.ErrGetRawData, errors.Trace(err))
+		return
+	}
+
+	failover := jsonmysql.NewFailoverWithDefault()
+	err = failover.Unmarshal(data)
+	if err != nil {
+		resp.ResponseNOK(c, message.ErrUnmarshalRawData, errors.Trace(err))
+		return
+	}
+	mysqlVersion, err := version.NewVersion(failover.MySQLServerParam.Version)
+	if err != nil {
+		resp.ResponseNOK(c, msgMySQL.ErrMySQLNotValidConfigMySQLVersion, errors.Trace(err))
+		return
+	}
+	err = linux.SortAddrs(failover.Addrs)
+	if err != nil {
+		resp.ResponseNOK(c, message.ErrSortAddrs, err, failover.Addrs)
+		return
+	}
+
+	e := mysql.NewEngineWithDefault(
+		mysqlVersion,
+		failover.Mode,
+		failover.Addrs,
+		failover.MySQLServerParam,
+		nil,
+	)
+
+	jsonBytes, err := json.Marshal(failover.Addrs)
+	if err != nil {
+		resp.ResponseNOK(c, message.ErrMarshalData, errors.Trace(err))
+		return
+	}
+	jsonStr := string(jsonBytes)
+
+	s := mysql.NewServiceWithDefault(e)
+	operationID, err := s.Failover(failover.SourceAddr)
+	if err != nil {
+		resp.ResponseNOK(c, msgMySQL.ErrMySQLServiceFailover, err, failover.Mode, failover.SourceAddr, jsonStr)
+		return
+	}
+
+	resp.ResponseOK(c, fmt.Sprintf(failoverMessage, operationID, failover.Mode, failover.SourceAddr, jsonStr),
+		msgMySQL.InfoMySQLServiceFailover, operationID, failover.Mode, failover.SourceAddr, jsonStr)
+}
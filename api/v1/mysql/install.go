@@ -19,20 +19,25 @@ import (
 )
 
 const (
-	installMySQLMessage = `{"version": "%s",  "mode": %d, "addrs": "%s", "message": "install mysql server completed"}`
+	installMySQLMessage = `{"operation_id": %d, "version": "%s", "mode": %d, "addrs": %s, "message": "install mysql server started"}`
 )
 
-// @Tags health
-// @Summary install mysql server
+// @Tags mysql
+// @Summary install mysql server asynchronously, it returns the operation id immediately
 // @Accept	application/json
 // @Param	token	 			body string 			   true "token"
+// @Param	clusterName			body string 			   false "cluster_name, the instances will be registered to the cluster after they are installed, default is the addr of the source"
 // @Param 	mode 				body int  				   true "mode"
 // @Param   addrs 				body []string 			   true "addrs"
 // @Param   mysqlServerParam	body *parameter.MySQLServer true "mysql_server_param"
 // @Param	pmmClientParam		body *parameter.PMMClient   true "pmm_client_param"
+// @Param	rollbackOnFailure	body bool 				   false "rollback the partially applied changes of the failed instance, default is true. the undone steps will be run again when resuming the operation"
+// @Param	parameterProfile	body string 			   false "parameter_profile, the name of the parameter profile which will be merged into the config file"
+// @Param	extraParameters		body map[string]string 	   false "extra_parameters, the options which override the parameter profile"
+// @Param	autoSizing			body bool 				   false "auto_sizing, derive the buffer pool, io capacity and threads from the hardware of the host, default is false"
 // @Produce application/json
-// @Success 200 {string} string "0"
-// @Router	/api/v1/mysql/install [get]
+// @Success 200 {string} string "{"operation_id": 1, "version": "8.0.32", "mode": 2, "addrs": ["192.168.137.11:3306", "192.168.137.12:3306"], "message": "install mysql server started"}"
+// @Router	/api/v1/mysql/install [post]
 func Install(c *gin.Context) {
 	data, err := c.GetRawData()
 	if err != nil {
@@ -64,6 +69,10 @@ func Install(c *gin.Context) {
 		installMySQL.MySQLServerParam,
 		installMySQL.PMMClientParam,
 	)
+	e.SetClusterName(installMySQL.ClusterName)
+	e.SetRollbackOnFailure(installMySQL.RollbackOnFailure)
+	e.SetParameterProfile(installMySQL.ParameterProfile, installMySQL.ExtraParameters)
+	e.SetAutoSizing(installMySQL.AutoSizing)
 
 	jsonBytes, err := json.Marshal(installMySQL.Addrs)
 	if err != nil {
@@ -73,13 +82,13 @@ func Install(c *gin.Context) {
 	jsonStr := string(jsonBytes)
 
 	s := mysql.NewServiceWithDefault(e)
-	err = s.Install()
+	operationID, err := s.Install()
 	if err != nil {
 		resp.ResponseNOK(c, msgMySQL.ErrMySQLServiceInstallMySQL, err,
 			installMySQL.MySQLServerParam.Version, installMySQL.Mode, jsonStr)
 		return
 	}
 
-	resp.ResponseOK(c, fmt.Sprintf(installMySQLMessage, installMySQL.MySQLServerParam.Version, installMySQL.Mode, jsonStr),
-		msgMySQL.InfoMySQLServiceInstallMySQL, installMySQL.MySQLServerParam.Version, installMySQL.Mode, jsonStr)
+	resp.ResponseOK(c, fmt.Sprintf(installMySQLMessage, operationID, installMySQL.MySQLServerParam.Version, installMySQL.Mode, jsonStr),
+		msgMySQL.InfoMySQLServiceInstallMySQL, operationID, installMySQL.MySQLServerParam.Version, installMySQL.Mode, jsonStr)
 }
@@ -0,0 +1,174 @@
+package mysql
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hashicorp/go-version"
+	"github.com/pingcap/errors"
+	"github.com/romberli/go-util/constant"
+	"github.com/romberli/go-util/linux"
+
+	"github.com/romberli/db-operator/module/implement/mysql"
+	"github.com/romberli/db-operator/module/implement/mysql/mode"
+	"github.com/romberli/db-operator/module/implement/mysql/parameter"
+	"github.com/romberli/db-operator/pkg/message"
+	"github.com/romberli/db-operator/pkg/resp"
+
+	jsonmysql "github.com/romberli/db-operator/pkg/json/mysql"
+	msgMySQL "github.com/romberli/db-operator/pkg/message/mysql"
+)
+
+const (
+	addrParam = "addr"
+
+	startInstanceMessage   = `{"operation_id": %d, "addrs": %s, "message": "start mysql server started"}`
+	stopInstanceMessage    = `{"operation_id": %d, "slow_shutdown": %t, "addrs": %s, "message": "stop mysql server started"}`
+	restartInstanceMessage = `{"operation_id": %d, "slow_shutdown": %t, "addrs": %s, "message": "restart mysql server started"}`
+)
+
+// @Tags mysql
+// @Summary start mysql server with mysqld_multi asynchronously, it returns the operation id immediately
+// @Accept	application/json
+// @Param	token	 			body string 			   true  "token"
+// @Param   addrs 				body []string 			   true  "addrs"
+// @Param   mysqlServerParam	body *parameter.MySQLServer false "mysql_server_param"
+// @Produce application/json
+// @Success 200 {string} string "{"operation_id": 3, "addrs": ["192.168.137.11:3306"], "message": "start mysql server started"}"
+// @Router	/api/v1/mysql/instance/start [post]
+func StartInstance(c *gin.Context) {
+	_, s, jsonStr, ok := newInstanceLifecycleService(c)
+	if !ok {
+		return
+	}
+
+	operationID, err := s.Start()
+	if err != nil {
+		resp.ResponseNOK(c, msgMySQL.ErrMySQLServiceStartInstance, err, jsonStr)
+		return
+	}
+
+	resp.ResponseOK(c, fmt.Sprintf(startInstanceMessage, operationID, jsonStr),
+		msgMySQL.InfoMySQLServiceStartInstance, operationID, jsonStr)
+}
+
+// @Tags mysql
+// @Summary stop mysql server with mysqld_multi asynchronously, it returns the operation id immediately
+// @Accept	application/json
+// @Param	token	 			body string 			   true  "token"
+// @Param   addrs 				body []string 			   true  "addrs"
+// @Param   slow_shutdown 		body bool 				   false "set innodb_fast_shutdown to 0 before shutting down"
+// @Param   mysqlServerParam	body *parameter.MySQLServer false "mysql_server_param"
+// @Produce application/json
+// @Success 200 {string} string "{"operation_id": 4, "slow_shutdown": true, "addrs": ["192.168.137.11:3306"], "message": "stop mysql server started"}"
+// @Router	/api/v1/mysql/instance/stop [post]
+func StopInstance(c *gin.Context) {
+	instanceLifecycle, s, jsonStr, ok := newInstanceLifecycleService(c)
+	if !ok {
+		return
+	}
+
+	operationID, err := s.Stop(instanceLifecycle.SlowShutdown)
+	if err != nil {
+		resp.ResponseNOK(c, msgMySQL.ErrMySQLServiceStopInstance, err, instanceLifecycle.SlowShutdown, jsonStr)
+		return
+	}
+
+	resp.ResponseOK(c, fmt.Sprintf(stopInstanceMessage, operationID, instanceLifecycle.SlowShutdown, jsonStr),
+		msgMySQL.InfoMySQLServiceStopInstance, operationID, instanceLifecycle.SlowShutdown, jsonStr)
+}
+
+// @Tags mysql
+// @Summary restart mysql server with mysqld_multi asynchronously, it returns the operation id immediately
+// @Accept	application/json
+// @Param	token	 			body string 			   true  "token"
+// @Param   addrs 				body []string 			   true  "addrs"
+// @Param   slow_shutdown 		body bool 				   false "set innodb_fast_shutdown to 0 before shutting down"
+// @Param   mysqlServerParam	body *parameter.MySQLServer false "mysql_server_param"
+// @Produce application/json
+// @Success 200 {string} string "{"operation_id": 5, "slow_shutdown": false, "addrs": ["192.168.137.11:3306"], "message": "restart mysql server started"}"
+// @Router	/api/v1/mysql/instance/restart [post]
+func RestartInstance(c *gin.Context) {
+	instanceLifecycle, s, jsonStr, ok := newInstanceLifecycleService(c)
+	if !ok {
+		return
+	}
+
+	operationID, err := s.Restart(instanceLifecycle.SlowShutdown)
+	if err != nil {
+		resp.ResponseNOK(c, msgMySQL.ErrMySQLServiceRestartInstance, err, instanceLifecycle.SlowShutdown, jsonStr)
+		return
+	}
+
+	resp.ResponseOK(c, fmt.Sprintf(restartInstanceMessage, operationID, instanceLifecycle.SlowShutdown, jsonStr),
+		msgMySQL.InfoMySQLServiceRestartInstance, operationID, instanceLifecycle.SlowShutdown, jsonStr)
+}
+
+// newInstanceLifecycleService parses the request and returns the request, the service and the json string of the addrs,
+// it responds the error and returns false if anything went wrong
+func newInstanceLifecycleService(c *gin.Context) (*jsonmysql.InstanceLifecycle, *mysql.Service, string, bool) {
+	data, err := c.GetRawData()
+	if err != nil {
+		resp.ResponseNOK(c, message.ErrGetRawData, errors.Trace(err))
+		return nil, nil, constant.EmptyString, false
+	}
+
+	instanceLifecycle := jsonmysql.NewInstanceLifecycleWithDefault()
+	err = instanceLifecycle.Unmarshal(data)
+	if err != nil {
+		resp.ResponseNOK(c, message.ErrUnmarshalRawData, errors.Trace(err))
+		return nil, nil, constant.EmptyString, false
+	}
+	mysqlVersion, err := version.NewVersion(instanceLifecycle.MySQLServerParam.Version)
+	if err != nil {
+		resp.ResponseNOK(c, msgMySQL.ErrMySQLNotValidConfigMySQLVersion, errors.Trace(err))
+		return nil, nil, constant.EmptyString, false
+	}
+	err = linux.SortAddrs(instanceLifecycle.Addrs)
+	if err != nil {
+		resp.ResponseNOK(c, message.ErrSortAddrs, err, instanceLifecycle.Addrs)
+		return nil, nil, constant.EmptyString, false
+	}
+
+	e := mysql.NewEngineWithDefault(
+		mysqlVersion,
+		mode.Standalone,
+		instanceLifecycle.Addrs,
+		instanceLifecycle.MySQLServerParam,
+		parameter.NewPMMClientWithDefault(),
+	)
+
+	jsonBytes, err := json.Marshal(instanceLifecycle.Addrs)
+	if err != nil {
+		resp.ResponseNOK(c, message.ErrMarshalData, errors.Trace(err))
+		return nil, nil, constant.EmptyString, false
+	}
+
+	return instanceLifecycle, mysql.NewServiceWithDefault(e), string(jsonBytes), true
+}
+
+// @Tags mysql
+// @Summary compare the instance section rendered from the template with the default parameters, the instance section of /etc/my.cnf and the runtime variables of the instance
+// @Accept	application/json
+// @Param	token	body string	true "token"
+// @Param	addr	path string	true "addr of the instance, formatted as host:port"
+// @Produce application/json
+// @Success 200 {string} string "{"addr": "192.168.137.11:3306", "runtime_error": "", "drifts": [{"name": "max_connections", "template": "2000", "file": "2000", "runtime": "3000", "template_file_drift": false, "file_runtime_drift": true}]}"
+// @Router	/api/v1/mysql/instance/:addr/drift [get]
+func GetConfigDrift(c *gin.Context) {
+	addr := c.Param(addrParam)
+	instanceDrift, err := mysql.GetConfigDriftByAddr(addr)
+	if err != nil {
+		resp.ResponseNOK(c, msgMySQL.ErrMySQLServiceGetConfigDrift, err, addr)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(instanceDrift)
+	if err != nil {
+		resp.ResponseNOK(c, message.ErrMarshalData, errors.Trace(err))
+		return
+	}
+
+	resp.ResponseOK(c, string(jsonBytes), msgMySQL.InfoMySQLServiceGetConfigDrift, addr)
+}
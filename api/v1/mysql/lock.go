@@ -0,0 +1,88 @@
+package mysql
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pingcap/errors"
+	"github.com/romberli/log"
+
+	"github.com/romberli/db-operator/module/implement/mysql"
+	"github.com/romberli/db-operator/pkg/message"
+	"github.com/romberli/db-operator/pkg/resp"
+
+	jsonmysql "github.com/romberli/db-operator/pkg/json/mysql"
+	msgMySQL "github.com/romberli/db-operator/pkg/message/mysql"
+)
+
+const (
+	releaseLockMessage = `{"addr": "%s", "operation_id": %d, "owner": "%s", "message": "lock released"}`
+)
+
+// @Tags mysql
+// @Summary get the operation locks, expired is 1 if the owner has not renewed the lock within the lease timeout
+// @Accept	application/json
+// @Param	token	body string true "token"
+// @Produce application/json
+// @Success 200 {string} string "[{"id": 1, "operation_id": 6, "addr": "192.168.137.11:3306", "owner": "dbo-host:12345", "expired": 0, "del_flag": 0, "create_time": "2024-01-01T10:00:00+08:00", "last_update_time": "2024-01-01T10:00:20+08:00"}]"
+// @Router	/api/v1/mysql/lock [get]
+func GetLocks(c *gin.Context) {
+	locks, err := mysql.NewDBORepoWithDefault().GetLocks()
+	if err != nil {
+		resp.ResponseNOK(c, msgMySQL.ErrMySQLServiceGetLocks, err)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(locks)
+	if err != nil {
+		resp.ResponseNOK(c, message.ErrMarshalData, errors.Trace(err))
+		return
+	}
+
+	resp.ResponseOK(c, string(jsonBytes), msgMySQL.InfoMySQLServiceGetLocks)
+}
+
+// @Tags mysql
+// @Summary force release the operation lock of the addr no matter which process owns it, the operation which held the lock is not stopped
+// @Accept	application/json
+// @Param	token	body string true "token"
+// @Param	addr	body string true "addr of the instance, formatted as host:port"
+// @Produce application/json
+// @Success 200 {string} string "{"addr": "192.168.137.11:3306", "operation_id": 6, "owner": "dbo-host:12345", "message": "lock released"}"
+// @Router	/api/v1/mysql/lock [delete]
+func ReleaseLock(c *gin.Context) {
+	data, err := c.GetRawData()
+	if err != nil {
+		resp.ResponseNOK(c, message.ErrGetRawData, errors.Trace(err))
+		return
+	}
+
+	releaseLock := jsonmysql.NewReleaseLockWithDefault()
+	err = releaseLock.Unmarshal(data)
+	if err != nil {
+		resp.ResponseNOK(c, message.ErrUnmarshalRawData, errors.Trace(err))
+		return
+	}
+
+	repo := mysql.NewDBORepoWithDefault()
+	lock, err := repo.GetLockByAddr(releaseLock.Addr)
+	if err != nil {
+		resp.ResponseNOK(c, msgMySQL.ErrMySQLServiceReleaseLock, err, releaseLock.Addr)
+		return
+	}
+	if lock == nil {
+		resp.ResponseNOK(c, msgMySQL.ErrMySQLServiceReleaseLock, errors.New("the addr is not locked"), releaseLock.Addr)
+		return
+	}
+	err = repo.ForceReleaseLock(releaseLock.Addr)
+	if err != nil {
+		resp.ResponseNOK(c, msgMySQL.ErrMySQLServiceReleaseLock, err, releaseLock.Addr)
+		return
+	}
+	log.Warnf("mysql ReleaseLock(): the lock was force released. addr: %s, operationID: %d, owner: %s",
+		lock.Addr, lock.OperationID, lock.Owner)
+
+	resp.ResponseOK(c, fmt.Sprintf(releaseLockMessage, lock.Addr, lock.OperationID, lock.Owner),
+		msgMySQL.InfoMySQLServiceReleaseLock, lock.Addr, lock.OperationID, lock.Owner)
+}
@@ -0,0 +1,176 @@
+package mysql
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hashicorp/go-version"
+	"github.com/pingcap/errors"
+	"github.com/romberli/go-util/constant"
+
+	"github.com/romberli/db-operator/module/implement/mysql"
+	"github.com/romberli/db-operator/pkg/message"
+	"github.com/romberli/db-operator/pkg/resp"
+
+	jsonmysql "github.com/romberli/db-operator/pkg/json/mysql"
+	msgMySQL "github.com/romberli/db-operator/pkg/message/mysql"
+)
+
+const (
+	operationIDParam       = "id"
+	resumeOperationMessage = `{"operation_id": %d, "message": "resume operation started"}`
+	cancelOperationMessage = `{"operation_id": %d, "message": "cancel operation requested"}`
+)
+
+// @Tags mysql
+// @Summary get operation history
+// @Accept	application/json
+// @Param	token	body string true "token"
+// @Param	id		path int	true "operation id"
+// @Produce application/json
+// @Success 200 {string} string "{"id": 1, "operation_type": 1, "addrs": "192.168.137.11:3306,192.168.137.12:3306", "status": 2, "message": "install mysql server completed.", "del_flag": 0, "create_time": "2023-10-01T10:00:00+08:00", "last_update_time": "2023-10-01T10:05:00+08:00"}"
+// @Router	/api/v1/mysql/operation/:id [get]
+func GetOperation(c *gin.Context) {
+	operationID, ok := getOperationID(c)
+	if !ok {
+		return
+	}
+
+	operationInfo, err := mysql.NewDBORepoWithDefault().GetOperationHistory(operationID)
+	if err != nil {
+		resp.ResponseNOK(c, msgMySQL.ErrMySQLServiceGetOperationHistory, err, operationID)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(operationInfo)
+	if err != nil {
+		resp.ResponseNOK(c, message.ErrMarshalData, errors.Trace(err))
+		return
+	}
+
+	resp.ResponseOK(c, string(jsonBytes), msgMySQL.InfoMySQLServiceGetOperationHistory, operationID)
+}
+
+// @Tags mysql
+// @Summary get operation details
+// @Accept	application/json
+// @Param	token	body string true "token"
+// @Param	id		path int	true "operation id"
+// @Produce application/json
+// @Success 200 {string} string "[{"id": 1, "operation_id": 1, "host_ip": "192.168.137.11", "port_num": 3306, "status": 2, "message": "install mysql server completed.", "del_flag": 0, "create_time": "2023-10-01T10:00:00+08:00", "last_update_time": "2023-10-01T10:05:00+08:00"}]"
+// @Router	/api/v1/mysql/operation/:id/detail [get]
+func GetOperationDetail(c *gin.Context) {
+	operationID, ok := getOperationID(c)
+	if !ok {
+		return
+	}
+
+	operationDetails, err := mysql.NewDBORepoWithDefault().GetOperationDetails(operationID)
+	if err != nil {
+		resp.ResponseNOK(c, msgMySQL.ErrMySQLServiceGetOperationDetails, err, operationID)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(operationDetails)
+	if err != nil {
+		resp.ResponseNOK(c, message.ErrMarshalData, errors.Trace(err))
+		return
+	}
+
+	resp.ResponseOK(c, string(jsonBytes), msgMySQL.InfoMySQLServiceGetOperationDetails, operationID)
+}
+
+// @Tags mysql
+// @Summary resume the failed install operation asynchronously, the steps which were completed in the previous run will be skipped, if the failed instance was rolled back, the steps undone by the rollback will be run again
+// @Accept	application/json
+// @Param	token	body string true "token"
+// @Param	id		path int	true "operation id"
+// @Produce application/json
+// @Success 200 {string} string "{"operation_id": 1, "message": "resume operation started"}"
+// @Router	/api/v1/mysql/operation/:id/resume [post]
+func ResumeOperation(c *gin.Context) {
+	operationID, ok := getOperationID(c)
+	if !ok {
+		return
+	}
+
+	operationInfo, err := mysql.NewDBORepoWithDefault().GetOperationHistory(operationID)
+	if err != nil {
+		resp.ResponseNOK(c, msgMySQL.ErrMySQLServiceGetOperationHistory, err, operationID)
+		return
+	}
+	if !operationInfo.IsResumable() {
+		resp.ResponseNOK(c, msgMySQL.ErrMySQLServiceNotResumableOperation, operationID, operationInfo.OperationType, operationInfo.Status)
+		return
+	}
+	// rebuild the engine with the request of the operation
+	installMySQL := jsonmysql.NewInstallMySQLWithDefault()
+	err = installMySQL.Unmarshal([]byte(operationInfo.RequestBody))
+	if err != nil {
+		resp.ResponseNOK(c, message.ErrUnmarshalRawData, errors.Trace(err))
+		return
+	}
+	mysqlVersion, err := version.NewVersion(installMySQL.MySQLServerParam.Version)
+	if err != nil {
+		resp.ResponseNOK(c, msgMySQL.ErrMySQLNotValidConfigMySQLVersion, errors.Trace(err))
+		return
+	}
+
+	e := mysql.NewEngineWithDefault(
+		mysqlVersion,
+		installMySQL.Mode,
+		installMySQL.Addrs,
+		installMySQL.MySQLServerParam,
+		installMySQL.PMMClientParam,
+	)
+	e.SetClusterName(installMySQL.ClusterName)
+	e.SetRollbackOnFailure(installMySQL.RollbackOnFailure)
+	e.SetAutoSizing(installMySQL.AutoSizing)
+
+	s := mysql.NewServiceWithDefault(e)
+	err = s.Resume(operationID)
+	if err != nil {
+		resp.ResponseNOK(c, msgMySQL.ErrMySQLServiceResumeOperation, err, operationID)
+		return
+	}
+
+	resp.ResponseOK(c, fmt.Sprintf(resumeOperationMessage, operationID), msgMySQL.InfoMySQLServiceResumeOperation, operationID)
+}
+
+// @Tags mysql
+// @Summary cancel the running operation, it stops at the next step boundary and the running remote commands are killed
+// @Accept	application/json
+// @Param	token	body string true "token"
+// @Param	id		path int	true "operation id"
+// @Produce application/json
+// @Success 200 {string} string "{"operation_id": 1, "message": "cancel operation requested"}"
+// @Router	/api/v1/mysql/operation/:id/cancel [post]
+func CancelOperation(c *gin.Context) {
+	operationID, ok := getOperationID(c)
+	if !ok {
+		return
+	}
+
+	err := mysql.NewOperationCancellerWithDefault().Cancel(operationID)
+	if err != nil {
+		resp.ResponseNOK(c, msgMySQL.ErrMySQLServiceCancelOperation, err, operationID)
+		return
+	}
+
+	resp.ResponseOK(c, fmt.Sprintf(cancelOperationMessage, operationID), msgMySQL.InfoMySQLServiceCancelOperation, operationID)
+}
+
+// getOperationID gets the operation id from the path, if the operation id is not valid,
+// it responses the error to the client and returns false
+func getOperationID(c *gin.Context) (int, bool) {
+	operationIDStr := c.Param(operationIDParam)
+	operationID, err := strconv.Atoi(operationIDStr)
+	if err != nil || operationID <= constant.ZeroInt {
+		resp.ResponseNOK(c, msgMySQL.ErrMySQLServiceNotValidOperationID, operationIDStr)
+		return constant.ZeroInt, false
+	}
+
+	return operationID, true
+}
@@ -0,0 +1,118 @@
+package mysql
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hashicorp/go-version"
+	"github.com/pingcap/errors"
+	"github.com/romberli/go-util/linux"
+
+	"github.com/romberli/db-operator/module/implement/mysql"
+	"github.com/romberli/db-operator/module/implement/mysql/parameter"
+	"github.com/romberli/db-operator/pkg/message"
+	"github.com/romberli/db-operator/pkg/resp"
+
+	jsonmysql "github.com/romberli/db-operator/pkg/json/mysql"
+	msgMySQL "github.com/romberli/db-operator/pkg/message/mysql"
+)
+
+const (
+	setParametersMessage = `{"operation_id": %d, "variables": %s, "restart_required": %s, "addrs": %s, "message": "set parameters started"}`
+)
+
+// @Tags mysql
+// @Summary set the variables of mysql server asynchronously, it returns the operation id and the static variables which require a restart immediately
+// @Accept	application/json
+// @Param	token	 			body string 			   true  "token"
+// @Param 	mode 				body int  				   false "mode, the group replication variables and the semi-sync variables are only allowed in their own modes, default is standalone"
+// @Param   addrs 				body []string 			   true  "addrs"
+// @Param   variables 			body map[string]string	   true  "variables, the dynamic ones are changed with set persist, the static ones are written into the config file"
+// @Param   mysqlServerParam	body *parameter.MySQLServer false "mysql_server_param"
+// @Produce application/json
+// @Success 200 {string} string "{"operation_id": 6, "variables": {"max_connections": "3000", "innodb_buffer_pool_instances": "8"}, "restart_required": ["innodb_buffer_pool_instances"], "addrs": ["192.168.137.11:3306"], "message": "set parameters started"}"
+// @Router	/api/v1/mysql/parameter/set [post]
+func SetParameters(c *gin.Context) {
+	data, err := c.GetRawData()
+	if err != nil {
+		resp.ResponseNOK(c, message.ErrGetRawData, errors.Trace(err))
+		return
+	}
+
+	setParameters := jsonmysql.NewSetParametersWithDefault()
+	err = setParameters.Unmarshal(data)
+	if err != nil {
+		resp.ResponseNOK(c, message.ErrUnmarshalRawData, errors.Trace(err))
+		return
+	}
+	mysqlVersion, err := version.NewVersion(setParameters.MySQLServerParam.Version)
+	if err != nil {
+		resp.ResponseNOK(c, msgMySQL.ErrMySQLNotValidConfigMySQLVersion, errors.Trace(err))
+		return
+	}
+	err = linux.SortAddrs(setParameters.Addrs)
+	if err != nil {
+		resp.ResponseNOK(c, message.ErrSortAddrs, err, setParameters.Addrs)
+		return
+	}
+
+	e := mysql.NewEngineWithDefault(
+		mysqlVersion,
+		setParameters.Mode,
+		setParameters.Addrs,
+		setParameters.MySQLServerParam,
+		parameter.NewPMMClientWithDefault(),
+	)
+
+	addrsBytes, err := json.Marshal(setParameters.Addrs)
+	if err != nil {
+		resp.ResponseNOK(c, message.ErrMarshalData, errors.Trace(err))
+		return
+	}
+	addrsStr := string(addrsBytes)
+	variablesBytes, err := json.Marshal(setParameters.Variables)
+	if err != nil {
+		resp.ResponseNOK(c, message.ErrMarshalData, errors.Trace(err))
+		return
+	}
+	variablesStr := string(variablesBytes)
+
+	s := mysql.NewServiceWithDefault(e)
+	operationID, restartRequired, err := s.SetParameters(setParameters.Variables)
+	if err != nil {
+		resp.ResponseNOK(c, msgMySQL.ErrMySQLServiceSetParameters, err, variablesStr, addrsStr)
+		return
+	}
+
+	restartRequiredBytes, err := json.Marshal(restartRequired)
+	if err != nil {
+		resp.ResponseNOK(c, message.ErrMarshalData, errors.Trace(err))
+		return
+	}
+	restartRequiredStr := string(restartRequiredBytes)
+
+	resp.ResponseOK(c, fmt.Sprintf(setParametersMessage, operationID, variablesStr, restartRequiredStr, addrsStr),
+		msgMySQL.InfoMySQLServiceSetParameters, operationID, variablesStr, restartRequiredStr, addrsStr)
+}
+
+// @Tags mysql
+// @Summary get the parameter profiles which could be selected when installing mysql server
+// @Produce application/json
+// @Success 200 {string} string "[{"id": 1, "profile_name": "oltp", "description": "...", "parameters": "{\"sort_buffer_size\": \"2M\"}", "del_flag": 0, "create_time": "2024-01-01T00:00:00+08:00", "last_update_time": "2024-01-01T00:00:00+08:00"}]"
+// @Router	/api/v1/mysql/parameter/profile [get]
+func GetParameterProfiles(c *gin.Context) {
+	parameterProfiles, err := mysql.NewDBORepoWithDefault().GetParameterProfiles()
+	if err != nil {
+		resp.ResponseNOK(c, msgMySQL.ErrMySQLServiceGetParameterProfiles, err)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(parameterProfiles)
+	if err != nil {
+		resp.ResponseNOK(c, message.ErrMarshalData, errors.Trace(err))
+		return
+	}
+
+	resp.ResponseOK(c, string(jsonBytes), msgMySQL.InfoMySQLServiceGetParameterProfiles)
+}
@@ -0,0 +1,85 @@
+package mysql
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hashicorp/go-version"
+	"github.com/pingcap/errors"
+	"github.com/romberli/go-util/linux"
+
+	"github.com/romberli/db-operator/module/implement/mysql"
+	"github.com/romberli/db-operator/module/implement/mysql/mode"
+	"github.com/romberli/db-operator/pkg/message"
+	"github.com/romberli/db-operator/pkg/resp"
+
+	jsonmysql "github.com/romberli/db-operator/pkg/json/mysql"
+	msgMySQL "github.com/romberli/db-operator/pkg/message/mysql"
+)
+
+const (
+	removeMySQLMessage = `{"operation_id": %d, "archive": %t, "remove_binary": %t, "addrs": %s, "message": "remove mysql server started"}`
+)
+
+// @Tags mysql
+// @Summary remove mysql server asynchronously, it returns the operation id immediately
+// @Accept	application/json
+// @Param	token	 			body string 			   true  "token"
+// @Param   addrs 				body []string 			   true  "addrs"
+// @Param   archive 			body bool 				   false "archive the data and log directories instead of deleting them"
+// @Param   remove_binary 		body bool 				   false "remove the mysql binary if no other instance on the host is using it"
+// @Param   mysqlServerParam	body *parameter.MySQLServer false "mysql_server_param"
+// @Param	pmmClientParam		body *parameter.PMMClient   false "pmm_client_param"
+// @Produce application/json
+// @Success 200 {string} string "{"operation_id": 2, "archive": true, "remove_binary": false, "addrs": ["192.168.137.11:3306", "192.168.137.12:3306"], "message": "remove mysql server started"}"
+// @Router	/api/v1/mysql/remove [post]
+func Remove(c *gin.Context) {
+	data, err := c.GetRawData()
+	if err != nil {
+		resp.ResponseNOK(c, message.ErrGetRawData, errors.Trace(err))
+		return
+	}
+
+	removeMySQL := jsonmysql.NewRemoveMySQLWithDefault()
+	err = removeMySQL.Unmarshal(data)
+	if err != nil {
+		resp.ResponseNOK(c, message.ErrUnmarshalRawData, errors.Trace(err))
+		return
+	}
+	mysqlVersion, err := version.NewVersion(removeMySQL.MySQLServerParam.Version)
+	if err != nil {
+		resp.ResponseNOK(c, msgMySQL.ErrMySQLNotValidConfigMySQLVersion, errors.Trace(err))
+		return
+	}
+	err = linux.SortAddrs(removeMySQL.Addrs)
+	if err != nil {
+		resp.ResponseNOK(c, message.ErrSortAddrs, err, removeMySQL.Addrs)
+		return
+	}
+
+	e := mysql.NewEngineWithDefault(
+		mysqlVersion,
+		mode.Standalone,
+		removeMySQL.Addrs,
+		removeMySQL.MySQLServerParam,
+		removeMySQL.PMMClientParam,
+	)
+
+	jsonBytes, err := json.Marshal(removeMySQL.Addrs)
+	if err != nil {
+		resp.ResponseNOK(c, message.ErrMarshalData, errors.Trace(err))
+		return
+	}
+	jsonStr := string(jsonBytes)
+
+	s := mysql.NewServiceWithDefault(e)
+	operationID, err := s.Remove(removeMySQL.Archive, removeMySQL.RemoveBinary)
+	if err != nil {
+		resp.ResponseNOK(c, msgMySQL.ErrMySQLServiceRemoveMySQL, err, removeMySQL.Archive, removeMySQL.RemoveBinary, jsonStr)
+		return
+	}
+
+	resp.ResponseOK(c, fmt.Sprintf(removeMySQLMessage, operationID, removeMySQL.Archive, removeMySQL.RemoveBinary, jsonStr),
+		msgMySQL.InfoMySQLServiceRemoveMySQL, operationID, removeMySQL.Archive, removeMySQL.RemoveBinary, jsonStr)
+}
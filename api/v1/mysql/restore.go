@@ -0,0 +1,71 @@
+package mysql
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hashicorp/go-version"
+	"github.com/pingcap/errors"
+
+	"github.com/romberli/db-operator/module/implement/mysql"
+	"github.com/romberli/db-operator/module/implement/mysql/mode"
+	"github.com/romberli/db-operator/module/implement/mysql/parameter"
+	"github.com/romberli/db-operator/pkg/message"
+	"github.com/romberli/db-operator/pkg/resp"
+
+	jsonmysql "github.com/romberli/db-operator/pkg/json/mysql"
+	msgMySQL "github.com/romberli/db-operator/pkg/message/mysql"
+)
+
+const (
+	restoreMessage = `{"operation_id": %d, "backup_id": %d, "stop_gtid": "%s", "stop_datetime": "%s", "addr": "%s", "message": "restore mysql server started"}`
+)
+
+// @Tags mysql
+// @Summary install a fresh mysql instance from the backup set and replay the binlogs of the instance where the backup set was taken asynchronously, it returns the operation id immediately
+// @Accept	application/json
+// @Param	token	 			body string 			   true  "token"
+// @Param   addr 				body string 			   true  "addr of the new instance, formatted as host:port"
+// @Param   backup_id 			body int 				   true  "id of the backup set"
+// @Param   stop_gtid 			body string 			   false "the transaction of the gtid and the later transactions of the same source uuid will not be replayed"
+// @Param   stop_datetime 		body string 			   false "the transactions from the datetime will not be replayed, formatted as 2006-01-02 15:04:05"
+// @Param   mysqlServerParam	body *parameter.MySQLServer false "mysql_server_param"
+// @Produce application/json
+// @Success 200 {string} string "{"operation_id": 7, "backup_id": 1, "stop_gtid": "", "stop_datetime": "2024-01-01 12:00:00", "addr": "192.168.137.12:3306", "message": "restore mysql server started"}"
+// @Router	/api/v1/mysql/restore [post]
+func Restore(c *gin.Context) {
+	data, err := c.GetRawData()
+	if err != nil {
+		resp.ResponseNOK(c, message.ErrGetRawData, errors.Trace(err))
+		return
+	}
+
+	restore := jsonmysql.NewRestoreWithDefault()
+	err = restore.Unmarshal(data)
+	if err != nil {
+		resp.ResponseNOK(c, message.ErrUnmarshalRawData, errors.Trace(err))
+		return
+	}
+	mysqlVersion, err := version.NewVersion(restore.MySQLServerParam.Version)
+	if err != nil {
+		resp.ResponseNOK(c, msgMySQL.ErrMySQLNotValidConfigMySQLVersion, errors.Trace(err))
+		return
+	}
+
+	e := mysql.NewEngineWithDefault(
+		mysqlVersion,
+		mode.Standalone,
+		[]string{restore.Addr},
+		restore.MySQLServerParam,
+		parameter.NewPMMClientWithDefault(),
+	)
+	s := mysql.NewServiceWithDefault(e)
+	operationID, err := s.Restore(restore.BackupID, restore.StopGTID, restore.StopDatetime)
+	if err != nil {
+		resp.ResponseNOK(c, msgMySQL.ErrMySQLServiceRestore, err, restore.BackupID, restore.StopGTID, restore.StopDatetime, restore.Addr)
+		return
+	}
+
+	resp.ResponseOK(c, fmt.Sprintf(restoreMessage, operationID, restore.BackupID, restore.StopGTID, restore.StopDatetime, restore.Addr),
+		msgMySQL.InfoMySQLServiceRestore, operationID, restore.BackupID, restore.StopGTID, restore.StopDatetime, restore.Addr)
+}
@@ -0,0 +1,84 @@
+package mysql
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hashicorp/go-version"
+	"github.com/pingcap/errors"
+	"github.com/romberli/go-util/linux"
+
+	"github.com/romberli/db-operator/module/implement/mysql"
+	"github.com/romberli/db-operator/pkg/message"
+	"github.com/romberli/db-operator/pkg/resp"
+
+	jsonmysql "github.com/romberli/db-operator/pkg/json/mysql"
+	msgMySQL "github.com/romberli/db-operator/pkg/message/mysql"
+)
+
+const (
+	switchoverMessage = `{"operation_id": %d, "mode": %d, "source_addr": "%s", "target_addr": "%s", "addrs": %s, "message": "switchover started"}`
+)
+
+// @Tags mysql
+// @Summary promote the target replica to be the new source in a planned way asynchronously, it returns the operation id immediately
+// @Accept	application/json
+// @Param	token	 			body string 			   true  "token"
+// @Param 	mode 				body int  				   true  "mode, only async replication and semi-sync replication are supported"
+// @Param	sourceAddr			body string 			   true  "source_addr"
+// @Param	targetAddr			body string 			   true  "target_addr"
+// @Param   addrs 				body []string 			   true  "all the members of the cluster, including the source and the target"
+// @Param   mysqlServerParam	body *parameter.MySQLServer false "mysql_server_param"
+// @Produce application/json
+// @Success 200 {string} string "{"operation_id": 4, "mode": 1, "source_addr": "192.168.137.11:3306", "target_addr": "192.168.137.12:3306", "addrs": ["192.168.137.11:3306", "192.168.137.12:3306"], "message": "switchover started"}"
+// @Router	/api/v1/mysql/switchover [post]
+func Switchover(c *gin.Context) {
+	data, err := c.GetRawData()
+	if err != nil {
+		resp.ResponseNOK(c, message.ErrGetRawData, errors.Trace(err))
+		return
+	}
+
+	switchover := jsonmysql.NewSwitchoverWithDefault()
+	err = switchover.Unmarshal(data)
+	if err != nil {
+		resp.ResponseNOK(c, message.ErrUnmarshalRawData, errors.Trace(err))
+		return
+	}
+	mysqlVersion, err := version.NewVersion(switchover.MySQLServerParam.Version)
+	if err != nil {
+		resp.ResponseNOK(c, msgMySQL.ErrMySQLNotValidConfigMySQLVersion, errors.Trace(err))
+		return
+	}
+	err = linux.SortAddrs(switchover.Addrs)
+	if err != nil {
+		resp.ResponseNOK(c, message.ErrSortAddrs, err, switchover.Addrs)
+		return
+	}
+
+	e := mysql.NewEngineWithDefault(
+		mysqlVersion,
+		switchover.Mode,
+		switchover.Addrs,
+		switchover.MySQLServerParam,
+		nil,
+	)
+
+	jsonBytes, err := json.Marshal(switchover.Addrs)
+	if err != nil {
+		resp.ResponseNOK(c, message.ErrMarshalData, errors.Trace(err))
+		return
+	}
+	jsonStr := string(jsonBytes)
+
+	s := mysql.NewServiceWithDefault(e)
+	operationID, err := s.Switchover(switchover.SourceAddr, switchover.TargetAddr)
+	if err != nil {
+		resp.ResponseNOK(c, msgMySQL.ErrMySQLServiceSwitchover, err, switchover.Mode, switchover.SourceAddr, switchover.TargetAddr, jsonStr)
+		return
+	}
+
+	resp.ResponseOK(c, fmt.Sprintf(switchoverMessage, operationID, switchover.Mode, switchover.SourceAddr, switchover.TargetAddr, jsonStr),
+		msgMySQL.InfoMySQLServiceSwitchover, operationID, switchover.Mode, switchover.SourceAddr, switchover.TargetAddr, jsonStr)
+}
@@ -0,0 +1,83 @@
+package mysql
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hashicorp/go-version"
+	"github.com/pingcap/errors"
+	"github.com/romberli/go-util/linux"
+
+	"github.com/romberli/db-operator/module/implement/mysql"
+	"github.com/romberli/db-operator/module/implement/mysql/mode"
+	"github.com/romberli/db-operator/module/implement/mysql/parameter"
+	"github.com/romberli/db-operator/pkg/message"
+	"github.com/romberli/db-operator/pkg/resp"
+
+	jsonmysql "github.com/romberli/db-operator/pkg/json/mysql"
+	msgMySQL "github.com/romberli/db-operator/pkg/message/mysql"
+)
+
+const (
+	upgradeMySQLMessage = `{"operation_id": %d, "version": "%s", "addrs": %s, "message": "upgrade mysql server started"}`
+)
+
+// @Tags mysql
+// @Summary upgrade mysql server to a newer minor version in place asynchronously, it returns the operation id immediately
+// @Accept	application/json
+// @Param	token	 			body string 			   true "token"
+// @Param   addrs 				body []string 			   true "addrs"
+// @Param   mysqlServerParam	body *parameter.MySQLServer true "mysql_server_param, the version is the target version"
+// @Produce application/json
+// @Success 200 {string} string "{"operation_id": 3, "version": "8.0.34", "addrs": ["192.168.137.11:3306", "192.168.137.12:3306"], "message": "upgrade mysql server started"}"
+// @Router	/api/v1/mysql/upgrade [post]
+func Upgrade(c *gin.Context) {
+	data, err := c.GetRawData()
+	if err != nil {
+		resp.ResponseNOK(c, message.ErrGetRawData, errors.Trace(err))
+		return
+	}
+
+	upgradeMySQL := jsonmysql.NewUpgradeMySQLWithDefault()
+	err = upgradeMySQL.Unmarshal(data)
+	if err != nil {
+		resp.ResponseNOK(c, message.ErrUnmarshalRawData, errors.Trace(err))
+		return
+	}
+	mysqlVersion, err := version.NewVersion(upgradeMySQL.MySQLServerParam.Version)
+	if err != nil {
+		resp.ResponseNOK(c, msgMySQL.ErrMySQLNotValidConfigMySQLVersion, errors.Trace(err))
+		return
+	}
+	err = linux.SortAddrs(upgradeMySQL.Addrs)
+	if err != nil {
+		resp.ResponseNOK(c, message.ErrSortAddrs, err, upgradeMySQL.Addrs)
+		return
+	}
+
+	e := mysql.NewEngineWithDefault(
+		mysqlVersion,
+		mode.Standalone,
+		upgradeMySQL.Addrs,
+		upgradeMySQL.MySQLServerParam,
+		parameter.NewPMMClientWithDefault(),
+	)
+
+	jsonBytes, err := json.Marshal(upgradeMySQL.Addrs)
+	if err != nil {
+		resp.ResponseNOK(c, message.ErrMarshalData, errors.Trace(err))
+		return
+	}
+	jsonStr := string(jsonBytes)
+
+	s := mysql.NewServiceWithDefault(e)
+	operationID, err := s.Upgrade()
+	if err != nil {
+		resp.ResponseNOK(c, msgMySQL.ErrMySQLServiceUpgradeMySQL, err, upgradeMySQL.MySQLServerParam.Version, jsonStr)
+		return
+	}
+
+	resp.ResponseOK(c, fmt.Sprintf(upgradeMySQLMessage, operationID, upgradeMySQL.MySQLServerParam.Version, jsonStr),
+		msgMySQL.InfoMySQLServiceUpgradeMySQL, operationID, upgradeMySQL.MySQLServerParam.Version, jsonStr)
+}
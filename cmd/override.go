@@ -248,9 +248,27 @@ func overrideMySQLByCLI() {
 	if mysqlUserDASPass != constant.DefaultRandomString {
 		viper.Set(config.MySQLUserDASPassKey, mysqlUserDASPass)
 	}
+	if mysqlUserCloneUser != constant.DefaultRandomString {
+		viper.Set(config.MySQLUserCloneUserKey, mysqlUserCloneUser)
+	}
+	if mysqlUserClonePass != constant.DefaultRandomString {
+		viper.Set(config.MySQLUserClonePassKey, mysqlUserClonePass)
+	}
 	if mysqlOperationTimeout != constant.DefaultRandomInt {
 		viper.Set(config.MySQLOperationTimeoutKey, mysqlOperationTimeout)
 	}
+	if mysqlLockLeaseTimeout != constant.DefaultRandomInt {
+		viper.Set(config.MySQLLockLeaseTimeoutKey, mysqlLockLeaseTimeout)
+	}
+	if mysqlInstallConcurrency != constant.DefaultRandomInt {
+		viper.Set(config.MySQLInstallConcurrencyKey, mysqlInstallConcurrency)
+	}
+	if mysqlBackupToolPath != constant.DefaultRandomString {
+		viper.Set(config.MySQLBackupToolPathKey, mysqlBackupToolPath)
+	}
+	if mysqlBackupRetentionDays != constant.DefaultRandomInt {
+		viper.Set(config.MySQLBackupRetentionDaysKey, mysqlBackupRetentionDays)
+	}
 }
 
 // overridePMMByCLI overrides the pmm section by command line interface
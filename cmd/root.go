@@ -92,7 +92,13 @@ var (
 	mysqlUserMonitorPass               string
 	mysqlUserDASUser                   string
 	mysqlUserDASPass                   string
+	mysqlUserCloneUser                 string
+	mysqlUserClonePass                 string
 	mysqlOperationTimeout              int
+	mysqlLockLeaseTimeout              int
+	mysqlInstallConcurrency            int
+	mysqlBackupToolPath                string
+	mysqlBackupRetentionDays           int
 	// pmm
 	pmmServerAddr                   string
 	pmmServerUser                   string
@@ -197,7 +203,13 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&mysqlUserMonitorPass, "mysql:user-monitor-pass", constant.DefaultRandomString, fmt.Sprintf("specify the default monitor password(default: %s)", config.DefaultMySQLUserMonitorPass))
 	rootCmd.PersistentFlags().StringVar(&mysqlUserDASUser, "mysql:user-das-user", constant.DefaultRandomString, fmt.Sprintf("specify the default das user(default: %s)", config.DefaultMySQLUserDASUser))
 	rootCmd.PersistentFlags().StringVar(&mysqlUserDASPass, "mysql:user-das-pass", constant.DefaultRandomString, fmt.Sprintf("specify the default das password(default: %s)", config.DefaultMySQLUserDASPass))
+	rootCmd.PersistentFlags().StringVar(&mysqlUserCloneUser, "mysql:user-clone-user", constant.DefaultRandomString, fmt.Sprintf("specify the default clone user(default: %s)", config.DefaultMySQLUserCloneUser))
+	rootCmd.PersistentFlags().StringVar(&mysqlUserClonePass, "mysql:user-clone-pass", constant.DefaultRandomString, fmt.Sprintf("specify the default clone password(default: %s)", config.DefaultMySQLUserClonePass))
 	rootCmd.PersistentFlags().IntVar(&mysqlOperationTimeout, "mysql-operation-timeout", constant.DefaultRandomInt, fmt.Sprintf("specify the default mysql operation timeout(default: %d, unit: seconds)", config.DefaultMySQLOperationTimeout))
+	rootCmd.PersistentFlags().IntVar(&mysqlLockLeaseTimeout, "mysql-lock-lease-timeout", constant.DefaultRandomInt, fmt.Sprintf("specify how long the operation lock will be kept without heartbeat(default: %d, unit: seconds)", config.DefaultMySQLLockLeaseTimeout))
+	rootCmd.PersistentFlags().IntVar(&mysqlInstallConcurrency, "mysql-install-concurrency", constant.DefaultRandomInt, fmt.Sprintf("specify how many hosts could be installed concurrently in one operation(default: %d)", config.DefaultMySQLInstallConcurrency))
+	rootCmd.PersistentFlags().StringVar(&mysqlBackupToolPath, "mysql-backup-tool-path", constant.DefaultRandomString, fmt.Sprintf("specify the path of xtrabackup on the mysql hosts(default: %s)", config.DefaultMySQLBackupToolPath))
+	rootCmd.PersistentFlags().IntVar(&mysqlBackupRetentionDays, "mysql-backup-retention-days", constant.DefaultRandomInt, fmt.Sprintf("specify how many days the backup sets will be retained(default: %d)", config.DefaultMySQLBackupRetentionDays))
 	// pmm
 	rootCmd.PersistentFlags().StringVar(&pmmServerAddr, "pmm-server-addr", constant.DefaultRandomString, fmt.Sprintf("specify the pmm server address(default: %s)", config.DefaultPMMServerAddr))
 	rootCmd.PersistentFlags().StringVar(&pmmServerUser, "pmm-server-user", constant.DefaultRandomString, fmt.Sprintf("specify the pmm server user(default: %s)", config.DefaultPMMServerUser))
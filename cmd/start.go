@@ -31,6 +31,8 @@ import (
 
 	"github.com/romberli/db-operator/config"
 	"github.com/romberli/db-operator/global"
+	"github.com/romberli/db-operator/module/implement/job"
+	"github.com/romberli/db-operator/module/implement/mysql"
 	"github.com/romberli/db-operator/pkg/message"
 	"github.com/romberli/db-operator/router"
 	"github.com/romberli/db-operator/server"
@@ -125,9 +127,20 @@ var startCmd = &cobra.Command{
 				log.Errorf(constant.LogWithStackString, message.NewMessage(message.ErrInitConnectionPool, err))
 				os.Exit(constant.DefaultAbnormalExitCode)
 			}
+			// the operations which were running in the previous process will never finish
+			err = mysql.NewOperationRecovererWithDefault().RecoverOrphanedOperations()
+			if err != nil {
+				log.Errorf(constant.LogWithStackString, err)
+			}
 			// init purge service
 			purgeService := global.NewPurgeServiceWithDefault()
 			go purgeService.PurgeMySQLOperationLock()
+			// init backup purger
+			backupPurger := mysql.NewBackupPurgerWithDefault()
+			go backupPurger.PurgeExpiredBackups()
+			// init job scheduler
+			scheduler := job.NewSchedulerWithDefault()
+			scheduler.Start()
 
 			// init token auth
 			ta := router.NewTokenAuthWithGlobal()
@@ -148,6 +161,7 @@ var startCmd = &cobra.Command{
 				viper.GetInt(config.ServerWriteTimeoutKey),
 				r,
 			)
+			s.RegisterStopFunc(scheduler.Stop)
 			// start server
 			go s.Run()
 
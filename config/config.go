@@ -118,7 +118,13 @@ func SetDefaultMySQL() {
 	viper.SetDefault(MySQLUserMonitorPassKey, DefaultMySQLUserMonitorPass)
 	viper.SetDefault(MySQLUserDASUserKey, DefaultMySQLUserDASUser)
 	viper.SetDefault(MySQLUserDASPassKey, DefaultMySQLUserDASPass)
+	viper.SetDefault(MySQLUserCloneUserKey, DefaultMySQLUserCloneUser)
+	viper.SetDefault(MySQLUserClonePassKey, DefaultMySQLUserClonePass)
 	viper.SetDefault(MySQLOperationTimeoutKey, DefaultMySQLOperationTimeout)
+	viper.SetDefault(MySQLLockLeaseTimeoutKey, DefaultMySQLLockLeaseTimeout)
+	viper.SetDefault(MySQLInstallConcurrencyKey, DefaultMySQLInstallConcurrency)
+	viper.SetDefault(MySQLBackupToolPathKey, DefaultMySQLBackupToolPath)
+	viper.SetDefault(MySQLBackupRetentionDaysKey, DefaultMySQLBackupRetentionDays)
 }
 
 // SetDefaultPMM sets the default value of pmm
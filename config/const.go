@@ -98,9 +98,21 @@ const (
 	DefaultMySQLUserMonitorPass               = "pmm"
 	DefaultMySQLUserDASUser                   = "das"
 	DefaultMySQLUserDASPass                   = "das"
+	DefaultMySQLUserCloneUser                 = "clone"
+	DefaultMySQLUserClonePass                 = "clone"
 	DefaultMySQLOperationTimeout              = 86400
 	MinMySQLOperationTimeout                  = 60
 	MaxMySQLOperationTimeout                  = 86400 * 7
+	DefaultMySQLLockLeaseTimeout              = 60
+	MinMySQLLockLeaseTimeout                  = 10
+	MaxMySQLLockLeaseTimeout                  = 3600
+	DefaultMySQLInstallConcurrency            = 5
+	MinMySQLInstallConcurrency                = 1
+	MaxMySQLInstallConcurrency                = 100
+	DefaultMySQLBackupToolPath                = "/usr/bin/xtrabackup"
+	DefaultMySQLBackupRetentionDays           = 7
+	MinMySQLBackupRetentionDays               = 1
+	MaxMySQLBackupRetentionDays               = 365
 	// pmm
 	DefaultPMMServerAddr                   = "127.0.0.1:443"
 	DefaultPMMServerUser                   = "admin"
@@ -166,7 +178,13 @@ const (
 	MySQLUserMonitorPassKey               = "mysql.user.monitorPass"
 	MySQLUserDASUserKey                   = "mysql.user.dasUser"
 	MySQLUserDASPassKey                   = "mysql.user.dasPass"
+	MySQLUserCloneUserKey                 = "mysql.user.cloneUser"
+	MySQLUserClonePassKey                 = "mysql.user.clonePass"
 	MySQLOperationTimeoutKey              = "mysql.operationTimeout"
+	MySQLLockLeaseTimeoutKey              = "mysql.lockLeaseTimeout"
+	MySQLInstallConcurrencyKey            = "mysql.installConcurrency"
+	MySQLBackupToolPathKey                = "mysql.backup.toolPath"
+	MySQLBackupRetentionDaysKey           = "mysql.backup.retentionDays"
 	// pmm
 	PMMServerAddrKey                   = "pmm.server.addr"
 	PMMServerUserKey                   = "pmm.server.user"
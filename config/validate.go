@@ -521,6 +521,22 @@ func ValidateMySQL() error {
 		merr = multierror.Append(merr, errors.Trace(err))
 	}
 
+	// validate mysql.user.cloneUser
+	cloneUser, err := cast.ToStringE(viper.Get(MySQLUserCloneUserKey))
+	if err != nil {
+		merr = multierror.Append(merr, errors.Trace(err))
+	} else {
+		if cloneUser == constant.EmptyString {
+			merr = multierror.Append(merr, message.NewMessage(msgMySQL.ErrMySQLNotValidConfigMySQLUser, MySQLUserCloneUserKey))
+		}
+	}
+
+	// validate mysql.user.clonePass
+	_, err = cast.ToStringE(viper.Get(MySQLUserClonePassKey))
+	if err != nil {
+		merr = multierror.Append(merr, errors.Trace(err))
+	}
+
 	// validate mysql.operationTimeout
 	operationTimeout, err := cast.ToIntE(viper.Get(MySQLOperationTimeoutKey))
 	if err != nil {
@@ -531,6 +547,46 @@ func ValidateMySQL() error {
 		}
 	}
 
+	// validate mysql.lockLeaseTimeout
+	lockLeaseTimeout, err := cast.ToIntE(viper.Get(MySQLLockLeaseTimeoutKey))
+	if err != nil {
+		merr = multierror.Append(merr, errors.Trace(err))
+	} else {
+		if lockLeaseTimeout < MinMySQLLockLeaseTimeout || lockLeaseTimeout > MaxMySQLLockLeaseTimeout {
+			merr = multierror.Append(merr, message.NewMessage(msgMySQL.ErrMySQLNotValidConfigMySQLLockLeaseTimeout, MinMySQLLockLeaseTimeout, MaxMySQLLockLeaseTimeout, lockLeaseTimeout))
+		}
+	}
+
+	// validate mysql.installConcurrency
+	installConcurrency, err := cast.ToIntE(viper.Get(MySQLInstallConcurrencyKey))
+	if err != nil {
+		merr = multierror.Append(merr, errors.Trace(err))
+	} else {
+		if installConcurrency < MinMySQLInstallConcurrency || installConcurrency > MaxMySQLInstallConcurrency {
+			merr = multierror.Append(merr, message.NewMessage(msgMySQL.ErrMySQLNotValidConfigMySQLInstallConcurrency, MinMySQLInstallConcurrency, MaxMySQLInstallConcurrency, installConcurrency))
+		}
+	}
+
+	// validate mysql.backup.toolPath
+	backupToolPath, err := cast.ToStringE(viper.Get(MySQLBackupToolPathKey))
+	if err != nil {
+		merr = multierror.Append(merr, errors.Trace(err))
+	} else {
+		if backupToolPath == constant.EmptyString {
+			merr = multierror.Append(merr, message.NewMessage(msgMySQL.ErrMySQLNotValidConfigMySQLBackupToolPath))
+		}
+	}
+
+	// validate mysql.backup.retentionDays
+	backupRetentionDays, err := cast.ToIntE(viper.Get(MySQLBackupRetentionDaysKey))
+	if err != nil {
+		merr = multierror.Append(merr, errors.Trace(err))
+	} else {
+		if backupRetentionDays < MinMySQLBackupRetentionDays || backupRetentionDays > MaxMySQLBackupRetentionDays {
+			merr = multierror.Append(merr, message.NewMessage(msgMySQL.ErrMySQLNotValidConfigMySQLBackupRetentionDays, MinMySQLBackupRetentionDays, MaxMySQLBackupRetentionDays, backupRetentionDays))
+		}
+	}
+
 	return merr.ErrorOrNil()
 }
 
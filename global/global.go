@@ -1,8 +1,13 @@
 package global
 
 import (
+	"fmt"
+	"os"
+	"time"
+
 	"github.com/pingcap/errors"
 	"github.com/romberli/db-operator/config"
+	"github.com/romberli/go-util/constant"
 	"github.com/romberli/go-util/middleware/mysql"
 	"github.com/romberli/log"
 	"github.com/spf13/viper"
@@ -11,12 +16,28 @@ import (
 const (
 	defaultMaxIdleConns        = 200
 	defaultMaxIdleConnsPerHost = 50
+
+	ownerTemplate = "%s:%d:%d"
 )
 
 var (
 	DBOMySQLPool *mysql.Pool
+	// Owner identifies this db-operator process, it is formatted as hostname:pid:startTime,
+	// the start time makes it unique per process start even if the hostname and the pid are reused, e.g. in the containers,
+	// the operation locks and the job histories record it so that operators could see which process holds them
+	Owner = initOwner()
 )
 
+// initOwner returns the owner of this db-operator process
+func initOwner() string {
+	hostName, err := os.Hostname()
+	if err != nil {
+		hostName = constant.DefaultLocalHostIP
+	}
+
+	return fmt.Sprintf(ownerTemplate, hostName, os.Getpid(), time.Now().UnixNano())
+}
+
 // InitDBOMySQLPool initializes the global DBOMySQLPool
 func InitDBOMySQLPool() (err error) {
 	dbAddr := viper.GetString(config.DBDBOMySQLAddrKey)
@@ -48,10 +48,11 @@ func (pr *PurgeRepo) Execute(command string, args ...interface{}) (middleware.Re
 	return conn.Execute(command, args...)
 }
 
-// PurgeMySQLOperationLock purges the mysql operation lock
+// PurgeMySQLOperationLock purges the mysql operation locks which have not been renewed within the lease timeout,
+// the running operations renew their locks periodically, so only the locks of the crashed processes will be purged
 func (pr *PurgeRepo) PurgeMySQLOperationLock() error {
-	timeout := time.Duration(viper.GetInt(config.MySQLOperationTimeoutKey)) * time.Second
-	minTime := time.Now().Add(-timeout).Format(constant.TimeLayoutSecond)
+	leaseTimeout := time.Duration(viper.GetInt(config.MySQLLockLeaseTimeoutKey)) * time.Second
+	minTime := time.Now().Add(-leaseTimeout).Format(constant.TimeLayoutSecond)
 
 	sql := `DELETE FROM t_mysql_operation_lock WHERE last_update_time < ? ;`
 	log.Debugf("global PurgeRepo.PurgeMySQLOperationLock(): sql: %s, args: %s", sql, minTime)
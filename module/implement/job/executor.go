@@ -0,0 +1,146 @@
+package job
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-version"
+	"github.com/pingcap/errors"
+	"github.com/romberli/go-util/constant"
+	"github.com/romberli/go-util/linux"
+
+	"github.com/romberli/db-operator/module/implement/mysql"
+	"github.com/romberli/db-operator/module/implement/mysql/mode"
+	"github.com/romberli/db-operator/module/implement/mysql/parameter"
+	"github.com/romberli/db-operator/pkg/message"
+
+	jsonmysql "github.com/romberli/db-operator/pkg/json/mysql"
+	msgJob "github.com/romberli/db-operator/pkg/message/job"
+)
+
+const (
+	backupJobMessageTemplate                  = "backup operation started. operation_id: %d, addr: %s"
+	binlogPurgeJobMessageTemplate             = "binary logs purged. addrs: %v, before: %s"
+	configDriftCheckJobMessageTemplate        = "no config drift found. addr: %s"
+	operationHistoryCleanupJobMessageTemplate = "operation histories purged. purged_num: %d, before: %s"
+)
+
+// executor runs the job with the job param, it returns the message which will be saved in the job history
+type executor func(jr *JobRepo, jobParam string) (string, error)
+
+var executors = map[int]executor{
+	BackupJobType:                  executeBackup,
+	BinlogPurgeJobType:             executeBinlogPurge,
+	ConfigDriftCheckJobType:        executeConfigDriftCheck,
+	OperationHistoryCleanupJobType: executeOperationHistoryCleanup,
+}
+
+// getExecutor returns the executor of the job type
+func getExecutor(jobType int) (executor, error) {
+	e, ok := executors[jobType]
+	if !ok {
+		return nil, message.NewMessage(msgJob.ErrJobNotValidJobType, jobType)
+	}
+
+	return e, nil
+}
+
+// executeBackup starts the backup operation of the instance, the job param is the same as the request body of the backup api
+func executeBackup(jr *JobRepo, jobParam string) (string, error) {
+	backup := jsonmysql.NewBackupWithDefault()
+	err := backup.Unmarshal([]byte(jobParam))
+	if err != nil {
+		return constant.EmptyString, errors.Trace(err)
+	}
+	mysqlVersion, err := version.NewVersion(backup.MySQLServerParam.Version)
+	if err != nil {
+		return constant.EmptyString, errors.Trace(err)
+	}
+
+	e := mysql.NewEngineWithDefault(
+		mysqlVersion,
+		mode.Standalone,
+		[]string{backup.Addr},
+		backup.MySQLServerParam,
+		parameter.NewPMMClientWithDefault(),
+	)
+	operationID, err := mysql.NewServiceWithDefault(e).Backup(backup.BackupType, backup.BackupMethod)
+	if err != nil {
+		return constant.EmptyString, err
+	}
+
+	return fmt.Sprintf(backupJobMessageTemplate, operationID, backup.Addr), nil
+}
+
+// executeBinlogPurge purges the binary logs which are older than the retention hours on the instances
+func executeBinlogPurge(jr *JobRepo, jobParam string) (string, error) {
+	param := NewBinlogPurgeParamWithDefault()
+	err := param.Unmarshal([]byte(jobParam))
+	if err != nil {
+		return constant.EmptyString, errors.Trace(err)
+	}
+	mysqlVersion, err := version.NewVersion(param.MySQLServerParam.Version)
+	if err != nil {
+		return constant.EmptyString, errors.Trace(err)
+	}
+	err = linux.SortAddrs(param.Addrs)
+	if err != nil {
+		return constant.EmptyString, message.NewMessage(message.ErrSortAddrs, err, param.Addrs)
+	}
+
+	e := mysql.NewEngineWithDefault(
+		mysqlVersion,
+		mode.Standalone,
+		param.Addrs,
+		param.MySQLServerParam,
+		parameter.NewPMMClientWithDefault(),
+	)
+	before := time.Now().Add(-time.Duration(param.RetentionHours) * time.Hour)
+	err = e.PurgeBinaryLogs(before)
+	if err != nil {
+		return constant.EmptyString, err
+	}
+
+	return fmt.Sprintf(binlogPurgeJobMessageTemplate, param.Addrs, before.Format(constant.TimeLayoutSecond)), nil
+}
+
+// executeConfigDriftCheck checks the config drift of the instance, it fails if any drift is found,
+// so that the drift could be noticed from the job history
+func executeConfigDriftCheck(jr *JobRepo, jobParam string) (string, error) {
+	param := NewConfigDriftCheckParamWithDefault()
+	err := param.Unmarshal([]byte(jobParam))
+	if err != nil {
+		return constant.EmptyString, errors.Trace(err)
+	}
+
+	drift, err := mysql.GetConfigDriftByAddr(param.Addr)
+	if err != nil {
+		return constant.EmptyString, err
+	}
+	if len(drift.Drifts) > constant.ZeroInt || drift.RuntimeError != constant.EmptyString {
+		return constant.EmptyString, message.NewMessage(msgJob.ErrJobConfigDriftCheck, param.Addr, len(drift.Drifts), drift.RuntimeError)
+	}
+
+	return fmt.Sprintf(configDriftCheckJobMessageTemplate, param.Addr), nil
+}
+
+// executeOperationHistoryCleanup purges the mysql operation histories and the job histories which are older than the retention days
+func executeOperationHistoryCleanup(jr *JobRepo, jobParam string) (string, error) {
+	param := NewOperationHistoryCleanupParamWithDefault()
+	err := param.Unmarshal([]byte(jobParam))
+	if err != nil {
+		return constant.EmptyString, errors.Trace(err)
+	}
+
+	minTime := time.Now().AddDate(constant.ZeroInt, constant.ZeroInt, -param.RetentionDays).Format(constant.TimeLayoutSecond)
+	purgedNum, err := mysql.NewDBORepoWithDefault().PurgeOperationHistory(minTime)
+	if err != nil {
+		return constant.EmptyString, err
+	}
+	err = jr.PurgeJobHistory(minTime)
+	if err != nil {
+		return constant.EmptyString, err
+	}
+
+	return fmt.Sprintf(operationHistoryCleanupJobMessageTemplate, purgedNum, minTime), nil
+}
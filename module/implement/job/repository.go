@@ -0,0 +1,195 @@
+package job
+
+import (
+	"time"
+
+	"github.com/romberli/go-util/constant"
+	"github.com/romberli/go-util/middleware"
+	"github.com/romberli/log"
+
+	"github.com/romberli/db-operator/global"
+	"github.com/romberli/db-operator/pkg/message"
+
+	msgJob "github.com/romberli/db-operator/pkg/message/job"
+)
+
+type JobRepo struct {
+	Database middleware.Pool
+}
+
+// NewJobRepo returns a new *JobRepo
+func NewJobRepo(db middleware.Pool) *JobRepo {
+	return newJobRepo(db)
+}
+
+// NewJobRepoWithDefault returns a new *JobRepo with default middleware.Pool
+func NewJobRepoWithDefault() *JobRepo {
+	return newJobRepo(global.DBOMySQLPool)
+}
+
+// newJobRepo returns a new *JobRepo
+func newJobRepo(db middleware.Pool) *JobRepo {
+	return &JobRepo{
+		Database: db,
+	}
+}
+
+// Execute executes given command and placeholders on the middleware
+func (jr *JobRepo) Execute(command string, args ...interface{}) (middleware.Result, error) {
+	conn, err := jr.Database.Get()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = conn.Close()
+		if err != nil {
+			log.Errorf("job JobRepo.Execute(): close database connection failed.\n%+v", err)
+		}
+	}()
+
+	return conn.Execute(command, args...)
+}
+
+// GetJobs gets all the jobs from the middleware
+func (jr *JobRepo) GetJobs() ([]*Job, error) {
+	return jr.getJobs(`WHERE del_flag = 0 ORDER BY id ASC`)
+}
+
+// GetEnabledJobs gets the enabled jobs from the middleware
+func (jr *JobRepo) GetEnabledJobs() ([]*Job, error) {
+	return jr.getJobs(`WHERE del_flag = 0 AND enabled = 1 ORDER BY next_run_time ASC`)
+}
+
+// getJobs gets the jobs which match the given condition from the middleware
+func (jr *JobRepo) getJobs(condition string, args ...interface{}) ([]*Job, error) {
+	sql := `
+		SELECT id,
+			   job_name,
+			   job_type,
+			   cron_expr,
+			   job_param,
+			   enabled,
+			   last_run_time,
+			   next_run_time,
+			   del_flag,
+			   create_time,
+			   last_update_time
+		FROM t_sys_job
+	` + condition
+	log.Debugf("job JobRepo.getJobs() select sql: \n%s\nplaceholders: %v", sql, args)
+
+	result, err := jr.Execute(sql, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	jobList := make([]*Job, result.RowNumber())
+	for i := constant.ZeroInt; i < result.RowNumber(); i++ {
+		jobList[i] = NewJobWithDefault()
+	}
+
+	err = result.MapToStructSlice(jobList, constant.DefaultMiddlewareTag)
+	if err != nil {
+		return nil, err
+	}
+
+	return jobList, nil
+}
+
+// InitNextRunTime initializes the next run time of the job, it only takes effect when the next run time
+// is still earlier than the create time, so that the concurrent schedulers will not overwrite each other
+func (jr *JobRepo) InitNextRunTime(id int, nextRunTime time.Time) error {
+	sql := `UPDATE t_sys_job SET next_run_time = ? WHERE id = ? AND next_run_time < create_time ;`
+	log.Debugf("job JobRepo.InitNextRunTime() update sql: \n%s\nplaceholders: %s, %d",
+		sql, nextRunTime.Format(constant.TimeLayoutSecond), id)
+
+	_, err := jr.Execute(sql, nextRunTime.Format(constant.TimeLayoutSecond), id)
+
+	return err
+}
+
+// ClaimJob claims the run of the job at the scheduled time by inserting the job history,
+// only one owner could claim the same run because of the unique key of the job id and the scheduled time,
+// it returns the id of the job history, or zero if the run had been claimed by the other owner
+func (jr *JobRepo) ClaimJob(jobID int, scheduledTime time.Time, owner string) (int, error) {
+	sql := `INSERT IGNORE INTO t_sys_job_history(job_id, scheduled_time, owner, status) VALUES(?, ?, ?, ?) ;`
+	log.Debugf("job JobRepo.ClaimJob() insert sql: \n%s\nplaceholders: %d, %s, %s, %d",
+		sql, jobID, scheduledTime.Format(constant.TimeLayoutSecond), owner, defaultRunningStatus)
+
+	result, err := jr.Execute(sql, jobID, scheduledTime.Format(constant.TimeLayoutSecond), owner, defaultRunningStatus)
+	if err != nil {
+		return constant.ZeroInt, message.NewMessage(msgJob.ErrJobRepositoryClaimJob, err, jobID, scheduledTime.Format(constant.TimeLayoutSecond))
+	}
+
+	return result.LastInsertID()
+}
+
+// UpdateJobRunTime updates the last run time and the next run time of the job
+func (jr *JobRepo) UpdateJobRunTime(id int, lastRunTime, nextRunTime time.Time) error {
+	sql := `UPDATE t_sys_job SET last_run_time = ?, next_run_time = ? WHERE id = ? ;`
+	log.Debugf("job JobRepo.UpdateJobRunTime() update sql: \n%s\nplaceholders: %s, %s, %d",
+		sql, lastRunTime.Format(constant.TimeLayoutSecond), nextRunTime.Format(constant.TimeLayoutSecond), id)
+
+	_, err := jr.Execute(sql, lastRunTime.Format(constant.TimeLayoutSecond), nextRunTime.Format(constant.TimeLayoutSecond), id)
+
+	return err
+}
+
+// UpdateJobHistory updates the status and the message of the job history, the end time is set to now
+func (jr *JobRepo) UpdateJobHistory(id, status int, message string) error {
+	sql := `UPDATE t_sys_job_history SET status = ?, message = ?, end_time = NOW(6) WHERE id = ? ;`
+	log.Debugf("job JobRepo.UpdateJobHistory() update sql: \n%s\nplaceholders: %d, %s, %d", sql, status, message, id)
+
+	_, err := jr.Execute(sql, status, message, id)
+
+	return err
+}
+
+// GetJobHistories gets the run histories of the job from the middleware, the latest one is the first
+func (jr *JobRepo) GetJobHistories(jobID int) ([]*JobHistory, error) {
+	sql := `
+		SELECT id,
+			   job_id,
+			   scheduled_time,
+			   owner,
+			   status,
+			   message,
+			   start_time,
+			   end_time,
+			   del_flag,
+			   create_time,
+			   last_update_time
+		FROM t_sys_job_history
+		WHERE del_flag = 0
+		  AND job_id = ?
+		ORDER BY id DESC
+	`
+	log.Debugf("job JobRepo.GetJobHistories() select sql: \n%s\nplaceholders: %d", sql, jobID)
+
+	result, err := jr.Execute(sql, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	historyList := make([]*JobHistory, result.RowNumber())
+	for i := constant.ZeroInt; i < result.RowNumber(); i++ {
+		historyList[i] = NewJobHistoryWithDefault()
+	}
+
+	err = result.MapToStructSlice(historyList, constant.DefaultMiddlewareTag)
+	if err != nil {
+		return nil, err
+	}
+
+	return historyList, nil
+}
+
+// PurgeJobHistory deletes the finished job histories which were created before the given time
+func (jr *JobRepo) PurgeJobHistory(minTime string) error {
+	sql := `DELETE FROM t_sys_job_history WHERE status <> ? AND create_time < ? ;`
+	log.Debugf("job JobRepo.PurgeJobHistory() delete sql: \n%s\nplaceholders: %d, %s", sql, defaultRunningStatus, minTime)
+
+	_, err := jr.Execute(sql, defaultRunningStatus, minTime)
+
+	return err
+}
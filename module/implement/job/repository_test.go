@@ -0,0 +1,213 @@
+package job
+
+import (
+	"testing"
+	"time"
+
+	"github.com/romberli/go-util/constant"
+	"github.com/romberli/go-util/middleware/mysql"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/romberli/db-operator/config"
+	"github.com/romberli/db-operator/global"
+)
+
+const (
+	testDBDBOMySQLAddr = "192.168.137.11:3306"
+	testDBDBOMySQLName = "dbo"
+	testDBDBOMySQLUser = "root"
+	testDBDBOMySQLPass = "root"
+
+	testJobName     = "test-cleanup-operation-history"
+	testCronExpr    = "* * * * *"
+	testJobParam    = `{"retention_days": 30}`
+	testOwner       = "test-host:12345"
+	testJobMessage  = "test job message"
+	testEnabledFlag = 1
+)
+
+var (
+	testJobRepo *JobRepo
+)
+
+func init() {
+	testInitViper()
+	testInitDBOMySQLPool()
+
+	testJobRepo = NewJobRepoWithDefault()
+}
+
+func testInitViper() {
+	viper.Set(config.DBDBOMySQLAddrKey, testDBDBOMySQLAddr)
+	viper.Set(config.DBDBOMySQLNameKey, testDBDBOMySQLName)
+	viper.Set(config.DBDBOMySQLUserKey, testDBDBOMySQLUser)
+	viper.Set(config.DBDBOMySQLPassKey, testDBDBOMySQLPass)
+	viper.Set(config.DBPoolMaxConnectionsKey, mysql.DefaultMaxConnections)
+	viper.Set(config.DBPoolInitConnectionsKey, mysql.DefaultInitConnections)
+	viper.Set(config.DBPoolMaxIdleConnectionsKey, mysql.DefaultMaxIdleConnections)
+	viper.Set(config.DBPoolMaxIdleTimeKey, mysql.DefaultMaxIdleTime)
+	viper.Set(config.DBPoolMaxWaitTimeKey, mysql.DefaultMaxWaitTime)
+	viper.Set(config.DBPoolMaxRetryCountKey, mysql.DefaultMaxRetryCount)
+	viper.Set(config.DBPoolKeepAliveIntervalKey, mysql.DefaultKeepAliveInterval)
+}
+
+func testInitDBOMySQLPool() {
+	if global.DBOMySQLPool == nil {
+		err := global.InitDBOMySQLPool()
+		if err != nil {
+			panic(err)
+		}
+	}
+}
+
+// testInitJob inserts an enabled job whose next run time is not initialized, it returns the job id
+func testInitJob() (int, error) {
+	sql := `INSERT INTO t_sys_job(job_name, job_type, cron_expr, job_param, enabled) VALUES(?, ?, ?, ?, ?) ;`
+	result, err := testJobRepo.Execute(sql, testJobName, OperationHistoryCleanupJobType, testCronExpr, testJobParam, testEnabledFlag)
+	if err != nil {
+		return constant.ZeroInt, err
+	}
+
+	return result.LastInsertID()
+}
+
+func testGetJob(id int) (*Job, error) {
+	jobs, err := testJobRepo.getJobs(`WHERE id = ?`, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return jobs[constant.ZeroInt], nil
+}
+
+func testDeleteJob(id int) error {
+	sql := `DELETE FROM t_sys_job WHERE id = ? ;`
+	_, err := testJobRepo.Execute(sql, id)
+	if err != nil {
+		return err
+	}
+
+	sql = `DELETE FROM t_sys_job_history WHERE job_id = ? ;`
+	_, err = testJobRepo.Execute(sql, id)
+
+	return err
+}
+
+func TestJobRepo_All(t *testing.T) {
+	TestJobRepo_Execute(t)
+	TestJobRepo_GetEnabledJobs(t)
+	TestJobRepo_InitNextRunTime(t)
+	TestJobRepo_ClaimJob(t)
+	TestJobRepo_UpdateJobHistory(t)
+	TestJobRepo_PurgeJobHistory(t)
+}
+
+func TestJobRepo_Execute(t *testing.T) {
+	asst := assert.New(t)
+
+	result, err := testJobRepo.Execute("select 1")
+	asst.Nil(err, "test Execute() failed")
+	data, err := result.GetInt(constant.ZeroInt, constant.ZeroInt)
+	asst.Nil(err, "test Execute() failed")
+	asst.Equal(constant.OneInt, data, "test Execute() failed")
+}
+
+func TestJobRepo_GetEnabledJobs(t *testing.T) {
+	asst := assert.New(t)
+
+	id, err := testInitJob()
+	asst.Nil(err, "test GetEnabledJobs() failed")
+	jobs, err := testJobRepo.GetEnabledJobs()
+	asst.Nil(err, "test GetEnabledJobs() failed")
+	found := false
+	for _, job := range jobs {
+		if job.ID == id {
+			found = true
+			asst.True(job.NeedInitNextRunTime(), "test GetEnabledJobs() failed")
+		}
+	}
+	asst.True(found, "test GetEnabledJobs() failed")
+	// delete job
+	err = testDeleteJob(id)
+	asst.Nil(err, "test GetEnabledJobs() failed")
+}
+
+func TestJobRepo_InitNextRunTime(t *testing.T) {
+	asst := assert.New(t)
+
+	id, err := testInitJob()
+	asst.Nil(err, "test InitNextRunTime() failed")
+	nextRunTime := time.Now().Add(time.Hour).Truncate(time.Minute)
+	err = testJobRepo.InitNextRunTime(id, nextRunTime)
+	asst.Nil(err, "test InitNextRunTime() failed")
+	// the initialized next run time must not be overwritten
+	err = testJobRepo.InitNextRunTime(id, nextRunTime.Add(time.Hour))
+	asst.Nil(err, "test InitNextRunTime() failed")
+	job, err := testGetJob(id)
+	asst.Nil(err, "test InitNextRunTime() failed")
+	asst.True(nextRunTime.Equal(job.NextRunTime), "test InitNextRunTime() failed")
+	// delete job
+	err = testDeleteJob(id)
+	asst.Nil(err, "test InitNextRunTime() failed")
+}
+
+func TestJobRepo_ClaimJob(t *testing.T) {
+	asst := assert.New(t)
+
+	id, err := testInitJob()
+	asst.Nil(err, "test ClaimJob() failed")
+	scheduledTime := time.Now().Truncate(time.Minute)
+	historyID, err := testJobRepo.ClaimJob(id, scheduledTime, testOwner)
+	asst.Nil(err, "test ClaimJob() failed")
+	asst.NotEqual(constant.ZeroInt, historyID, "test ClaimJob() failed")
+	// the same run could not be claimed twice
+	historyID, err = testJobRepo.ClaimJob(id, scheduledTime, testOwner)
+	asst.Nil(err, "test ClaimJob() failed")
+	asst.Equal(constant.ZeroInt, historyID, "test ClaimJob() failed")
+	// delete job
+	err = testDeleteJob(id)
+	asst.Nil(err, "test ClaimJob() failed")
+}
+
+func TestJobRepo_UpdateJobHistory(t *testing.T) {
+	asst := assert.New(t)
+
+	id, err := testInitJob()
+	asst.Nil(err, "test UpdateJobHistory() failed")
+	historyID, err := testJobRepo.ClaimJob(id, time.Now().Truncate(time.Minute), testOwner)
+	asst.Nil(err, "test UpdateJobHistory() failed")
+	err = testJobRepo.UpdateJobHistory(historyID, defaultSuccessStatus, testJobMessage)
+	asst.Nil(err, "test UpdateJobHistory() failed")
+	histories, err := testJobRepo.GetJobHistories(id)
+	asst.Nil(err, "test UpdateJobHistory() failed")
+	asst.Equal(constant.OneInt, len(histories), "test UpdateJobHistory() failed")
+	asst.Equal(defaultSuccessStatus, histories[constant.ZeroInt].Status, "test UpdateJobHistory() failed")
+	asst.Equal(testJobMessage, histories[constant.ZeroInt].Message, "test UpdateJobHistory() failed")
+	// delete job
+	err = testDeleteJob(id)
+	asst.Nil(err, "test UpdateJobHistory() failed")
+}
+
+func TestJobRepo_PurgeJobHistory(t *testing.T) {
+	asst := assert.New(t)
+
+	id, err := testInitJob()
+	asst.Nil(err, "test PurgeJobHistory() failed")
+	historyID, err := testJobRepo.ClaimJob(id, time.Now().Truncate(time.Minute), testOwner)
+	asst.Nil(err, "test PurgeJobHistory() failed")
+	_, err = testJobRepo.ClaimJob(id, time.Now().Truncate(time.Minute).Add(time.Minute), testOwner)
+	asst.Nil(err, "test PurgeJobHistory() failed")
+	err = testJobRepo.UpdateJobHistory(historyID, defaultSuccessStatus, testJobMessage)
+	asst.Nil(err, "test PurgeJobHistory() failed")
+	// the running job history must be kept
+	err = testJobRepo.PurgeJobHistory(time.Now().Add(time.Hour).Format(constant.TimeLayoutSecond))
+	asst.Nil(err, "test PurgeJobHistory() failed")
+	histories, err := testJobRepo.GetJobHistories(id)
+	asst.Nil(err, "test PurgeJobHistory() failed")
+	asst.Equal(constant.OneInt, len(histories), "test PurgeJobHistory() failed")
+	asst.Equal(defaultRunningStatus, histories[constant.ZeroInt].Status, "test PurgeJobHistory() failed")
+	// delete job
+	err = testDeleteJob(id)
+	asst.Nil(err, "test PurgeJobHistory() failed")
+}
@@ -0,0 +1,205 @@
+package job
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/romberli/go-util/constant"
+	"github.com/romberli/log"
+
+	"github.com/romberli/db-operator/global"
+	"github.com/romberli/db-operator/pkg/message"
+	"github.com/romberli/db-operator/pkg/util/cron"
+
+	msgJob "github.com/romberli/db-operator/pkg/message/job"
+)
+
+const (
+	defaultScheduleInterval = 10 * time.Second
+	defaultStopTimeout      = 30 * time.Second
+)
+
+type Scheduler struct {
+	*JobRepo
+	owner    string
+	interval time.Duration
+	stopChan chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// NewScheduler returns a new *Scheduler
+func NewScheduler(repo *JobRepo, interval time.Duration) *Scheduler {
+	return newScheduler(repo, interval)
+}
+
+// NewSchedulerWithDefault returns a new *Scheduler with default repository and interval
+func NewSchedulerWithDefault() *Scheduler {
+	return newScheduler(NewJobRepoWithDefault(), defaultScheduleInterval)
+}
+
+// newScheduler returns a new *Scheduler, the owner is the owner of this db-operator process,
+// so that the job histories show which db-operator process ran the job
+func newScheduler(repo *JobRepo, interval time.Duration) *Scheduler {
+	return &Scheduler{
+		JobRepo:  repo,
+		owner:    global.Owner,
+		interval: interval,
+		stopChan: make(chan struct{}),
+	}
+}
+
+// Owner returns the owner of the scheduler
+func (s *Scheduler) Owner() string {
+	return s.owner
+}
+
+// Start starts the scheduler in the background, it checks the enabled jobs periodically
+// and runs the jobs whose next run time has come
+func (s *Scheduler) Start() {
+	s.wg.Add(constant.OneInt)
+	go s.run()
+
+	log.Info(message.NewMessage(msgJob.InfoJobSchedulerStart, s.owner).Error())
+}
+
+// Stop stops scheduling the jobs and waits for the running jobs to finish,
+// it returns an error if the running jobs could not finish in time
+func (s *Scheduler) Stop() error {
+	s.stopOnce.Do(func() {
+		close(s.stopChan)
+	})
+
+	doneChan := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(doneChan)
+	}()
+
+	select {
+	case <-doneChan:
+		log.Info(message.NewMessage(msgJob.InfoJobSchedulerStop, s.owner).Error())
+		return nil
+	case <-time.After(defaultStopTimeout):
+		return errors.Trace(message.NewMessage(msgJob.ErrJobSchedulerStopTimeout, s.owner, defaultStopTimeout.String()))
+	}
+}
+
+// run schedules the jobs until the scheduler is stopped
+func (s *Scheduler) run() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		s.schedule()
+
+		select {
+		case <-s.stopChan:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// schedule checks the enabled jobs and runs the jobs whose next run time has come
+func (s *Scheduler) schedule() {
+	jobs, err := s.GetEnabledJobs()
+	if err != nil {
+		log.Errorf(constant.LogWithStackString, message.NewMessage(msgJob.ErrJobSchedulerGetJobs, err))
+		return
+	}
+
+	for _, job := range jobs {
+		select {
+		case <-s.stopChan:
+			return
+		default:
+		}
+
+		err = s.scheduleJob(job, time.Now())
+		if err != nil {
+			log.Errorf(constant.LogWithStackString, message.NewMessage(msgJob.ErrJobSchedulerScheduleJob, err, job.ID, job.JobName))
+		}
+	}
+}
+
+// scheduleJob runs the job in the background if its next run time has come and the run is claimed by this scheduler,
+// the run is skipped if the other scheduler has claimed it
+func (s *Scheduler) scheduleJob(job *Job, now time.Time) error {
+	schedule, err := cron.Parse(job.CronExpr)
+	if err != nil {
+		return message.NewMessage(msgJob.ErrJobSchedulerParseCron, err, job.ID, job.JobName, job.CronExpr)
+	}
+	nextRunTime := schedule.Next(now)
+	if nextRunTime.IsZero() {
+		return message.NewMessage(msgJob.ErrJobSchedulerParseCron, errors.New("cron expression never matches"), job.ID, job.JobName, job.CronExpr)
+	}
+
+	if job.NeedInitNextRunTime() {
+		return s.InitNextRunTime(job.ID, nextRunTime)
+	}
+	if job.NextRunTime.After(now) {
+		return nil
+	}
+
+	historyID, err := s.ClaimJob(job.ID, job.NextRunTime, s.owner)
+	if err != nil {
+		return err
+	}
+	if historyID == constant.ZeroInt {
+		// the run has been claimed by the other scheduler
+		return nil
+	}
+	// the missed runs are not made up, the job will run at the next matched time after now
+	err = s.UpdateJobRunTime(job.ID, job.NextRunTime, nextRunTime)
+	if err != nil {
+		s.updateJobHistory(historyID, defaultFailedStatus, err.Error())
+		return err
+	}
+
+	s.wg.Add(constant.OneInt)
+	go s.runJob(job, historyID)
+
+	return nil
+}
+
+// runJob runs the job and saves the result to the job history
+func (s *Scheduler) runJob(job *Job, historyID int) {
+	defer s.wg.Done()
+	defer func() {
+		if r := recover(); r != nil {
+			err := errors.Errorf("job panicked. recover: %v", r)
+			log.Errorf(constant.LogWithStackString,
+				message.NewMessage(msgJob.ErrJobRun, err, job.ID, job.JobName, historyID, job.NextRunTime.Format(constant.TimeLayoutSecond)))
+			s.updateJobHistory(historyID, defaultFailedStatus, err.Error())
+		}
+	}()
+
+	execute, err := getExecutor(job.JobType)
+	if err != nil {
+		s.updateJobHistory(historyID, defaultFailedStatus, err.Error())
+		return
+	}
+	msg, err := execute(s.JobRepo, job.JobParam)
+	if err != nil {
+		log.Errorf(constant.LogWithStackString,
+			message.NewMessage(msgJob.ErrJobRun, err, job.ID, job.JobName, historyID, job.NextRunTime.Format(constant.TimeLayoutSecond)))
+		s.updateJobHistory(historyID, defaultFailedStatus, fmt.Sprintf(constant.LogWithStackString, err))
+		return
+	}
+
+	log.Info(message.NewMessage(msgJob.InfoJobRun, job.ID, job.JobName, historyID, job.NextRunTime.Format(constant.TimeLayoutSecond)).Error())
+	s.updateJobHistory(historyID, defaultSuccessStatus, msg)
+}
+
+// updateJobHistory updates the job history and logs the error if failed
+func (s *Scheduler) updateJobHistory(historyID, status int, msg string) {
+	err := s.UpdateJobHistory(historyID, status, msg)
+	if err != nil {
+		log.Errorf(constant.LogWithStackString, message.NewMessage(msgJob.ErrJobUpdateJobHistory, err, historyID))
+	}
+}
@@ -0,0 +1,65 @@
+package job
+
+import (
+	"testing"
+	"time"
+
+	"github.com/romberli/go-util/constant"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testScheduleInterval = time.Second
+)
+
+func TestScheduler_All(t *testing.T) {
+	TestScheduler_ScheduleJob(t)
+	TestScheduler_StartAndStop(t)
+}
+
+func TestScheduler_ScheduleJob(t *testing.T) {
+	asst := assert.New(t)
+
+	s := NewScheduler(testJobRepo, testScheduleInterval)
+	// the other scheduler runs in the other db-operator process
+	other := NewScheduler(testJobRepo, testScheduleInterval)
+
+	id, err := testInitJob()
+	asst.Nil(err, "test ScheduleJob() failed")
+	// the next run time is initialized at first
+	job, err := testGetJob(id)
+	asst.Nil(err, "test ScheduleJob() failed")
+	err = s.scheduleJob(job, time.Now())
+	asst.Nil(err, "test ScheduleJob() failed")
+	job, err = testGetJob(id)
+	asst.Nil(err, "test ScheduleJob() failed")
+	asst.False(job.NeedInitNextRunTime(), "test ScheduleJob() failed")
+	// both schedulers try to run the job, only one of them could claim the run
+	now := job.NextRunTime.Add(time.Second)
+	err = s.scheduleJob(job, now)
+	asst.Nil(err, "test ScheduleJob() failed")
+	err = other.scheduleJob(job, now)
+	asst.Nil(err, "test ScheduleJob() failed")
+	err = s.Stop()
+	asst.Nil(err, "test ScheduleJob() failed")
+	histories, err := testJobRepo.GetJobHistories(id)
+	asst.Nil(err, "test ScheduleJob() failed")
+	asst.Equal(constant.OneInt, len(histories), "test ScheduleJob() failed")
+	asst.Equal(defaultSuccessStatus, histories[constant.ZeroInt].Status, "test ScheduleJob() failed")
+	// delete job
+	err = testDeleteJob(id)
+	asst.Nil(err, "test ScheduleJob() failed")
+}
+
+func TestScheduler_StartAndStop(t *testing.T) {
+	asst := assert.New(t)
+
+	s := NewScheduler(testJobRepo, testScheduleInterval)
+	s.Start()
+	time.Sleep(testScheduleInterval * constant.TwoInt)
+	err := s.Stop()
+	asst.Nil(err, "test StartAndStop() failed")
+	// stop is idempotent
+	err = s.Stop()
+	asst.Nil(err, "test StartAndStop() failed")
+}
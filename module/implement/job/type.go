@@ -0,0 +1,154 @@
+package job
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/romberli/go-util/constant"
+
+	"github.com/romberli/db-operator/module/implement/mysql/parameter"
+)
+
+const (
+	BackupJobType = iota + 1
+	BinlogPurgeJobType
+	ConfigDriftCheckJobType
+	OperationHistoryCleanupJobType
+)
+
+const (
+	defaultRunningStatus = 1
+	defaultSuccessStatus = 2
+	defaultFailedStatus  = 3
+
+	defaultBinlogRetentionHours          = 168
+	defaultOperationHistoryRetentionDays = 30
+)
+
+type Job struct {
+	ID             int       `json:"id" middleware:"id"`
+	JobName        string    `json:"job_name" middleware:"job_name"`
+	JobType        int       `json:"job_type" middleware:"job_type"`
+	CronExpr       string    `json:"cron_expr" middleware:"cron_expr"`
+	JobParam       string    `json:"job_param" middleware:"job_param"`
+	Enabled        int       `json:"enabled" middleware:"enabled"`
+	LastRunTime    time.Time `json:"last_run_time" middleware:"last_run_time"`
+	NextRunTime    time.Time `json:"next_run_time" middleware:"next_run_time"`
+	DelFlag        int       `json:"del_flag" middleware:"del_flag"`
+	CreateTime     time.Time `json:"create_time" middleware:"create_time"`
+	LastUpdateTime time.Time `json:"last_update_time" middleware:"last_update_time"`
+}
+
+// NewJobWithDefault returns a new *Job with default value
+func NewJobWithDefault() *Job {
+	return &Job{
+		ID:             constant.ZeroInt,
+		JobName:        constant.EmptyString,
+		JobType:        constant.ZeroInt,
+		CronExpr:       constant.EmptyString,
+		JobParam:       constant.EmptyString,
+		Enabled:        constant.ZeroInt,
+		LastRunTime:    time.Time{},
+		NextRunTime:    time.Time{},
+		DelFlag:        constant.ZeroInt,
+		CreateTime:     time.Time{},
+		LastUpdateTime: time.Time{},
+	}
+}
+
+// NeedInitNextRunTime returns if the next run time of the job should be computed from the cron expression,
+// it happens when the job is newly created or its next run time was reset
+func (j *Job) NeedInitNextRunTime() bool {
+	return j.NextRunTime.Before(j.CreateTime)
+}
+
+type JobHistory struct {
+	ID             int       `json:"id" middleware:"id"`
+	JobID          int       `json:"job_id" middleware:"job_id"`
+	ScheduledTime  time.Time `json:"scheduled_time" middleware:"scheduled_time"`
+	Owner          string    `json:"owner" middleware:"owner"`
+	Status         int       `json:"status" middleware:"status"`
+	Message        string    `json:"message" middleware:"message"`
+	StartTime      time.Time `json:"start_time" middleware:"start_time"`
+	EndTime        time.Time `json:"end_time" middleware:"end_time"`
+	DelFlag        int       `json:"del_flag" middleware:"del_flag"`
+	CreateTime     time.Time `json:"create_time" middleware:"create_time"`
+	LastUpdateTime time.Time `json:"last_update_time" middleware:"last_update_time"`
+}
+
+// NewJobHistoryWithDefault returns a new *JobHistory with default value
+func NewJobHistoryWithDefault() *JobHistory {
+	return &JobHistory{
+		ID:             constant.ZeroInt,
+		JobID:          constant.ZeroInt,
+		ScheduledTime:  time.Time{},
+		Owner:          constant.EmptyString,
+		Status:         constant.ZeroInt,
+		Message:        constant.EmptyString,
+		StartTime:      time.Time{},
+		EndTime:        time.Time{},
+		DelFlag:        constant.ZeroInt,
+		CreateTime:     time.Time{},
+		LastUpdateTime: time.Time{},
+	}
+}
+
+type BinlogPurgeParam struct {
+	Addrs            []string               `json:"addrs"`
+	RetentionHours   int                    `json:"retention_hours"`
+	MySQLServerParam *parameter.MySQLServer `json:"mysql_server_param"`
+}
+
+// NewBinlogPurgeParamWithDefault returns a new *BinlogPurgeParam with default value, the binary logs of the last 7 days are kept by default
+func NewBinlogPurgeParamWithDefault() *BinlogPurgeParam {
+	return &BinlogPurgeParam{
+		Addrs:            []string{},
+		RetentionHours:   defaultBinlogRetentionHours,
+		MySQLServerParam: parameter.NewMySQLServerWithDefault(),
+	}
+}
+
+// Unmarshal unmarshals json data to *BinlogPurgeParam
+func (bpp *BinlogPurgeParam) Unmarshal(data []byte) error {
+	err := json.Unmarshal(data, bpp)
+	if err != nil {
+		return err
+	}
+
+	bpp.MySQLServerParam.SetVersion(bpp.MySQLServerParam.Version)
+
+	return nil
+}
+
+type ConfigDriftCheckParam struct {
+	Addr string `json:"addr"`
+}
+
+// NewConfigDriftCheckParamWithDefault returns a new *ConfigDriftCheckParam with default value
+func NewConfigDriftCheckParamWithDefault() *ConfigDriftCheckParam {
+	return &ConfigDriftCheckParam{
+		Addr: constant.EmptyString,
+	}
+}
+
+// Unmarshal unmarshals json data to *ConfigDriftCheckParam
+func (cdcp *ConfigDriftCheckParam) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, cdcp)
+}
+
+type OperationHistoryCleanupParam struct {
+	RetentionDays int `json:"retention_days"`
+}
+
+// NewOperationHistoryCleanupParamWithDefault returns a new *OperationHistoryCleanupParam with default value,
+// the operation histories of the last 30 days are kept by default
+func NewOperationHistoryCleanupParamWithDefault() *OperationHistoryCleanupParam {
+	return &OperationHistoryCleanupParam{
+		RetentionDays: defaultOperationHistoryRetentionDays,
+	}
+}
+
+// Unmarshal unmarshals json data to *OperationHistoryCleanupParam
+func (ohcp *OperationHistoryCleanupParam) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, ohcp)
+}
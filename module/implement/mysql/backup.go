@@ -0,0 +1,404 @@
+package mysql
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-version"
+	"github.com/pingcap/errors"
+	"github.com/romberli/go-util/constant"
+	"github.com/romberli/go-util/linux"
+	"github.com/romberli/go-util/middleware/mysql"
+	"github.com/romberli/log"
+	"github.com/spf13/viper"
+
+	"github.com/romberli/db-operator/config"
+	"github.com/romberli/db-operator/pkg/message"
+
+	msgMySQL "github.com/romberli/db-operator/pkg/message/mysql"
+)
+
+const (
+	FullBackupType        = 1
+	IncrementalBackupType = 2
+	XtrabackupMethod      = 1
+	CloneMethod           = 2
+
+	backupSuccessMessage = "backup mysql server completed."
+	backupPanicMessage   = "backup mysql server failed because of panic, please check the log for more details."
+
+	backupDirNameTemplate            = "%s_%s"
+	fullBackupDirSuffix              = "full"
+	incrementalBackupDirSuffix       = "incremental"
+	cloneBackupDirSuffix             = "clone"
+	xtrabackupCommandTemplate        = "%s --defaults-file=%s --defaults-group=mysqld%d --backup --user=%s --password='%s' --socket=%s/run/mysql.sock --target-dir=%s"
+	xtrabackupIncrementalOption      = " --incremental-basedir=%s"
+	xtrabackupCheckpointsFileName    = "xtrabackup_checkpoints"
+	xtrabackupBinlogInfoFileName     = "xtrabackup_binlog_info"
+	xtrabackupFromLSNKey             = "from_lsn"
+	xtrabackupToLSNKey               = "to_lsn"
+	xtrabackupBinlogInfoSeparator    = "\t"
+	xtrabackupBinlogInfoFieldNum     = 3
+	cloneLocalSQLTemplate            = "clone local data directory = '%s' ;"
+	getCloneGTIDExecutedSQL          = "select gtid_executed from performance_schema.clone_status ;"
+	getBackupSizeCommandTemplate     = `/usr/bin/du -sb %s | /usr/bin/awk -F' ' '{print \$1}'`
+	getBackupChecksumCommandTemplate = `cd %s && /usr/bin/find . -type f -print0 | /usr/bin/sort -z | /usr/bin/xargs -0 /usr/bin/md5sum | /usr/bin/md5sum | /usr/bin/awk -F' ' '{print \$1}'`
+
+	backupPurgeInterval = 10 * time.Minute
+	hoursPerDay         = 24
+)
+
+// CheckBackup checks if the backup type and the backup method are valid for the version of the engine,
+// the clone plugin only supports the full backup
+func (e *Engine) CheckBackup(backupType, backupMethod int) error {
+	if backupType != FullBackupType && backupType != IncrementalBackupType {
+		return errors.Errorf("mysql Engine.CheckBackup(): backup type must be %d(full) or %d(incremental). backupType: %d",
+			FullBackupType, IncrementalBackupType, backupType)
+	}
+
+	switch backupMethod {
+	case XtrabackupMethod:
+		return nil
+	case CloneMethod:
+		if backupType != FullBackupType {
+			return errors.New("mysql Engine.CheckBackup(): the clone plugin only supports the full backup")
+		}
+		minVersion, err := version.NewVersion(minCloneMySQLVersion)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if e.mysqlVersion.LessThan(minVersion) {
+			return errors.Errorf("mysql Engine.CheckBackup(): clone plugin requires mysql %s or later. version: %s",
+				minCloneMySQLVersion, e.mysqlVersion.String())
+		}
+
+		return nil
+	default:
+		return errors.Errorf("mysql Engine.CheckBackup(): backup method must be %d(xtrabackup) or %d(clone). backupMethod: %d",
+			XtrabackupMethod, CloneMethod, backupMethod)
+	}
+}
+
+// Backup takes the physical backups of the mysql instances of the addrs into the backup dir of the hosts,
+// the incremental backup is based on the latest successful xtrabackup backup set of the instance
+func (e *Engine) Backup(operationID, backupType, backupMethod int) error {
+	err := e.CheckBackup(backupType, backupMethod)
+	if err != nil {
+		return err
+	}
+
+	return e.runInstanceOperation(operationID, backupSuccessMessage, func(hostIP string, portNum int) error {
+		return e.BackupInstance(operationID, hostIP, portNum, backupType, backupMethod)
+	}, func(operationDetailID int, hostIP string, portNum int) {
+		log.Infof(message.NewMessage(msgMySQL.InfoMySQLEngineBackup, operationID, operationDetailID, hostIP, portNum, backupType, backupMethod).Error())
+	})
+}
+
+// BackupInstance takes the physical backup of the single instance and records the backup set,
+// the backup set will be recorded as failed if the backup failed
+func (e *Engine) BackupInstance(operationID int, hostIP string, portNum, backupType, backupMethod int) error {
+	err := e.initInstanceExecutor(hostIP, portNum)
+	if err != nil {
+		return err
+	}
+	isRunning, err := e.isInstanceRunning()
+	if err != nil {
+		return err
+	}
+	if !isRunning {
+		return errors.Errorf("mysql Engine.BackupInstance(): instance is not running. hostIP: %s, portNum: %d", hostIP, portNum)
+	}
+
+	var baseBackup *BackupInfo
+	if backupType == IncrementalBackupType {
+		baseBackup, err = e.dboRepo.GetLastBackup(hostIP, portNum, XtrabackupMethod)
+		if err != nil {
+			return err
+		}
+		if baseBackup == nil {
+			return errors.Errorf("mysql Engine.BackupInstance(): no successful backup found to be the base of the incremental backup. hostIP: %s, portNum: %d",
+				hostIP, portNum)
+		}
+	}
+
+	backupInfo := NewBackupInfoWithDefault()
+	backupInfo.HostIP = hostIP
+	backupInfo.PortNum = portNum
+	backupInfo.BackupDir = e.getBackupDir(backupType, backupMethod)
+	baseBackupID := constant.ZeroInt
+	if baseBackup != nil {
+		baseBackupID = baseBackup.ID
+	}
+	backupInfo.ID, err = e.dboRepo.InitBackup(operationID, hostIP, portNum, backupType, backupMethod, baseBackupID, backupInfo.BackupDir)
+	if err != nil {
+		return err
+	}
+
+	if backupMethod == CloneMethod {
+		err = e.cloneLocal(backupInfo)
+	} else {
+		err = e.xtrabackup(backupInfo, baseBackup)
+	}
+	if err == nil {
+		err = e.getBackupSizeAndChecksum(backupInfo)
+	}
+
+	backupInfo.Status = defaultSuccessStatus
+	backupInfo.Message = backupSuccessMessage
+	if err != nil {
+		backupInfo.Status = defaultFailedStatus
+		backupInfo.Message = err.Error()
+	}
+	updateErr := e.dboRepo.UpdateBackup(backupInfo)
+	if updateErr != nil {
+		log.Errorf(constant.LogWithStackString, message.NewMessage(msgMySQL.ErrMySQLEngineUpdateBackup, updateErr, backupInfo.ID, backupInfo.Status))
+		if err == nil {
+			return updateErr
+		}
+	}
+
+	return err
+}
+
+// getBackupDir returns the directory of the new backup set, it is under the backup dir of the instance
+func (e *Engine) getBackupDir(backupType, backupMethod int) string {
+	suffix := fullBackupDirSuffix
+	if backupMethod == CloneMethod {
+		suffix = cloneBackupDirSuffix
+	} else if backupType == IncrementalBackupType {
+		suffix = incrementalBackupDirSuffix
+	}
+
+	return filepath.Join(e.MySQLServer.BackupDir, strconv.Itoa(e.MySQLServer.PortNum),
+		fmt.Sprintf(backupDirNameTemplate, time.Now().Format(constant.TimeLayoutSecondDash), suffix))
+}
+
+// xtrabackup takes the backup with xtrabackup, the lsn and gtid set of the backup set are read from the files generated by xtrabackup
+func (e *Engine) xtrabackup(backupInfo *BackupInfo, baseBackup *BackupInfo) error {
+	err := e.ose.Conn.MkdirAll(backupInfo.BackupDir)
+	if err != nil {
+		return err
+	}
+	cmd := fmt.Sprintf(xtrabackupCommandTemplate, viper.GetString(config.MySQLBackupToolPathKey), defaultConfigFileName, e.MySQLServer.PortNum,
+		constant.DefaultRootUserName, e.MySQLServer.RootPass, e.MySQLServer.DataDirBase, backupInfo.BackupDir)
+	if baseBackup != nil {
+		cmd += fmt.Sprintf(xtrabackupIncrementalOption, baseBackup.BackupDir)
+	}
+	_, err = e.ose.Conn.ExecuteCommand(cmd)
+	if err != nil {
+		return err
+	}
+
+	// lsn
+	content, err := e.ose.Conn.Cat(filepath.Join(backupInfo.BackupDir, xtrabackupCheckpointsFileName))
+	if err != nil {
+		return err
+	}
+	backupInfo.FromLSN, backupInfo.ToLSN, err = parseXtrabackupCheckpoints(content)
+	if err != nil {
+		return err
+	}
+	// gtid set, the file does not exist if the binlog is disabled
+	binlogInfoPath := filepath.Join(backupInfo.BackupDir, xtrabackupBinlogInfoFileName)
+	exists, err := e.ose.Conn.PathExists(binlogInfoPath)
+	if err != nil || !exists {
+		return err
+	}
+	content, err = e.ose.Conn.Cat(binlogInfoPath)
+	if err != nil {
+		return err
+	}
+	backupInfo.GTIDSet = parseXtrabackupBinlogInfo(content)
+
+	return nil
+}
+
+// cloneLocal takes the backup with the clone plugin, the backup directory must not exist before cloning,
+// and its parent directory must be writable by the mysql user
+func (e *Engine) cloneLocal(backupInfo *BackupInfo) error {
+	parentDir := filepath.Dir(backupInfo.BackupDir)
+	err := e.ose.Conn.MkdirAll(parentDir)
+	if err != nil {
+		return err
+	}
+	err = e.ose.Conn.Chown(parentDir, defaultMySQLUser, defaultMySQLGroup)
+	if err != nil {
+		return err
+	}
+
+	addr := fmt.Sprintf(addrTemplate, e.MySQLServer.HostIP, e.MySQLServer.PortNum)
+	err = e.prepareCloneUser(addr, grantBackupAdminSQLTemplate)
+	if err != nil {
+		return err
+	}
+	conn, err := mysql.NewConn(addr, constant.EmptyString, e.MySQLServer.CloneUser, e.MySQLServer.ClonePass)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = conn.Close()
+		if err != nil {
+			log.Errorf("mysql Engine.cloneLocal(): close mysql connection failed. error:\n%+v", err)
+		}
+	}()
+
+	_, err = conn.Execute(fmt.Sprintf(cloneLocalSQLTemplate, backupInfo.BackupDir))
+	if err != nil {
+		return err
+	}
+	result, err := conn.Execute(getCloneGTIDExecutedSQL)
+	if err != nil {
+		return err
+	}
+	if result.RowNumber() > constant.ZeroInt {
+		gtidSet, err := result.GetString(constant.ZeroInt, constant.ZeroInt)
+		if err != nil {
+			return err
+		}
+		backupInfo.GTIDSet = strings.Join(strings.Fields(gtidSet), constant.EmptyString)
+	}
+
+	return nil
+}
+
+// getBackupSizeAndChecksum gets the size of the backup set and the checksum of all the files of the backup set
+func (e *Engine) getBackupSizeAndChecksum(backupInfo *BackupInfo) error {
+	output, err := e.ose.Conn.ExecuteCommand(fmt.Sprintf(getBackupSizeCommandTemplate, backupInfo.BackupDir))
+	if err != nil {
+		return err
+	}
+	backupInfo.BackupSize, err = strconv.ParseInt(strings.TrimSpace(output), 10, 64)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	output, err = e.ose.Conn.ExecuteCommand(fmt.Sprintf(getBackupChecksumCommandTemplate, backupInfo.BackupDir))
+	if err != nil {
+		return err
+	}
+	backupInfo.Checksum = strings.TrimSpace(output)
+
+	return nil
+}
+
+// parseXtrabackupCheckpoints returns the from lsn and the to lsn in the xtrabackup_checkpoints file
+func parseXtrabackupCheckpoints(content string) (int64, int64, error) {
+	values := make(map[string]string)
+	for _, line := range strings.Split(content, constant.CRLFString) {
+		kv := strings.SplitN(line, constant.EqualString, constant.TwoInt)
+		if len(kv) == constant.TwoInt {
+			values[strings.TrimSpace(kv[constant.ZeroInt])] = strings.TrimSpace(kv[constant.OneInt])
+		}
+	}
+
+	fromLSN, err := strconv.ParseInt(values[xtrabackupFromLSNKey], 10, 64)
+	if err != nil {
+		return constant.ZeroInt, constant.ZeroInt, errors.Trace(err)
+	}
+	toLSN, err := strconv.ParseInt(values[xtrabackupToLSNKey], 10, 64)
+	if err != nil {
+		return constant.ZeroInt, constant.ZeroInt, errors.Trace(err)
+	}
+
+	return fromLSN, toLSN, nil
+}
+
+// parseXtrabackupBinlogInfo returns the gtid set in the xtrabackup_binlog_info file,
+// the file contains the binlog file, the binlog position and the gtid set which may span multiple lines
+func parseXtrabackupBinlogInfo(content string) string {
+	fields := strings.SplitN(strings.TrimSpace(content), xtrabackupBinlogInfoSeparator, xtrabackupBinlogInfoFieldNum)
+	if len(fields) < xtrabackupBinlogInfoFieldNum {
+		return constant.EmptyString
+	}
+
+	return strings.Join(strings.Fields(fields[constant.TwoInt]), constant.EmptyString)
+}
+
+type BackupPurger struct {
+	*DBORepo
+}
+
+// NewBackupPurger returns a new *BackupPurger
+func NewBackupPurger(repo *DBORepo) *BackupPurger {
+	return newBackupPurger(repo)
+}
+
+// NewBackupPurgerWithDefault returns a new *BackupPurger with default value
+func NewBackupPurgerWithDefault() *BackupPurger {
+	return newBackupPurger(NewDBORepoWithDefault())
+}
+
+// newBackupPurger returns a new *BackupPurger
+func newBackupPurger(repo *DBORepo) *BackupPurger {
+	return &BackupPurger{DBORepo: repo}
+}
+
+// PurgeExpiredBackups purges the backup sets which are older than the retention days, it will execute periodically
+func (bp *BackupPurger) PurgeExpiredBackups() {
+	for {
+		err := bp.purgeExpiredBackups()
+		if err != nil {
+			log.Errorf("mysql BackupPurger.PurgeExpiredBackups(): purge expired backups failed.\n%+v", err)
+		}
+
+		time.Sleep(backupPurgeInterval)
+	}
+}
+
+// purgeExpiredBackups removes the directories of the expired backup sets and marks them as deleted,
+// the backup set which is still the base of a retained incremental backup set will be kept
+func (bp *BackupPurger) purgeExpiredBackups() error {
+	retention := time.Duration(viper.GetInt(config.MySQLBackupRetentionDaysKey)*hoursPerDay) * time.Hour
+	minTime := time.Now().Add(-retention).Format(constant.TimeLayoutSecond)
+
+	backups, err := bp.DBORepo.GetExpiredBackups(minTime)
+	if err != nil {
+		return err
+	}
+	for _, backup := range backups {
+		dependentCount, err := bp.DBORepo.GetDependentBackupCount(backup.ID)
+		if err != nil {
+			return err
+		}
+		if dependentCount > constant.ZeroInt {
+			continue
+		}
+		err = bp.purgeBackup(backup)
+		if err != nil {
+			// the other backup sets could still be purged
+			log.Errorf(constant.LogWithStackString, message.NewMessage(msgMySQL.ErrMySQLEnginePurgeBackup, err, backup.ID, backup.GetAddr(), backup.BackupDir))
+			continue
+		}
+
+		log.Infof(message.NewMessage(msgMySQL.InfoMySQLEnginePurgeBackup, backup.ID, backup.GetAddr(), backup.BackupDir).Error())
+	}
+
+	return nil
+}
+
+// purgeBackup removes the directory of the backup set on the host and marks the backup set as deleted
+func (bp *BackupPurger) purgeBackup(backup *BackupInfo) error {
+	sshConn, err := newBackupSSHConn(backup.HostIP)
+	if err != nil {
+		return err
+	}
+	err = sshConn.RemoveAll(backup.BackupDir)
+	if err != nil {
+		return err
+	}
+
+	return bp.DBORepo.DeleteBackup(backup.ID)
+}
+
+// newBackupSSHConn returns a new ssh connection to the host where the backup set is stored
+func newBackupSSHConn(hostIP string) (*linux.SSHConn, error) {
+	return linux.NewSSHConn(
+		hostIP,
+		constant.DefaultSSHPort,
+		viper.GetString(config.MySQLUserOSUserKey),
+		viper.GetString(config.MySQLUserOSPassKey),
+		defaultUseSudo,
+	)
+}
@@ -0,0 +1,72 @@
+package mysql
+
+import (
+	"testing"
+
+	"github.com/romberli/go-util/common"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testXtrabackupCheckpoints = "backup_type = full-backuped\nfrom_lsn = 0\nto_lsn = 19013468\nlast_lsn = 19013478\nflushed_lsn = 19013468\n"
+	testXtrabackupBinlogInfo  = "mysql-bin.000003\t1165\t3e11fa47-71ca-11e1-9e33-c80aa9429562:1-5,\n9a1e2f43-71ca-11e1-9e33-c80aa9429562:1-3\n"
+)
+
+func TestBackup_All(t *testing.T) {
+	TestParseXtrabackupCheckpoints(t)
+	TestParseXtrabackupBinlogInfo(t)
+	TestEngine_CheckBackup(t)
+	TestEngine_BackupInstance(t)
+}
+
+func TestParseXtrabackupCheckpoints(t *testing.T) {
+	asst := assert.New(t)
+
+	fromLSN, toLSN, err := parseXtrabackupCheckpoints(testXtrabackupCheckpoints)
+	asst.Nil(err, common.CombineMessageWithError("test parseXtrabackupCheckpoints() failed", err))
+	asst.Equal(int64(0), fromLSN, "test parseXtrabackupCheckpoints() failed")
+	asst.Equal(int64(19013468), toLSN, "test parseXtrabackupCheckpoints() failed")
+	// missing lsn
+	_, _, err = parseXtrabackupCheckpoints("backup_type = full-backuped\n")
+	asst.NotNil(err, "test parseXtrabackupCheckpoints() failed")
+}
+
+func TestParseXtrabackupBinlogInfo(t *testing.T) {
+	asst := assert.New(t)
+
+	asst.Equal("3e11fa47-71ca-11e1-9e33-c80aa9429562:1-5,9a1e2f43-71ca-11e1-9e33-c80aa9429562:1-3",
+		parseXtrabackupBinlogInfo(testXtrabackupBinlogInfo), "test parseXtrabackupBinlogInfo() failed")
+	// gtid is disabled
+	asst.Equal("", parseXtrabackupBinlogInfo("mysql-bin.000003\t1165\n"), "test parseXtrabackupBinlogInfo() failed")
+}
+
+func TestEngine_CheckBackup(t *testing.T) {
+	asst := assert.New(t)
+
+	err := testEngine.CheckBackup(FullBackupType, CloneMethod)
+	asst.Nil(err, common.CombineMessageWithError("test CheckBackup() failed", err))
+	err = testEngine.CheckBackup(IncrementalBackupType, XtrabackupMethod)
+	asst.Nil(err, common.CombineMessageWithError("test CheckBackup() failed", err))
+	// the clone plugin does not support the incremental backup
+	err = testEngine.CheckBackup(IncrementalBackupType, CloneMethod)
+	asst.NotNil(err, "test CheckBackup() failed")
+	err = testEngine.CheckBackup(FullBackupType, 3)
+	asst.NotNil(err, "test CheckBackup() failed")
+}
+
+func TestEngine_BackupInstance(t *testing.T) {
+	asst := assert.New(t)
+
+	err := testEngine.MySQLServer.InitWithHostInfo(testHostIP1, testPortNum1, true)
+	asst.Nil(err, common.CombineMessageWithError("test BackupInstance() failed", err))
+	err = testEngine.BackupInstance(testOperationID, testHostIP1, testPortNum1, FullBackupType, XtrabackupMethod)
+	asst.Nil(err, common.CombineMessageWithError("test BackupInstance() failed", err))
+	err = testEngine.BackupInstance(testOperationID, testHostIP1, testPortNum1, IncrementalBackupType, XtrabackupMethod)
+	asst.Nil(err, common.CombineMessageWithError("test BackupInstance() failed", err))
+	lastBackup, err := testDBORepo.GetLastBackup(testHostIP1, testPortNum1, XtrabackupMethod)
+	asst.Nil(err, common.CombineMessageWithError("test BackupInstance() failed", err))
+	asst.Equal(IncrementalBackupType, lastBackup.BackupType, "test BackupInstance() failed")
+	// truncate backup info
+	err = testTruncateBackupInfo()
+	asst.Nil(err, common.CombineMessageWithError("test BackupInstance() failed", err))
+}
@@ -0,0 +1,69 @@
+package mysql
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/romberli/go-util/constant"
+	"github.com/romberli/go-util/middleware/mysql"
+	"github.com/romberli/log"
+
+	"github.com/romberli/db-operator/pkg/message"
+
+	msgMySQL "github.com/romberli/db-operator/pkg/message/mysql"
+)
+
+const (
+	purgeBinaryLogsSQLTemplate = "purge binary logs before '%s' ;"
+)
+
+// PurgeBinaryLogs purges the binary logs which were written before the given time on the instances of the addrs,
+// the binary logs after the latest successful backup set of the instance are always kept for the point-in-time restore
+func (e *Engine) PurgeBinaryLogs(before time.Time) error {
+	for _, addr := range e.Addrs {
+		hostIP, portNum, err := splitAddr(addr)
+		if err != nil {
+			return err
+		}
+		purgeTime := before
+		backups, err := e.dboRepo.GetBackups(hostIP, portNum)
+		if err != nil {
+			return err
+		}
+		for _, backup := range backups {
+			if backup.Status == defaultSuccessStatus {
+				if backup.StartTime.Before(purgeTime) {
+					purgeTime = backup.StartTime
+				}
+				break
+			}
+		}
+
+		err = e.purgeBinaryLogs(addr, purgeTime.Format(constant.TimeLayoutSecond))
+		if err != nil {
+			return err
+		}
+
+		log.Infof(message.NewMessage(msgMySQL.InfoMySQLEnginePurgeBinaryLogs, addr, purgeTime.Format(constant.TimeLayoutSecond)).Error())
+	}
+
+	return nil
+}
+
+// purgeBinaryLogs purges the binary logs which were written before the given time on the instance of the addr
+func (e *Engine) purgeBinaryLogs(addr, before string) error {
+	conn, err := mysql.NewConn(addr, constant.EmptyString, constant.DefaultRootUserName, e.MySQLServer.RootPass)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = conn.Close()
+		if err != nil {
+			log.Errorf("mysql Engine.purgeBinaryLogs(): close mysql connection failed. addr: %s, error:\n%+v", addr, err)
+		}
+	}()
+
+	_, err = conn.Execute(fmt.Sprintf(purgeBinaryLogsSQLTemplate, before))
+
+	return err
+}
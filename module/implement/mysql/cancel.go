@@ -0,0 +1,130 @@
+package mysql
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/romberli/log"
+
+	"github.com/romberli/db-operator/pkg/message"
+
+	msgMySQL "github.com/romberli/db-operator/pkg/message/mysql"
+)
+
+const (
+	operationCancelledMessageTemplate = "operation cancelled, it stopped at the step which was running when the cancellation was requested. cancelled install operations could be resumed. error: %s"
+)
+
+// runningOperations holds the cancel functions of the operations which are run by this db-operator process
+var runningOperations = newOperationRegistry()
+
+type operationRegistry struct {
+	mutex       *sync.Mutex
+	cancelFuncs map[int]context.CancelFunc
+}
+
+// newOperationRegistry returns a new *operationRegistry
+func newOperationRegistry() *operationRegistry {
+	return &operationRegistry{
+		mutex:       &sync.Mutex{},
+		cancelFuncs: make(map[int]context.CancelFunc),
+	}
+}
+
+// register registers the cancel function of the running operation
+func (ro *operationRegistry) register(operationID int, cancel context.CancelFunc) {
+	ro.mutex.Lock()
+	defer ro.mutex.Unlock()
+
+	ro.cancelFuncs[operationID] = cancel
+}
+
+// unregister removes the cancel function of the finished operation
+func (ro *operationRegistry) unregister(operationID int) {
+	ro.mutex.Lock()
+	defer ro.mutex.Unlock()
+
+	delete(ro.cancelFuncs, operationID)
+}
+
+// cancel cancels the context of the operation, it returns false if the operation is not run by this db-operator process
+func (ro *operationRegistry) cancel(operationID int) bool {
+	ro.mutex.Lock()
+	defer ro.mutex.Unlock()
+
+	cancel, ok := ro.cancelFuncs[operationID]
+	if ok {
+		cancel()
+	}
+
+	return ok
+}
+
+type OperationCanceller struct {
+	*DBORepo
+}
+
+// NewOperationCanceller returns a new *OperationCanceller
+func NewOperationCanceller(repo *DBORepo) *OperationCanceller {
+	return newOperationCanceller(repo)
+}
+
+// NewOperationCancellerWithDefault returns a new *OperationCanceller with default repository
+func NewOperationCancellerWithDefault() *OperationCanceller {
+	return newOperationCanceller(NewDBORepoWithDefault())
+}
+
+// newOperationCanceller returns a new *OperationCanceller
+func newOperationCanceller(repo *DBORepo) *OperationCanceller {
+	return &OperationCanceller{
+		DBORepo: repo,
+	}
+}
+
+// Cancel requests the running operation to be cancelled, the cancellation is recorded in the middleware,
+// so that the operation could be cancelled no matter which db-operator process runs it.
+// the operation run by this process is cancelled immediately, the others will notice the request within a heartbeat of the operation lock
+func (oc *OperationCanceller) Cancel(operationID int) error {
+	operationInfo, err := oc.GetOperationHistory(operationID)
+	if err != nil {
+		return err
+	}
+	if operationInfo.Status != defaultRunningStatus {
+		return message.NewMessage(msgMySQL.ErrMySQLServiceNotCancellableOperation, operationID, operationInfo.Status)
+	}
+
+	err = oc.CancelOperation(operationID)
+	if err != nil {
+		return err
+	}
+	if !runningOperations.cancel(operationID) {
+		log.Infof("mysql OperationCanceller.Cancel(): the operation is run by another db-operator process, it will be cancelled after the next heartbeat. operationID: %d, owner: %s",
+			operationID, operationInfo.Owner)
+	}
+
+	return nil
+}
+
+// checkCancelled returns an error if the context of the engine is done, it is called at the step boundaries of the operation
+func (e *Engine) checkCancelled(hostIP string, portNum int) error {
+	err := e.ctx.Err()
+	if err != nil {
+		return message.NewMessage(msgMySQL.ErrMySQLEngineOperationCancelled, err, e.operationID, hostIP, portNum)
+	}
+
+	return nil
+}
+
+// sleep waits for the duration, it returns an error immediately if the context of the engine is done while waiting
+func (e *Engine) sleep(d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-e.ctx.Done():
+		return e.checkCancelled(e.MySQLServer.HostIP, e.MySQLServer.PortNum)
+	case <-timer.C:
+		return nil
+	}
+}
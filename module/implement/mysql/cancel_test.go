@@ -0,0 +1,62 @@
+package mysql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/romberli/go-util/constant"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testCancelSleepDuration = time.Minute
+)
+
+func TestOperationCanceller_All(t *testing.T) {
+	TestOperationCanceller_Cancel(t)
+	TestEngine_Sleep(t)
+}
+
+func TestOperationCanceller_Cancel(t *testing.T) {
+	asst := assert.New(t)
+
+	testOperationCanceller := NewOperationCanceller(testDBORepo)
+	// the operation run by this process is cancelled immediately
+	operationID, err := testDBORepo.InitOperationHistory(defaultInstallOperation, []string{testAddr1}, constant.EmptyString)
+	asst.Nil(err, "test Cancel() failed")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	runningOperations.register(operationID, cancel)
+	defer runningOperations.unregister(operationID)
+	err = testOperationCanceller.Cancel(operationID)
+	asst.Nil(err, "test Cancel() failed")
+	asst.NotNil(ctx.Err(), "test Cancel() failed")
+	cancelRequested, err := testDBORepo.IsCancelRequested(operationID)
+	asst.Nil(err, "test Cancel() failed")
+	asst.True(cancelRequested, "test Cancel() failed")
+	// the finished operation could not be cancelled
+	err = testDBORepo.UpdateOperationHistory(operationID, defaultSuccessStatus, constant.EmptyString)
+	asst.Nil(err, "test Cancel() failed")
+	err = testOperationCanceller.Cancel(operationID)
+	asst.NotNil(err, "test Cancel() failed")
+	// truncate operation info
+	err = testTruncateOperationInfo()
+	asst.Nil(err, "test Cancel() failed")
+}
+
+func TestEngine_Sleep(t *testing.T) {
+	asst := assert.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	testEngine.SetContext(ctx)
+	defer testEngine.SetContext(context.Background())
+
+	go cancel()
+	start := time.Now()
+	err := testEngine.sleep(testCancelSleepDuration)
+	asst.NotNil(err, "test Sleep() failed")
+	asst.Less(time.Since(start), testCancelSleepDuration, "test Sleep() failed")
+	err = testEngine.checkCancelled(testEngine.MySQLServer.HostIP, testEngine.MySQLServer.PortNum)
+	asst.NotNil(err, "test Sleep() failed")
+}
@@ -0,0 +1,93 @@
+package mysql
+
+import (
+	"github.com/pingcap/errors"
+	"github.com/romberli/go-util/constant"
+	"github.com/romberli/log"
+
+	"github.com/romberli/db-operator/pkg/message"
+
+	msgMySQL "github.com/romberli/db-operator/pkg/message/mysql"
+)
+
+// newInstanceInfo returns the information of the installed instance which will be registered to the cluster,
+// it must be called after the mysql server parameter and the os executor are initialized with the host info of the instance,
+// the effective mysql server parameter is saved with the instance, so that the config drift could be checked against it later
+func (e *Engine) newInstanceInfo(isSource bool) (*InstanceInfo, error) {
+	role := defaultReplicaRole
+	if isSource {
+		role = defaultSourceRole
+	}
+
+	pmmServiceName := constant.EmptyString
+	// pmm client is only installed on x64 platform
+	if e.ose.arch == constant.X64Arch {
+		var err error
+		pmmServiceName, err = NewPMMExecutor(e.ose.Conn, e.MySQLServer.HostIP, e.MySQLServer.PortNum, e.PMMClient).getServiceName()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	mysqlServerParam, err := e.MySQLServer.Marshal()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return NewInstanceInfo(e.MySQLServer.HostIP, e.MySQLServer.PortNum, role, e.MySQLServer.ServerID,
+		e.MySQLServer.DataDirBase, e.MySQLServer.LogDirBase, pmmServiceName, string(mysqlServerParam)), nil
+}
+
+// registerCluster saves the cluster and the instances in the repository, it does nothing if the cluster name is empty
+func (e *Engine) registerCluster(clusterInfo *ClusterInfo, instances []*InstanceInfo) error {
+	if clusterInfo.ClusterName == constant.EmptyString {
+		log.Warnf("mysql Engine.registerCluster(): cluster name is empty, the instances will not be registered. addrs: %v", e.Addrs)
+		return nil
+	}
+
+	clusterID, err := e.clusterRepo.SaveCluster(clusterInfo, instances)
+	if err != nil {
+		return err
+	}
+
+	log.Infof(message.NewMessage(msgMySQL.InfoMySQLEngineRegisterCluster, clusterID, clusterInfo.ClusterName, len(instances)).Error())
+
+	return nil
+}
+
+// registerReplicas registers the replicas to the cluster of the source,
+// it does nothing if the source does not belong to any cluster
+func (e *Engine) registerReplicas(sourceHostIP string, sourcePortNum int, instances []*InstanceInfo) error {
+	clusterInfo, err := e.clusterRepo.GetClusterByInstance(sourceHostIP, sourcePortNum)
+	if err != nil {
+		log.Warnf("mysql Engine.registerReplicas(): get the cluster of the source failed, the replicas will not be registered. hostIP: %s, portNum: %d, error:\n%+v",
+			sourceHostIP, sourcePortNum, err)
+		return nil
+	}
+
+	return e.registerCluster(clusterInfo, instances)
+}
+
+// setClusterSource sets the instance of the addr as the source of its cluster in the repository,
+// it only logs the error, because the topology of the cluster was already changed
+func (e *Engine) setClusterSource(addr string) {
+	hostIP, portNum, err := splitAddr(addr)
+	if err == nil {
+		err = e.clusterRepo.SetSource(hostIP, portNum)
+	}
+	if err != nil {
+		log.Errorf(constant.LogWithStackString, message.NewMessage(msgMySQL.ErrMySQLEngineUpdateCluster, err, addr))
+	}
+}
+
+// unregisterInstance deletes the instance of the addr from its cluster in the repository,
+// it only logs the error, because the instance was already removed
+func (e *Engine) unregisterInstance(addr string) {
+	hostIP, portNum, err := splitAddr(addr)
+	if err == nil {
+		err = e.clusterRepo.DeleteInstance(hostIP, portNum)
+	}
+	if err != nil {
+		log.Errorf(constant.LogWithStackString, message.NewMessage(msgMySQL.ErrMySQLEngineUpdateCluster, err, addr))
+	}
+}
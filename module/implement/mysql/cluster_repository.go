@@ -0,0 +1,367 @@
+package mysql
+
+import (
+	"github.com/pingcap/errors"
+	"github.com/romberli/go-util/constant"
+	"github.com/romberli/go-util/middleware"
+	"github.com/romberli/log"
+
+	"github.com/romberli/db-operator/global"
+	"github.com/romberli/db-operator/pkg/message"
+
+	msgMySQL "github.com/romberli/db-operator/pkg/message/mysql"
+)
+
+const (
+	defaultSourceRole  = 1
+	defaultReplicaRole = 2
+)
+
+type ClusterRepo struct {
+	Database middleware.Pool
+}
+
+// NewClusterRepo returns a new *ClusterRepo
+func NewClusterRepo(db middleware.Pool) *ClusterRepo {
+	return newClusterRepo(db)
+}
+
+// NewClusterRepoWithDefault returns a new *ClusterRepo with default middleware.Pool
+func NewClusterRepoWithDefault() *ClusterRepo {
+	return newClusterRepo(global.DBOMySQLPool)
+}
+
+// newClusterRepo returns a new *ClusterRepo
+func newClusterRepo(db middleware.Pool) *ClusterRepo {
+	return &ClusterRepo{
+		Database: db,
+	}
+}
+
+// Execute executes given command and placeholders on the middleware
+func (cr *ClusterRepo) Execute(command string, args ...interface{}) (middleware.Result, error) {
+	conn, err := cr.Database.Get()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = conn.Close()
+		if err != nil {
+			log.Errorf("mysql ClusterRepo.Execute(): close database connection failed.\n%+v", err)
+		}
+	}()
+
+	return conn.Execute(command, args...)
+}
+
+// Transaction returns a middleware.Transaction that could execute multiple commands as a transaction
+func (cr *ClusterRepo) Transaction() (middleware.Transaction, error) {
+	return cr.Database.Transaction()
+}
+
+// GetClusters gets all the mysql clusters from the middleware, it returns an empty slice if no cluster found
+func (cr *ClusterRepo) GetClusters() ([]*ClusterInfo, error) {
+	sql := `
+		SELECT id,
+			   cluster_name,
+			   mode,
+			   version,
+			   del_flag,
+			   create_time,
+			   last_update_time
+		FROM t_mysql_cluster
+		WHERE del_flag = 0
+		ORDER BY id ASC
+	`
+	log.Debugf("mysql ClusterRepo.GetClusters() select sql: \n%s", sql)
+
+	result, err := cr.Execute(sql)
+	if err != nil {
+		return nil, err
+	}
+
+	clusterInfoList := make([]*ClusterInfo, result.RowNumber())
+	for i := constant.ZeroInt; i < result.RowNumber(); i++ {
+		clusterInfoList[i] = NewClusterInfoWithDefault()
+	}
+
+	err = result.MapToStructSlice(clusterInfoList, constant.DefaultMiddlewareTag)
+	if err != nil {
+		return nil, err
+	}
+
+	return clusterInfoList, nil
+}
+
+// GetClusterByName gets the mysql cluster of the given name from the middleware
+func (cr *ClusterRepo) GetClusterByName(clusterName string) (*ClusterInfo, error) {
+	clusterInfo, err := cr.findClusterByName(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	if clusterInfo == nil {
+		return nil, errors.Errorf("mysql ClusterRepo.GetClusterByName(): no cluster found. clusterName: %s", clusterName)
+	}
+
+	return clusterInfo, nil
+}
+
+// findClusterByName finds the mysql cluster of the given name from the middleware, it returns nil if no cluster found
+func (cr *ClusterRepo) findClusterByName(clusterName string) (*ClusterInfo, error) {
+	sql := `
+		SELECT id,
+			   cluster_name,
+			   mode,
+			   version,
+			   del_flag,
+			   create_time,
+			   last_update_time
+		FROM t_mysql_cluster
+		WHERE del_flag = 0
+		  AND cluster_name = ?
+	`
+	log.Debugf("mysql ClusterRepo.findClusterByName() select sql: \n%s\nplaceholders: %s", sql, clusterName)
+
+	result, err := cr.Execute(sql, clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.RowNumber() == constant.ZeroInt {
+		return nil, nil
+	}
+
+	clusterInfo := NewClusterInfoWithDefault()
+	err = result.MapToStructByRowIndex(clusterInfo, constant.ZeroInt, constant.DefaultMiddlewareTag)
+	if err != nil {
+		return nil, err
+	}
+
+	return clusterInfo, nil
+}
+
+// GetClusterByInstance gets the mysql cluster which the instance of the given host info belongs to from the middleware
+func (cr *ClusterRepo) GetClusterByInstance(hostIP string, portNum int) (*ClusterInfo, error) {
+	sql := `
+		SELECT c.id,
+			   c.cluster_name,
+			   c.mode,
+			   c.version,
+			   c.del_flag,
+			   c.create_time,
+			   c.last_update_time
+		FROM t_mysql_cluster c
+			INNER JOIN t_mysql_instance i ON c.id = i.cluster_id
+		WHERE c.del_flag = 0
+		  AND i.del_flag = 0
+		  AND i.host_ip = ?
+		  AND i.port_num = ?
+	`
+	log.Debugf("mysql ClusterRepo.GetClusterByInstance() select sql: \n%s\nplaceholders: %s, %d", sql, hostIP, portNum)
+
+	result, err := cr.Execute(sql, hostIP, portNum)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.RowNumber() == constant.ZeroInt {
+		return nil, errors.Errorf("mysql ClusterRepo.GetClusterByInstance(): no cluster found. hostIP: %s, portNum: %d", hostIP, portNum)
+	}
+
+	clusterInfo := NewClusterInfoWithDefault()
+	err = result.MapToStructByRowIndex(clusterInfo, constant.ZeroInt, constant.DefaultMiddlewareTag)
+	if err != nil {
+		return nil, err
+	}
+
+	return clusterInfo, nil
+}
+
+// GetClusterByAddr gets the mysql cluster which the instance of the addr belongs to from the middleware
+func (cr *ClusterRepo) GetClusterByAddr(addr string) (*ClusterInfo, error) {
+	hostIP, portNum, err := splitAddr(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	return cr.GetClusterByInstance(hostIP, portNum)
+}
+
+// GetClusterDetail gets the mysql cluster of the given name and its instances from the middleware
+func (cr *ClusterRepo) GetClusterDetail(clusterName string) (*ClusterDetail, error) {
+	clusterInfo, err := cr.GetClusterByName(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	instances, err := cr.GetInstances(clusterInfo.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewClusterDetail(clusterInfo, instances), nil
+}
+
+// GetInstances gets the mysql instances of the cluster from the middleware, it returns an empty slice if no instance found
+func (cr *ClusterRepo) GetInstances(clusterID int) ([]*InstanceInfo, error) {
+	sql := `
+		SELECT id,
+			   cluster_id,
+			   host_ip,
+			   port_num,
+			   role,
+			   server_id,
+			   data_dir_base,
+			   log_dir_base,
+			   pmm_service_name,
+			   mysql_server_param,
+			   del_flag,
+			   create_time,
+			   last_update_time
+		FROM t_mysql_instance
+		WHERE del_flag = 0
+		  AND cluster_id = ?
+		ORDER BY role ASC, id ASC
+	`
+	log.Debugf("mysql ClusterRepo.GetInstances() select sql: \n%s\nplaceholders: %d", sql, clusterID)
+
+	result, err := cr.Execute(sql, clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	instanceInfoList := make([]*InstanceInfo, result.RowNumber())
+	for i := constant.ZeroInt; i < result.RowNumber(); i++ {
+		instanceInfoList[i] = NewInstanceInfoWithDefault()
+	}
+
+	err = result.MapToStructSlice(instanceInfoList, constant.DefaultMiddlewareTag)
+	if err != nil {
+		return nil, err
+	}
+
+	return instanceInfoList, nil
+}
+
+// SaveCluster saves the mysql cluster and its instances in the middleware as a transaction,
+// the existing cluster and instances will be updated, and it returns the cluster id
+func (cr *ClusterRepo) SaveCluster(clusterInfo *ClusterInfo, instances []*InstanceInfo) (int, error) {
+	clusterSQL := `
+		INSERT INTO t_mysql_cluster(cluster_name, mode, version) VALUES(?, ?, ?)
+		ON DUPLICATE KEY UPDATE id = LAST_INSERT_ID(id), mode = VALUES(mode), version = VALUES(version), del_flag = 0 ;
+	`
+	instanceSQL := `
+		INSERT INTO t_mysql_instance(cluster_id, host_ip, port_num, role, server_id, data_dir_base, log_dir_base, pmm_service_name, mysql_server_param)
+		VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE cluster_id = VALUES(cluster_id), role = VALUES(role), server_id = VALUES(server_id),
+			data_dir_base = VALUES(data_dir_base), log_dir_base = VALUES(log_dir_base), pmm_service_name = VALUES(pmm_service_name),
+			mysql_server_param = VALUES(mysql_server_param), del_flag = 0 ;
+	`
+
+	tx, err := cr.Transaction()
+	if err != nil {
+		return constant.ZeroInt, err
+	}
+	defer func() {
+		err = tx.Close()
+		if err != nil {
+			log.Errorf("mysql ClusterRepo.SaveCluster(): close database connection failed.\n%+v", err)
+		}
+	}()
+
+	err = tx.Begin()
+	if err != nil {
+		return constant.ZeroInt, err
+	}
+	clusterID, err := cr.saveCluster(tx, clusterSQL, instanceSQL, clusterInfo, instances)
+	if err != nil {
+		rollbackErr := tx.Rollback()
+		if rollbackErr != nil {
+			log.Errorf("mysql ClusterRepo.SaveCluster(): rollback failed.\n%+v", rollbackErr)
+		}
+		return constant.ZeroInt, message.NewMessage(msgMySQL.ErrMySQLRepositorySaveCluster, err, clusterInfo.ClusterName)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return constant.ZeroInt, message.NewMessage(msgMySQL.ErrMySQLRepositorySaveCluster, err, clusterInfo.ClusterName)
+	}
+
+	return clusterID, nil
+}
+
+// saveCluster executes the sqls of saving the cluster and its instances in the transaction
+func (cr *ClusterRepo) saveCluster(tx middleware.Transaction, clusterSQL, instanceSQL string, clusterInfo *ClusterInfo, instances []*InstanceInfo) (int, error) {
+	log.Debugf("mysql ClusterRepo.saveCluster() insert sql: \n%s\nplaceholders: %s, %d, %s",
+		clusterSQL, clusterInfo.ClusterName, clusterInfo.Mode, clusterInfo.Version)
+	result, err := tx.Execute(clusterSQL, clusterInfo.ClusterName, clusterInfo.Mode, clusterInfo.Version)
+	if err != nil {
+		return constant.ZeroInt, err
+	}
+	clusterID, err := result.LastInsertID()
+	if err != nil {
+		return constant.ZeroInt, err
+	}
+
+	for _, instance := range instances {
+		log.Debugf("mysql ClusterRepo.saveCluster() insert sql: \n%s\nplaceholders: %d, %s, %d, %d, %d, %s, %s, %s",
+			instanceSQL, clusterID, instance.HostIP, instance.PortNum, instance.Role, instance.ServerID,
+			instance.DataDirBase, instance.LogDirBase, instance.PMMServiceName)
+		_, err = tx.Execute(instanceSQL, clusterID, instance.HostIP, instance.PortNum, instance.Role, instance.ServerID,
+			instance.DataDirBase, instance.LogDirBase, instance.PMMServiceName, instance.MySQLServerParam)
+		if err != nil {
+			return constant.ZeroInt, err
+		}
+	}
+
+	return clusterID, nil
+}
+
+// SetSource sets the instance of the given host info as the source of its cluster,
+// the other instances of the cluster will be set as the replicas
+func (cr *ClusterRepo) SetSource(hostIP string, portNum int) error {
+	sql := `
+		UPDATE t_mysql_instance i
+			INNER JOIN t_mysql_instance s ON i.cluster_id = s.cluster_id
+		SET i.role = IF(i.id = s.id, ?, ?)
+		WHERE i.del_flag = 0
+		  AND s.del_flag = 0
+		  AND s.host_ip = ?
+		  AND s.port_num = ? ;
+	`
+	log.Debugf("mysql ClusterRepo.SetSource() update sql: \n%s\nplaceholders: %d, %d, %s, %d",
+		sql, defaultSourceRole, defaultReplicaRole, hostIP, portNum)
+
+	_, err := cr.Execute(sql, defaultSourceRole, defaultReplicaRole, hostIP, portNum)
+	if err != nil {
+		return message.NewMessage(msgMySQL.ErrMySQLRepositorySetSource, err, hostIP, portNum)
+	}
+
+	return nil
+}
+
+// DeleteInstance deletes the mysql instance of the given host info in the middleware,
+// the cluster will also be deleted if it does not have any instance
+func (cr *ClusterRepo) DeleteInstance(hostIP string, portNum int) error {
+	instanceSQL := `UPDATE t_mysql_instance SET del_flag = 1 WHERE host_ip = ? AND port_num = ? ;`
+	log.Debugf("mysql ClusterRepo.DeleteInstance() update sql: \n%s\nplaceholders: %s, %d", instanceSQL, hostIP, portNum)
+
+	_, err := cr.Execute(instanceSQL, hostIP, portNum)
+	if err != nil {
+		return message.NewMessage(msgMySQL.ErrMySQLRepositoryDeleteInstance, err, hostIP, portNum)
+	}
+
+	clusterSQL := `
+		UPDATE t_mysql_cluster c
+		SET c.del_flag = 1
+		WHERE c.del_flag = 0
+		  AND NOT EXISTS(SELECT 1 FROM t_mysql_instance i WHERE i.cluster_id = c.id AND i.del_flag = 0) ;
+	`
+	log.Debugf("mysql ClusterRepo.DeleteInstance() update sql: \n%s", clusterSQL)
+
+	_, err = cr.Execute(clusterSQL)
+	if err != nil {
+		return message.NewMessage(msgMySQL.ErrMySQLRepositoryDeleteInstance, err, hostIP, portNum)
+	}
+
+	return nil
+}
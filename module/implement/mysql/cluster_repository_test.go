@@ -0,0 +1,127 @@
+package mysql
+
+import (
+	"testing"
+
+	"github.com/romberli/go-util/constant"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testClusterRepo *ClusterRepo
+)
+
+func init() {
+	testInitViper()
+	testInitDBOMySQLPool()
+	testClusterRepo = testInitClusterRepo()
+}
+
+func testInitClusterRepo() *ClusterRepo {
+	return NewClusterRepoWithDefault()
+}
+
+func testTruncateCluster() error {
+	sql := `truncate table t_mysql_cluster ;`
+	_, err := testClusterRepo.Execute(sql)
+	if err != nil {
+		return err
+	}
+
+	sql = `truncate table t_mysql_instance ;`
+	_, err = testClusterRepo.Execute(sql)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func testSaveCluster() (int, error) {
+	return testClusterRepo.SaveCluster(NewClusterInfo(testClusterName, int(testMode), testVersion), []*InstanceInfo{
+		NewInstanceInfo(testHostIP1, testPortNum1, defaultSourceRole, testServerID, testDataDirBaseName, testLogDirBaseName, constant.EmptyString, constant.EmptyString),
+		NewInstanceInfo(testHostIP2, testPortNum2, defaultReplicaRole, testServerID, testDataDirBaseName, testLogDirBaseName, constant.EmptyString, constant.EmptyString),
+	})
+}
+
+func TestClusterRepo_All(t *testing.T) {
+	TestClusterRepo_SaveCluster(t)
+	TestClusterRepo_GetClusterByInstance(t)
+	TestClusterRepo_SetSource(t)
+	TestClusterRepo_DeleteInstance(t)
+}
+
+func TestClusterRepo_SaveCluster(t *testing.T) {
+	asst := assert.New(t)
+
+	clusterID, err := testSaveCluster()
+	asst.Nil(err, "test SaveCluster() failed")
+	// save the same cluster again
+	newClusterID, err := testSaveCluster()
+	asst.Nil(err, "test SaveCluster() failed")
+	asst.Equal(clusterID, newClusterID, "test SaveCluster() failed")
+	clusters, err := testClusterRepo.GetClusters()
+	asst.Nil(err, "test SaveCluster() failed")
+	asst.Equal(constant.OneInt, len(clusters), "test SaveCluster() failed")
+	clusterDetail, err := testClusterRepo.GetClusterDetail(testClusterName)
+	asst.Nil(err, "test SaveCluster() failed")
+	asst.Equal(constant.TwoInt, len(clusterDetail.Instances), "test SaveCluster() failed")
+	// truncate cluster
+	err = testTruncateCluster()
+	asst.Nil(err, "test SaveCluster() failed")
+}
+
+func TestClusterRepo_GetClusterByInstance(t *testing.T) {
+	asst := assert.New(t)
+
+	clusterID, err := testSaveCluster()
+	asst.Nil(err, "test GetClusterByInstance() failed")
+	clusterInfo, err := testClusterRepo.GetClusterByInstance(testHostIP2, testPortNum2)
+	asst.Nil(err, "test GetClusterByInstance() failed")
+	asst.Equal(clusterID, clusterInfo.ID, "test GetClusterByInstance() failed")
+	// truncate cluster
+	err = testTruncateCluster()
+	asst.Nil(err, "test GetClusterByInstance() failed")
+}
+
+func TestClusterRepo_SetSource(t *testing.T) {
+	asst := assert.New(t)
+
+	clusterID, err := testSaveCluster()
+	asst.Nil(err, "test SetSource() failed")
+	err = testClusterRepo.SetSource(testHostIP2, testPortNum2)
+	asst.Nil(err, "test SetSource() failed")
+	instances, err := testClusterRepo.GetInstances(clusterID)
+	asst.Nil(err, "test SetSource() failed")
+	for _, instance := range instances {
+		if instance.HostIP == testHostIP2 && instance.PortNum == testPortNum2 {
+			asst.Equal(defaultSourceRole, instance.Role, "test SetSource() failed")
+			continue
+		}
+		asst.Equal(defaultReplicaRole, instance.Role, "test SetSource() failed")
+	}
+	// truncate cluster
+	err = testTruncateCluster()
+	asst.Nil(err, "test SetSource() failed")
+}
+
+func TestClusterRepo_DeleteInstance(t *testing.T) {
+	asst := assert.New(t)
+
+	_, err := testSaveCluster()
+	asst.Nil(err, "test DeleteInstance() failed")
+	err = testClusterRepo.DeleteInstance(testHostIP1, testPortNum1)
+	asst.Nil(err, "test DeleteInstance() failed")
+	clusters, err := testClusterRepo.GetClusters()
+	asst.Nil(err, "test DeleteInstance() failed")
+	asst.Equal(constant.OneInt, len(clusters), "test DeleteInstance() failed")
+	// the cluster will be deleted after all the instances are deleted
+	err = testClusterRepo.DeleteInstance(testHostIP2, testPortNum2)
+	asst.Nil(err, "test DeleteInstance() failed")
+	clusters, err = testClusterRepo.GetClusters()
+	asst.Nil(err, "test DeleteInstance() failed")
+	asst.Equal(constant.ZeroInt, len(clusters), "test DeleteInstance() failed")
+	// truncate cluster
+	err = testTruncateCluster()
+	asst.Nil(err, "test DeleteInstance() failed")
+}
@@ -0,0 +1,333 @@
+package mysql
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/pingcap/errors"
+	"github.com/romberli/go-util/constant"
+	"github.com/romberli/go-util/middleware/mysql"
+	"github.com/romberli/log"
+
+	"github.com/romberli/db-operator/module/implement/mysql/mode"
+	"github.com/romberli/db-operator/module/implement/mysql/parameter"
+)
+
+const (
+	getGlobalVariablesSQL    = "show global variables ;"
+	getPersistedVariablesSQL = "select variable_name as Variable_name, variable_value as Value from performance_schema.persisted_variables ;"
+	VariableNameField        = "Variable_name"
+	VariableValueField       = "Value"
+
+	configCommentPrefix          = "#"
+	configSemicolonCommentPrefix = ";"
+	configQuoteChars             = `'"`
+	configPathSeparator          = "/"
+
+	GroupReplicationGroupNameVariable  = "group_replication_group_name"
+	GroupReplicationGroupSeedsVariable = "group_replication_group_seeds"
+)
+
+var (
+	// configBoolValues maps the boolean values to the form shown by show global variables
+	configBoolValues = map[string]string{"on": "1", "true": "1", "off": "0", "false": "0"}
+	// driftIgnoredRuntimeVariables are the options whose runtime value has a different meaning from the config file,
+	// e.g. log-bin is the binlog path in the config file, but log_bin is ON or OFF at runtime
+	driftIgnoredRuntimeVariables = map[string]bool{"log_bin": true}
+)
+
+// GetConfigDriftByAddr gets the config drift of the instance of the addr with the engine of the version and the mode of its cluster,
+// the template is rendered from the effective mysql server parameter which was saved when the instance was installed,
+// the template comparison is skipped if the instance was registered before the parameter was saved
+func GetConfigDriftByAddr(addr string) (*InstanceDrift, error) {
+	hostIP, portNum, err := splitAddr(addr)
+	if err != nil {
+		return nil, err
+	}
+	clusterRepo := NewClusterRepoWithDefault()
+	clusterInfo, err := clusterRepo.GetClusterByInstance(hostIP, portNum)
+	if err != nil {
+		return nil, err
+	}
+	mysqlVersion, err := version.NewVersion(clusterInfo.Version)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	instances, err := clusterRepo.GetInstances(clusterInfo.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	mysqlServerParam := parameter.NewMySQLServerWithDefault()
+	withTemplate := false
+	for _, instance := range instances {
+		if instance.HostIP == hostIP && instance.PortNum == portNum && instance.MySQLServerParam != constant.EmptyString {
+			err = mysqlServerParam.Unmarshal([]byte(instance.MySQLServerParam))
+			if err != nil {
+				return nil, err
+			}
+			withTemplate = true
+		}
+	}
+	if !withTemplate {
+		log.Warnf("mysql GetConfigDriftByAddr(): the effective mysql server parameter of the instance was not saved, will skip comparing with the template. addr: %s", addr)
+	}
+	// the cluster may have been upgraded after the instance was installed
+	mysqlServerParam.SetVersion(clusterInfo.Version)
+
+	e := NewEngineWithDefault(mysqlVersion, mode.Mode(clusterInfo.Mode), []string{addr}, mysqlServerParam, nil)
+
+	return e.getConfigDrift(addr, withTemplate)
+}
+
+// GetConfigDrift compares the instance section rendered from the template, the instance section of the config file
+// and the runtime variables of the instance of the addr, it returns the options which are not consistent,
+// the runtime comparison is skipped and the error is recorded if the instance could not be connected
+func (e *Engine) GetConfigDrift(addr string) (*InstanceDrift, error) {
+	return e.getConfigDrift(addr, true)
+}
+
+// getConfigDrift compares the config values of the instance of the addr, the template comparison is skipped if withTemplate is false,
+// the runtime values which were changed with set persist are not treated as drift
+func (e *Engine) getConfigDrift(addr string, withTemplate bool) (*InstanceDrift, error) {
+	hostIP, portNum, err := splitAddr(addr)
+	if err != nil {
+		return nil, err
+	}
+	isSource, err := e.isRegisteredSource(hostIP, portNum)
+	if err != nil {
+		return nil, err
+	}
+	// reset MySQL Sever Parameter
+	err = e.MySQLServer.InitWithHostInfo(hostIP, portNum, isSource)
+	if err != nil {
+		return nil, err
+	}
+	// init os executor
+	err = e.InitOSExecutor()
+	if err != nil {
+		return nil, err
+	}
+
+	// file
+	content, err := e.ose.Conn.Cat(defaultConfigFileName)
+	if err != nil {
+		return nil, err
+	}
+	section := fmt.Sprintf(mysqldMultiInstanceSectionTemplate, portNum)
+	fileValues, found := getConfigSectionValues(content, section)
+	if !found {
+		return nil, errors.Errorf("mysql Engine.GetConfigDrift(): instance section not found in the config file. hostIP: %s, section: %s", hostIP, section)
+	}
+
+	// template
+	var templateValues map[string]string
+	if withTemplate {
+		if e.Mode == mode.GroupReplication && e.MySQLServer.GroupReplicationGroupName == constant.EmptyString {
+			// the group name and seeds are generated when installing, they are not part of the template
+			e.MySQLServer.SetGroupReplicationGroupName(fileValues[GroupReplicationGroupNameVariable])
+			e.MySQLServer.SetGroupReplicationGroupSeeds(fileValues[GroupReplicationGroupSeedsVariable])
+		}
+		templateBytes, err := e.MySQLServer.GetMySQLDConfigWithTitle(e.getMultiInstanceTitle(), e.mysqlVersion, e.Mode)
+		if err != nil {
+			return nil, err
+		}
+		templateValues, _ = getConfigSectionValues(string(templateBytes), section)
+	}
+
+	// runtime
+	var runtimeError string
+	runtimeValues, persistedValues, err := e.getRuntimeVariables(addr)
+	if err != nil {
+		log.Warnf("mysql Engine.GetConfigDrift(): get runtime variables failed, will skip comparing with the runtime variables. addr: %s, error:\n%+v", addr, err)
+		runtimeError = err.Error()
+	}
+
+	return NewInstanceDrift(addr, runtimeError, compareConfigValues(templateValues, fileValues, runtimeValues, persistedValues)), nil
+}
+
+// isRegisteredSource returns if the instance is registered as the source of its cluster
+func (e *Engine) isRegisteredSource(hostIP string, portNum int) (bool, error) {
+	clusterInfo, err := e.clusterRepo.GetClusterByInstance(hostIP, portNum)
+	if err != nil {
+		return false, err
+	}
+	instances, err := e.clusterRepo.GetInstances(clusterInfo.ID)
+	if err != nil {
+		return false, err
+	}
+	for _, instance := range instances {
+		if instance.HostIP == hostIP && instance.PortNum == portNum {
+			return instance.Role == defaultSourceRole, nil
+		}
+	}
+
+	return false, errors.Errorf("mysql Engine.isRegisteredSource(): instance is not registered. hostIP: %s, portNum: %d", hostIP, portNum)
+}
+
+// getGlobalVariables returns the global variables of the instance of the addr, the key is the variable name
+func (e *Engine) getGlobalVariables(addr string) (map[string]string, error) {
+	conn, err := mysql.NewConn(addr, constant.EmptyString, constant.DefaultRootUserName, e.MySQLServer.RootPass)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = conn.Close()
+		if err != nil {
+			log.Errorf("mysql Engine.getGlobalVariables(): close mysql connection failed. error:\n%+v", err)
+		}
+	}()
+
+	return getVariables(conn, getGlobalVariablesSQL)
+}
+
+// getRuntimeVariables returns the global variables and the persisted variables of the instance of the addr,
+// the persisted variables are empty if the instance does not support set persist
+func (e *Engine) getRuntimeVariables(addr string) (map[string]string, map[string]string, error) {
+	conn, err := mysql.NewConn(addr, constant.EmptyString, constant.DefaultRootUserName, e.MySQLServer.RootPass)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() {
+		err = conn.Close()
+		if err != nil {
+			log.Errorf("mysql Engine.getRuntimeVariables(): close mysql connection failed. error:\n%+v", err)
+		}
+	}()
+
+	globalVariables, err := getVariables(conn, getGlobalVariablesSQL)
+	if err != nil {
+		return nil, nil, err
+	}
+	rs, err := getConnReplicationSyntax(conn)
+	if err != nil {
+		return nil, nil, err
+	}
+	if rs.setVariableSQLTemplate != setPersistVariableSQLTemplate {
+		return globalVariables, map[string]string{}, nil
+	}
+	persistedVariables, err := getVariables(conn, getPersistedVariablesSQL)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return globalVariables, persistedVariables, nil
+}
+
+// getVariables returns the variables which are queried by the given sql, the key is the variable name
+func getVariables(conn *mysql.Conn, sql string) (map[string]string, error) {
+	result, err := conn.Execute(sql)
+	if err != nil {
+		return nil, err
+	}
+
+	variables := make(map[string]string, result.RowNumber())
+	for i := constant.ZeroInt; i < result.RowNumber(); i++ {
+		name, err := result.GetStringByName(i, VariableNameField)
+		if err != nil {
+			return nil, err
+		}
+		value, err := result.GetStringByName(i, VariableValueField)
+		if err != nil {
+			return nil, err
+		}
+		variables[name] = value
+	}
+
+	return variables, nil
+}
+
+// getConfigSectionValues returns the options of the given section of the config content, the key is the normalized option name,
+// it returns whether the section was found
+func getConfigSectionValues(content, section string) (map[string]string, bool) {
+	values := make(map[string]string)
+
+	var found, inSection bool
+	for _, line := range strings.Split(content, constant.CRLFString) {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, configSectionPrefix) {
+			// a new section starts
+			inSection = trimmed == section
+			if inSection {
+				found = true
+			}
+			continue
+		}
+		if !inSection || trimmed == constant.EmptyString ||
+			strings.HasPrefix(trimmed, configCommentPrefix) || strings.HasPrefix(trimmed, configSemicolonCommentPrefix) {
+			continue
+		}
+
+		kv := strings.SplitN(trimmed, constant.EqualString, constant.TwoInt)
+		value := constant.EmptyString
+		if len(kv) == constant.TwoInt {
+			value = strings.Trim(strings.TrimSpace(kv[constant.OneInt]), configQuoteChars)
+		}
+		values[parameter.NormalizeVariableName(kv[constant.ZeroInt])] = value
+	}
+
+	return values, found
+}
+
+// compareConfigValues compares the template, file and runtime values of the options in the template or the file,
+// it returns the options which are not consistent, sorted by the option name.
+// the template comparison is skipped if the template values are nil,
+// and the runtime value which is the same as the persisted value is not treated as drift, because it was changed with set persist on purpose
+func compareConfigValues(templateValues, fileValues, runtimeValues, persistedValues map[string]string) []*ConfigDrift {
+	names := make(map[string]bool, len(templateValues)+len(fileValues))
+	for name := range templateValues {
+		names[name] = true
+	}
+	for name := range fileValues {
+		names[name] = true
+	}
+	sortedNames := make([]string, constant.ZeroInt, len(names))
+	for name := range names {
+		sortedNames = append(sortedNames, name)
+	}
+	sort.Strings(sortedNames)
+
+	drifts := []*ConfigDrift{}
+	for _, name := range sortedNames {
+		templateValue, inTemplate := templateValues[name]
+		fileValue, inFile := fileValues[name]
+		runtimeValue, inRuntime := runtimeValues[name]
+		persistedValue, inPersisted := persistedValues[name]
+
+		templateFileDrift := templateValues != nil &&
+			(inTemplate != inFile || normalizeConfigValue(templateValue) != normalizeConfigValue(fileValue))
+		fileRuntimeDrift := inFile && inRuntime && !driftIgnoredRuntimeVariables[name] &&
+			normalizeConfigValue(fileValue) != normalizeConfigValue(runtimeValue) &&
+			!(inPersisted && normalizeConfigValue(persistedValue) == normalizeConfigValue(runtimeValue))
+		if templateFileDrift || fileRuntimeDrift {
+			drifts = append(drifts, NewConfigDrift(name, templateValue, fileValue, runtimeValue, templateFileDrift, fileRuntimeDrift))
+		}
+	}
+
+	return drifts
+}
+
+// normalizeConfigValue returns the comparable form of the option value,
+// the boolean values are converted to 1 or 0, the size values are converted to bytes and the trailing path separator is removed
+func normalizeConfigValue(value string) string {
+	value = strings.ToLower(strings.Trim(strings.TrimSpace(value), configQuoteChars))
+	boolValue, ok := configBoolValues[value]
+	if ok {
+		return boolValue
+	}
+	matches := parameterSizeValueRegexp.FindStringSubmatch(value)
+	if matches != nil {
+		size, err := strconv.ParseInt(matches[constant.OneInt], 10, 64)
+		if err == nil {
+			return strconv.FormatInt(size*parameterSizeUnits[matches[constant.TwoInt]], 10)
+		}
+	}
+	if len(value) > constant.OneInt {
+		value = strings.TrimSuffix(value, configPathSeparator)
+	}
+
+	return value
+}
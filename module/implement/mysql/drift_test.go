@@ -0,0 +1,72 @@
+package mysql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDrift_All(t *testing.T) {
+	TestGetConfigSectionValues(t)
+	TestNormalizeConfigValue(t)
+	TestCompareConfigValues(t)
+	TestEngine_GetConfigDrift(t)
+}
+
+func TestGetConfigSectionValues(t *testing.T) {
+	asst := assert.New(t)
+
+	content := "[mysqld3306]\n# comment\nserver-id=3306137011\nloose-group_replication_group_name='aaaa'\nskip_name_resolve\n\n[mysqld3307]\nserver-id=3307137011\n"
+	values, found := getConfigSectionValues(content, "[mysqld3306]")
+	asst.True(found, "test getConfigSectionValues() failed")
+	asst.Equal(map[string]string{"server_id": "3306137011", "group_replication_group_name": "aaaa", "skip_name_resolve": ""},
+		values, "test getConfigSectionValues() failed")
+	_, found = getConfigSectionValues(content, "[mysqld3308]")
+	asst.False(found, "test getConfigSectionValues() failed")
+}
+
+func TestNormalizeConfigValue(t *testing.T) {
+	asst := assert.New(t)
+
+	asst.Equal("1", normalizeConfigValue("ON"), "test normalizeConfigValue() failed")
+	asst.Equal("0", normalizeConfigValue("false"), "test normalizeConfigValue() failed")
+	asst.Equal("4294967296", normalizeConfigValue("4G"), "test normalizeConfigValue() failed")
+	asst.Equal("/data/mysql/data/mysql3306/data", normalizeConfigValue("/data/mysql/data/mysql3306/data/"), "test normalizeConfigValue() failed")
+	asst.Equal("eventual", normalizeConfigValue("'EVENTUAL'"), "test normalizeConfigValue() failed")
+}
+
+func TestCompareConfigValues(t *testing.T) {
+	asst := assert.New(t)
+
+	templateValues := map[string]string{"max_connections": "2000", "innodb_buffer_pool_size": "1G", "log_bin": "/data/mysql-bin"}
+	fileValues := map[string]string{"max_connections": "2000", "innodb_buffer_pool_size": "2G", "log_bin": "/data/mysql-bin", "innodb_buffer_pool_instances": "8"}
+	runtimeValues := map[string]string{"max_connections": "3000", "innodb_buffer_pool_size": "2147483648", "log_bin": "ON", "innodb_buffer_pool_instances": "8"}
+	drifts := compareConfigValues(templateValues, fileValues, runtimeValues, map[string]string{})
+	asst.Equal([]*ConfigDrift{
+		NewConfigDrift("innodb_buffer_pool_instances", "", "8", "8", true, false),
+		NewConfigDrift("innodb_buffer_pool_size", "1G", "2G", "2147483648", true, false),
+		NewConfigDrift("max_connections", "2000", "2000", "3000", false, true),
+	}, drifts, "test compareConfigValues() failed")
+	// the runtime value which was changed with set persist is not drift
+	drifts = compareConfigValues(templateValues, fileValues, runtimeValues, map[string]string{"max_connections": "3000"})
+	asst.Equal([]*ConfigDrift{
+		NewConfigDrift("innodb_buffer_pool_instances", "", "8", "8", true, false),
+		NewConfigDrift("innodb_buffer_pool_size", "1G", "2G", "2147483648", true, false),
+	}, drifts, "test compareConfigValues() failed")
+	// the template comparison is skipped without the template
+	drifts = compareConfigValues(nil, fileValues, runtimeValues, map[string]string{})
+	asst.Equal([]*ConfigDrift{
+		NewConfigDrift("max_connections", "", "2000", "3000", false, true),
+	}, drifts, "test compareConfigValues() failed")
+}
+
+func TestEngine_GetConfigDrift(t *testing.T) {
+	asst := assert.New(t)
+
+	instanceDrift, err := testEngine.GetConfigDrift(testAddr1)
+	asst.Nil(err, "test GetConfigDrift() failed")
+	asst.Empty(instanceDrift.RuntimeError, "test GetConfigDrift() failed")
+	for _, drift := range instanceDrift.Drifts {
+		t.Logf("name: %s, template: %s, file: %s, runtime: %s", drift.Name, drift.Template, drift.File, drift.Runtime)
+	}
+}
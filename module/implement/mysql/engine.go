@@ -1,18 +1,22 @@
 package mysql
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/romberli/db-operator/pkg/message"
 
+	"github.com/google/uuid"
 	"github.com/hashicorp/go-version"
 	"github.com/pingcap/errors"
+	"github.com/romberli/go-util/common"
 	"github.com/romberli/go-util/constant"
 	"github.com/romberli/go-util/linux"
 	"github.com/romberli/go-util/middleware/mysql"
@@ -28,9 +32,17 @@ import (
 )
 
 const (
-	installSuccessMessage = "install mysql server completed."
-
-	defaultUseSudo = true
+	installSuccessMessage    = "install mysql server completed."
+	installPanicMessage      = "install mysql server failed because of panic, please check the log for more details."
+	removeSuccessMessage     = "remove mysql server completed."
+	removePanicMessage       = "remove mysql server failed because of panic, please check the log for more details."
+	upgradeSuccessMessage    = "upgrade mysql server completed."
+	upgradePanicMessage      = "upgrade mysql server failed because of panic, please check the log for more details."
+	addReplicaSuccessMessage = "add mysql replica completed."
+	addReplicaPanicMessage   = "add mysql replica failed because of panic, please check the log for more details."
+
+	defaultUseSudo           = true
+	defaultRollbackOnFailure = true
 
 	addrTemplate                         = "%s:%d"
 	defaultConfigFileName                = "/etc/my.cnf"
@@ -40,23 +52,62 @@ const (
 	mysqldSingleInstanceSectionTemplate  = "[mysqld]"
 	mysqldMultiInstanceSectionTemplate   = "[mysqld%d]"
 	mysqldMultiInstanceIsRunningTemplate = "MySQL server from group: mysqld%d is running"
+	configSectionPrefix                  = "["
+	configBaseDirKey                     = "basedir"
+	configBaseDirTemplate                = configBaseDirKey + "=%s"
+	configMySQLDKey                      = "mysqld"
+	configMySQLDValueTemplate            = "%s/bin/mysqld_safe"
+	configMySQLAdminKey                  = "mysqladmin"
+	configMySQLAdminValueTemplate        = "%s/bin/mysqladmin"
+	archiveDirNameTemplate               = "%s.%s"
+	operationStepKeyTemplate             = "%s:%d:%d"
 
 	getMySQLPIDListCommandTemplate     = `/usr/bin/ps -ef | /usr/bin/grep mysqld | /usr/bin/grep %d | /usr/bin/grep %s | /usr/bin/grep -v grep | /usr/bin/awk -F' ' '{print \$2}'`
 	initMySQLInstanceCommandTemplate   = "%s/bin/mysqld --defaults-file=/tmp/my.cnf.%d --initialize --basedir=%s --datadir=%s/data --user=%s"
 	getDefaultRootPassCommandTemplate  = `grep 'A temporary password is generated for root@localhost' %s/%s/mysql.err | awk -F' ' '{print \$NF}'`
 	startSingleInstanceCommandTemplate = "%s/bin/mysqld --defaults-file=/tmp/my.cnf.%d --basedir=%s --datadir=%s/data --user=%s &"
 	startMultiInstanceCommandTemplate  = "export PATH=$PATH:%s/bin && mysqld_multi start %d"
+	stopMultiInstanceCommandTemplate   = "export PATH=$PATH:%s/bin && mysqld_multi stop %d"
 	checkMultiInstanceCommandTemplate  = `export PATH=$PATH:%s/bin && mysqld_multi report %d | /usr/bin/grep \"MySQL server from group\"`
 	initMySQLUserCommandTemplate       = `%s/bin/mysql --connect-expired-password -uroot -p'%s' -S %s/run/mysql.sock -e \"%s\"`
-
-	shutdownSQL     = "shutdown ;"
-	startReplicaSQL = "start replica ;"
-	stopReplicaSQL  = "stop replica ;"
-
-	changeMasterSQLTemplate    = "change master to master_host='%s', master_port=%d, master_user='%s', master_password='%s', master_auto_position=1 ;"
-	SlaveIOThreadRunningField  = "Slave_IO_Running"
-	SlaveSQLThreadRunningField = "Slave_SQL_Running"
-	IsRunningValue             = "Yes"
+	killMySQLDCommandTemplate          = "/usr/bin/kill -9 %d"
+
+	shutdownSQL        = "shutdown ;"
+	setSlowShutdownSQL = "set global innodb_fast_shutdown = 0 ;"
+	getVersionSQL      = "select @@version ;"
+
+	IsRunningValue = "Yes"
+
+	getClonePluginStatusSQL           = "select plugin_status from information_schema.plugins where plugin_name = 'clone' ;"
+	installClonePluginSQL             = "install plugin clone soname 'mysql_clone.so' ;"
+	createCloneUserSQLTemplate        = "create user if not exists '%s'@'%%' identified by '%s' ;"
+	grantBackupAdminSQLTemplate       = "grant backup_admin on *.* to '%s'@'%%' ;"
+	grantCloneAdminSQLTemplate        = "grant clone_admin on *.* to '%s'@'%%' ;"
+	setCloneValidDonorListSQLTemplate = "set global clone_valid_donor_list = '%s:%d' ;"
+	cloneInstanceSQLTemplate          = "clone instance from '%s'@'%s':%d identified by '%s' ;"
+	getCloneStatusSQL                 = "select state, error_message from performance_schema.clone_status ;"
+	ClonePluginActiveValue            = "ACTIVE"
+	CloneStateField                   = "state"
+	CloneErrorMessageField            = "error_message"
+	CloneStateCompletedValue          = "Completed"
+	minCloneMySQLVersion              = "8.0.17"
+
+	getGlobalStatusSQLTemplate = "show global status like '%s' ;"
+	GlobalStatusValueField     = "Value"
+	SemiSyncStatusOnValue      = "ON"
+
+	groupReplicationGroupSeedTemplate       = "%s:%d"
+	setGroupReplicationBootstrapGroupOnSQL  = "set global group_replication_bootstrap_group = on ;"
+	setGroupReplicationBootstrapGroupOffSQL = "set global group_replication_bootstrap_group = off ;"
+	startGroupReplicationSQL                = "start group_replication ;"
+	stopGroupReplicationSQL                 = "stop group_replication ;"
+	getGroupReplicationMembersSQL           = "select member_host, member_port, member_state from performance_schema.replication_group_members ;"
+	GroupReplicationMemberHostField         = "member_host"
+	GroupReplicationMemberPortField         = "member_port"
+	GroupReplicationMemberStateField        = "member_state"
+	GroupReplicationMemberOnlineValue       = "ONLINE"
+	GroupReplicationMemberOfflineValue      = "OFFLINE"
+	GroupReplicationMemberPrimaryValue      = "PRIMARY"
 
 	maxRetryCount        = 5
 	retryInterval        = 2 * time.Second
@@ -64,13 +115,25 @@ const (
 )
 
 type Engine struct {
-	dboRepo      *DBORepo
-	ose          *OSExecutor
-	mysqlVersion *version.Version
-	Mode         mode.Mode              `json:"mode"`
-	Addrs        []string               `json:"addrs"`
-	MySQLServer  *parameter.MySQLServer `json:"mysql_server"`
-	PMMClient    *parameter.PMMClient   `json:"pmm_client"`
+	dboRepo           *DBORepo
+	clusterRepo       *ClusterRepo
+	ose               *OSExecutor
+	rollbackStack     *RollbackStack
+	ctx               context.Context
+	operationID       int
+	operationDetails  map[string]int
+	operationSteps    map[string]int
+	progressMutex     *sync.Mutex
+	mysqlVersion      *version.Version
+	ClusterName       string                 `json:"cluster_name"`
+	Mode              mode.Mode              `json:"mode"`
+	Addrs             []string               `json:"addrs"`
+	MySQLServer       *parameter.MySQLServer `json:"mysql_server"`
+	PMMClient         *parameter.PMMClient   `json:"pmm_client"`
+	RollbackOnFailure bool                   `json:"rollback_on_failure"`
+	ParameterProfile  string                 `json:"parameter_profile"`
+	ExtraParameters   map[string]string      `json:"extra_parameters"`
+	AutoSizing        bool                   `json:"auto_sizing"`
 }
 
 // NewEngine returns a new *Engine
@@ -93,123 +156,341 @@ func NewEngineWithDefault(mysqlVersion *version.Version, m mode.Mode, addrs []st
 // newEngine returns a new *Engine
 func newEngine(dboRepo *DBORepo, mysqlVersion *version.Version, m mode.Mode, addrs []string, mysqlServer *parameter.MySQLServer, pmmClient *parameter.PMMClient) *Engine {
 	return &Engine{
-		dboRepo:      dboRepo,
-		mysqlVersion: mysqlVersion,
-		Mode:         m,
-		Addrs:        addrs,
-		MySQLServer:  mysqlServer,
-		PMMClient:    pmmClient,
+		dboRepo:           dboRepo,
+		clusterRepo:       NewClusterRepo(dboRepo.Database),
+		ctx:               context.Background(),
+		mysqlVersion:      mysqlVersion,
+		Mode:              m,
+		Addrs:             addrs,
+		MySQLServer:       mysqlServer,
+		PMMClient:         pmmClient,
+		RollbackOnFailure: defaultRollbackOnFailure,
+		ParameterProfile:  constant.EmptyString,
+		ExtraParameters:   map[string]string{},
+		AutoSizing:        false,
+	}
+}
+
+// SetContext sets the context of the engine, the running operation will stop at the next step boundary
+// and the running remote commands will be killed when the context is done
+func (e *Engine) SetContext(ctx context.Context) {
+	e.ctx = ctx
+	if e.ose != nil {
+		e.ose.Conn.SetContext(ctx)
 	}
 }
 
-// Install installs mysql to the hosts
+// SetRollbackOnFailure sets whether the partially applied changes on the host should be rolled back when installing the instance failed
+func (e *Engine) SetRollbackOnFailure(rollbackOnFailure bool) {
+	e.RollbackOnFailure = rollbackOnFailure
+}
+
+// SetParameterProfile sets the name of the parameter profile and the extra parameters which override the options of the profile,
+// they will be merged into the mysqld section of the config file when installing the instances
+func (e *Engine) SetParameterProfile(profileName string, extraParameters map[string]string) {
+	e.ParameterProfile = profileName
+	e.ExtraParameters = extraParameters
+}
+
+// SetAutoSizing sets whether the buffer pool, io capacity, io threads and replica parallel workers
+// should be derived from the hardware of the host when installing the instances
+func (e *Engine) SetAutoSizing(autoSizing bool) {
+	e.AutoSizing = autoSizing
+}
+
+// SetClusterName sets the name of the cluster which the instances will be registered to after they are installed
+func (e *Engine) SetClusterName(clusterName string) {
+	e.ClusterName = clusterName
+}
+
+// Install installs mysql to the hosts, if the operation was run before,
+// the steps which were already completed will be skipped, so that a failed installation could be resumed.
+// the hosts are installed concurrently, the instances on the same host are installed one by one,
+// and the replicas start replicating only after the source is installed
 func (e *Engine) Install(operationID int) error {
 	err := linux.SortAddrs(e.Addrs)
 	if err != nil {
 		return err
 	}
 
+	if e.Mode == mode.GroupReplication {
+		// group name and group seeds must be ready before generating the config file of each member
+		err = e.initGroupReplicationParameter()
+		if err != nil {
+			return err
+		}
+	}
+	// the fenced instances must not be reattached to the cluster
+	err = e.checkFencedAddrs(e.Addrs...)
+	if err != nil {
+		return err
+	}
+	// load the progress of the previous run
+	err = e.loadOperationProgress(operationID)
+	if err != nil {
+		return err
+	}
+
+	hostAddrsList, err := groupAddrsByHost(e.Addrs)
+	if err != nil {
+		return err
+	}
+	sourceHostIP, sourcePortNum, err := splitAddr(e.Addrs[constant.ZeroInt])
+	if err != nil {
+		return err
+	}
+	source := newInstallSource(sourceHostIP, sourcePortNum)
+
 	var (
-		sourceHostIP  string
-		sourcePortNum int
+		wg           sync.WaitGroup
+		memberList   = make([][]*OperationDetail, len(hostAddrsList))
+		instanceList = make([][]*InstanceInfo, len(hostAddrsList))
+		errList      = make([]error, len(hostAddrsList))
+		workers      = make(chan struct{}, getInstallConcurrency())
 	)
+	for i, hostAddrs := range hostAddrsList {
+		// the host of the source is the first one to get the worker,
+		// so the replicas waiting for the source will never occupy all the workers
+		workers <- struct{}{}
+		wg.Add(constant.OneInt)
+		go func(i int, hostAddrs []string) {
+			defer func() {
+				<-workers
+				wg.Done()
+			}()
+
+			memberList[i], instanceList[i], errList[i] = e.newWorker().installHost(operationID, hostAddrs, source)
+		}(i, hostAddrs)
+	}
+	wg.Wait()
 
-	for i, addr := range e.Addrs {
-		var (
-			isSource          bool
-			hostIP            string
-			portNumStr        string
-			portNum           int
-			operationDetailID int
-		)
-
-		hostIP, portNumStr, err = net.SplitHostPort(addr)
+	var errMessages []string
+	for i, err := range errList {
 		if err != nil {
-			return errors.Trace(err)
+			errMessages = append(errMessages, fmt.Sprintf("%s: %s", strings.Join(hostAddrsList[i], constant.CommaString), err.Error()))
 		}
-		if hostIP == constant.EmptyString || portNumStr == constant.EmptyString {
-			return errors.Errorf("mysql Engine.Install(): addr must be formatted as host:port, %s is invalid", addr)
+	}
+	if len(errMessages) > constant.ZeroInt {
+		return errors.Errorf("mysql Engine.Install(): install mysql failed on some of the hosts. errors: %s",
+			strings.Join(errMessages, constant.SemicolonString))
+	}
+
+	if e.Mode == mode.GroupReplication {
+		var groupReplicationMemberList []*OperationDetail
+		for _, members := range memberList {
+			groupReplicationMemberList = append(groupReplicationMemberList, members...)
 		}
-		portNum, err = strconv.Atoi(portNumStr)
+		// configure mysql group replication
+		err = e.runGroupReplicationStep(groupReplicationMemberList)
 		if err != nil {
-			return errors.Trace(err)
+			e.updateGroupReplicationOperationDetails(groupReplicationMemberList, defaultFailedStatus, err.Error())
+			return err
+		}
+
+		e.updateGroupReplicationOperationDetails(groupReplicationMemberList, defaultSuccessStatus, installSuccessMessage)
+	}
+
+	var instances []*InstanceInfo
+	for _, hostInstances := range instanceList {
+		instances = append(instances, hostInstances...)
+	}
+
+	return e.registerCluster(NewClusterInfo(e.ClusterName, int(e.Mode), e.MySQLServer.Version), instances)
+}
+
+// getInstallConcurrency returns the maximum number of the hosts which are installed concurrently,
+// it is at least 1 even if the config is not set, otherwise the installation would never start
+func getInstallConcurrency() int {
+	concurrency := viper.GetInt(config.MySQLInstallConcurrencyKey)
+	if concurrency < constant.OneInt {
+		return constant.OneInt
+	}
+
+	return concurrency
+}
+
+// newWorker returns a copy of the engine which has its own mysql server parameter, extra parameters, os executor and rollback stack,
+// so that the instances on different hosts could be installed concurrently,
+// the operation progress is shared with the original engine
+func (e *Engine) newWorker() *Engine {
+	worker := *e
+	worker.MySQLServer = e.MySQLServer.Clone()
+	worker.ExtraParameters = make(map[string]string, len(e.ExtraParameters))
+	for name, value := range e.ExtraParameters {
+		worker.ExtraParameters[name] = value
+	}
+	worker.ose = nil
+	worker.rollbackStack = nil
+
+	return &worker
+}
+
+// installHost installs the instances of the addrs on the same host one by one,
+// it returns the operation details of the group replication members if the mode is group replication
+// and the instance information which will be registered to the cluster,
+// the source will be notified when the installation of the source is completed, even if it fails or panics
+func (e *Engine) installHost(operationID int, addrs []string, source *installSource) (members []*OperationDetail, instances []*InstanceInfo, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.Errorf("mysql Engine.installHost(): panic recovered. addrs: %s, panic: %v", strings.Join(addrs, constant.CommaString), r)
+		}
+		if source.isOnHost(addrs) {
+			// it does nothing if the source was already notified, otherwise the replicas would wait forever
+			source.finish(err)
 		}
+	}()
 
-		if i == constant.ZeroInt {
-			isSource = true
-			sourceHostIP = hostIP
-			sourcePortNum = portNum
+	for _, addr := range addrs {
+		hostIP, portNum, err := splitAddr(addr)
+		if err != nil {
+			return nil, nil, err
 		}
+		isSource := source.is(hostIP, portNum)
 
 		// init operation detail
-		operationDetailID, err = e.dboRepo.InitOperationDetail(operationID, e.MySQLServer.HostIP, e.MySQLServer.PortNum)
+		operationDetailID, err := e.initOperationDetail(operationID, hostIP, portNum)
 		if err != nil {
-			return err
+			if isSource {
+				source.finish(err)
+			}
+			return nil, nil, err
 		}
 		// install single instance
 		err = e.InstallSingleInstance(hostIP, portNum, isSource)
+		if isSource {
+			source.finish(err)
+		}
 		if err != nil {
-			updateErr := e.dboRepo.UpdateOperationDetail(operationDetailID, defaultFailedStatus, err.Error())
-			if updateErr != nil {
-				log.Errorf(constant.LogWithStackString, message.NewMessage(msgMySQL.ErrMySQLEngineUpdateOperationDetail,
-					updateErr, operationID, operationDetailID, hostIP, portNum, defaultFailedStatus))
-			}
-
-			return err
+			e.updateOperationDetail(operationID, operationDetailID, hostIP, portNum, defaultFailedStatus, err.Error())
+			return nil, nil, err
+		}
+		instance, err := e.newInstanceInfo(isSource)
+		if err != nil {
+			e.updateOperationDetail(operationID, operationDetailID, hostIP, portNum, defaultFailedStatus, err.Error())
+			return nil, nil, err
+		}
+		instances = append(instances, instance)
+
+		if e.Mode == mode.GroupReplication {
+			// the operation detail of the member will be updated after the whole group is online
+			member := NewOperationDetailWithDefault()
+			member.ID = operationDetailID
+			member.OperationID = operationID
+			member.HostIP = hostIP
+			member.PortNum = portNum
+			members = append(members, member)
+
+			log.Infof(message.NewMessage(msgMySQL.InfoMySQLEngineInitInstance, operationID, operationDetailID, hostIP, portNum).Error())
+			continue
 		}
 
-		if !isSource && e.Mode == mode.AsyncReplication || e.Mode == mode.SemiSyncReplication {
-			// configure mysql replica
-			err = e.ConfigureReplica(addr, sourceHostIP, sourcePortNum)
+		if !isSource && (e.Mode == mode.AsyncReplication || e.Mode == mode.SemiSyncReplication) {
+			// configure mysql replica after the source is installed
+			err = source.wait()
+			if err == nil {
+				err = e.runStep(hostIP, portNum, installStepReplication, func() error {
+					err := e.ConfigureReplica(addr, source.hostIP, source.portNum)
+					if err != nil || e.Mode != mode.SemiSyncReplication {
+						return err
+					}
+					// check if semi-sync replication is really working
+					return e.CheckSemiSyncReplication(addr, source.hostIP, source.portNum)
+				})
+			}
 			if err != nil {
-				updateErr := e.dboRepo.UpdateOperationDetail(operationDetailID, defaultFailedStatus, err.Error())
-				if updateErr != nil {
-					log.Errorf(constant.LogWithStackString, message.NewMessage(msgMySQL.ErrMySQLEngineUpdateOperationDetail,
-						updateErr, operationID, operationDetailID, hostIP, portNum, defaultFailedStatus))
-				}
-
-				return err
+				e.updateOperationDetail(operationID, operationDetailID, hostIP, portNum, defaultFailedStatus, err.Error())
+				return nil, nil, err
 			}
 		}
 
-		updateErr := e.dboRepo.UpdateOperationDetail(operationDetailID, defaultSuccessStatus, installSuccessMessage)
-		if updateErr != nil {
-			log.Errorf(constant.LogWithStackString, message.NewMessage(msgMySQL.ErrMySQLEngineUpdateOperationDetail,
-				updateErr, operationID, operationDetailID, hostIP, portNum, defaultSuccessStatus))
-		}
-
+		e.updateOperationDetail(operationID, operationDetailID, hostIP, portNum, defaultSuccessStatus, installSuccessMessage)
 		log.Infof(message.NewMessage(msgMySQL.InfoMySQLEngineInitInstance, operationID, operationDetailID, hostIP, portNum).Error())
 	}
 
-	if e.Mode == mode.GroupReplication {
-		// configure mysql group replication
-		return e.ConfigureGroupReplication()
+	return members, instances, nil
+}
+
+// InstallSingleInstance installs the single instance,
+// if it fails and RollbackOnFailure is true, the completed steps will be undone in reverse order
+func (e *Engine) InstallSingleInstance(hostIP string, portNum int, isSource bool) error {
+	return e.runWithRollback(hostIP, portNum, func() error {
+		return e.installSingleInstance(hostIP, portNum, isSource)
+	})
+}
+
+// runWithRollback runs the install function of the single instance with a new rollback stack,
+// if it fails and RollbackOnFailure is true, the recorded compensating actions will be run in reverse order
+func (e *Engine) runWithRollback(hostIP string, portNum int, install func() error) error {
+	e.rollbackStack = NewRollbackStack()
+
+	err := install()
+	if err != nil && e.RollbackOnFailure {
+		if e.ctx.Err() != nil {
+			// the compensating actions must not be interrupted by the cancelled context
+			e.SetContext(context.Background())
+		}
+		undoneSteps := e.rollbackStack.UndoneSteps()
+		rollbackErr := e.rollbackStack.Rollback()
+		if rollbackErr != nil {
+			log.Errorf(constant.LogWithStackString, message.NewMessage(msgMySQL.ErrMySQLEngineRollbackInstance, rollbackErr, hostIP, portNum))
+			return err
+		}
+
+		log.Infof(message.NewMessage(msgMySQL.InfoMySQLEngineRollbackInstance, hostIP, portNum).Error())
+		// only the undone steps must be run again when resuming the operation,
+		// the completed steps before them are kept, so that they will be skipped
+		e.resetOperationSteps(hostIP, portNum, undoneSteps...)
 	}
 
-	return nil
+	return err
 }
 
-// InstallSingleInstance installs the single instance
-func (e *Engine) InstallSingleInstance(hostIP string, portNum int, isSource bool) error {
+// installSingleInstance installs the single instance step by step, the steps which were already completed will be skipped,
+// and the compensating actions of the steps which are run will be recorded
+func (e *Engine) installSingleInstance(hostIP string, portNum int, isSource bool) error {
 	// reset MySQL Sever Parameter
 	err := e.MySQLServer.InitWithHostInfo(hostIP, portNum, isSource)
 	if err != nil {
 		return err
 	}
+	// init os executor
+	err = e.InitOSExecutor()
+	if err != nil {
+		return err
+	}
+	err = e.ose.InitExecutor()
+	if err != nil {
+		return err
+	}
 	// init os
-	err = e.InitOS()
+	err = e.runStep(hostIP, portNum, installStepInitOS, e.ose.PrepareOS)
 	if err != nil {
 		return err
 	}
-	// init mysql instance
-	err = e.InitMySQLInstance()
+	// install mysql binary
+	err = e.runStep(hostIP, portNum, installStepInstallBinary, e.ose.InstallBinary)
+	if err != nil {
+		return err
+	}
+	// initialize mysql instance
+	err = e.runStep(hostIP, portNum, installStepInitialize, e.initializeInstance)
+	if err != nil {
+		return err
+	}
+	// init mysql user
+	err = e.runStep(hostIP, portNum, installStepInitUser, e.initInstanceUser)
+	if err != nil {
+		return err
+	}
+	// start mysql multi instance
+	err = e.runStep(hostIP, portNum, installStepStartMySQLDMulti, e.startMultiInstance)
 	if err != nil {
 		return err
 	}
 	// init pmm client
 	// TODO: for now, we only support installing pmm client on x64 platform
 	if e.ose.arch == constant.X64Arch {
-		err = e.InitPMMClient()
+		err = e.runStep(hostIP, portNum, installStepPMM, e.InitPMMClient)
 		if err != nil {
 			return err
 		}
@@ -218,315 +499,1597 @@ func (e *Engine) InstallSingleInstance(hostIP string, portNum int, isSource bool
 	return nil
 }
 
-// InitOS initializes the os
-func (e *Engine) InitOS() error {
-	err := e.InitOSExecutor()
+// loadOperationProgress loads the operation details and steps of the previous run of the operation
+func (e *Engine) loadOperationProgress(operationID int) error {
+	e.operationID = operationID
+	e.operationDetails = make(map[string]int)
+	e.operationSteps = make(map[string]int)
+	e.progressMutex = &sync.Mutex{}
+
+	operationDetails, err := e.dboRepo.getOperationDetails(operationID)
 	if err != nil {
 		return err
 	}
+	for _, operationDetail := range operationDetails {
+		e.operationDetails[fmt.Sprintf(addrTemplate, operationDetail.HostIP, operationDetail.PortNum)] = operationDetail.ID
+	}
 
-	return e.ose.Init()
-}
-
-// InitOSExecutor initializes the ssh connection
-func (e *Engine) InitOSExecutor() error {
-	sshConn, err := linux.NewSSHConn(
-		e.MySQLServer.HostIP,
-		constant.DefaultSSHPort,
-		viper.GetString(config.MySQLUserOSUserKey),
-		viper.GetString(config.MySQLUserOSPassKey),
-		defaultUseSudo,
-	)
+	operationSteps, err := e.dboRepo.GetOperationSteps(operationID)
 	if err != nil {
 		return err
 	}
-
-	e.ose = NewOSExecutor(ssh.NewConn(sshConn), e.mysqlVersion, e.MySQLServer)
+	for _, operationStep := range operationSteps {
+		e.operationSteps[getOperationStepKey(operationStep.HostIP, operationStep.PortNum, operationStep.Step)] = operationStep.Status
+	}
 
 	return nil
 }
 
-// InitMySQLInstance initializes the mysql instance
-func (e *Engine) InitMySQLInstance() error {
-	// prepare mysql multi instance config file
-	err := e.prepareMultiInstanceConfigFile()
-	if err != nil {
-		return err
-	}
-	// init single instance
-	rootPass, err := e.initMySQLInstance()
-	if err != nil {
-		return err
+// initOperationDetail initializes the operation detail of the host, the detail of the previous run will be reused if exists
+func (e *Engine) initOperationDetail(operationID int, hostIP string, portNum int) (int, error) {
+	operationDetailID, exists := e.operationDetails[fmt.Sprintf(addrTemplate, hostIP, portNum)]
+	if !exists {
+		return e.dboRepo.InitOperationDetail(operationID, hostIP, portNum)
 	}
-	// start mysql single instance asynchronously
-	go func() {
-		err = e.startInstanceWithMySQLD()
-		if err != nil {
-			log.Errorf("mysql Engine.InitMySQLInstance(): start mysql instance failed. hostIP: %s, portNum: %d, error:\n%+v", e.MySQLServer.HostIP, e.MySQLServer.PortNum, err)
-		}
-	}()
-	// check instance status
-	err = e.checkInstanceWithPID()
+
+	err := e.dboRepo.UpdateOperationDetail(operationDetailID, defaultRunningStatus, constant.EmptyString)
 	if err != nil {
-		return err
+		return constant.ZeroInt, err
 	}
-	time.Sleep(retryInterval)
-	// init mysql user
-	err = e.initMySQLUser(rootPass)
+
+	return operationDetailID, nil
+}
+
+// runStep runs the step of the host and saves its status, the step will be skipped if it was completed in the previous run,
+// if the engine is not running an operation, the step will be run directly without saving the status
+func (e *Engine) runStep(hostIP string, portNum, step int, stepFunc func() error) error {
+	err := e.checkCancelled(hostIP, portNum)
 	if err != nil {
 		return err
 	}
-	// stop mysql single instance
-	err = e.stopInstance()
-	if err != nil {
-		return err
+	if e.operationID == constant.ZeroInt {
+		return stepFunc()
 	}
-	err = e.waitForShuttingDown()
-	// start mysql multi instance
-	err = e.startInstanceWithMySQLDMulti()
-	if err != nil {
-		return err
+
+	if e.getOperationStepStatus(hostIP, portNum, step) == defaultSuccessStatus {
+		log.Infof(message.NewMessage(msgMySQL.InfoMySQLEngineSkipStep, e.operationID, hostIP, portNum, step).Error())
+		return nil
 	}
-	// check mysql multi instance
-	isRunning, err := e.checkInstanceWithMySQLDMulti()
+
+	e.rollbackStack.BeginStep(step)
+	e.saveOperationStep(hostIP, portNum, step, defaultRunningStatus, constant.EmptyString)
+	err = stepFunc()
 	if err != nil {
+		e.saveOperationStep(hostIP, portNum, step, defaultFailedStatus, err.Error())
 		return err
 	}
-	if !isRunning {
-		return errors.Errorf("mysql Engine.InitMySQLInstance(): mysql multi instance is not running. hostIP: %s, portNum: %d", e.MySQLServer.HostIP, e.MySQLServer.PortNum)
-	}
+	e.saveOperationStep(hostIP, portNum, step, defaultSuccessStatus, constant.EmptyString)
 
 	return nil
 }
 
-// ConfigureReplica configures the replication
-func (e *Engine) ConfigureReplica(addr, sourceHostIP string, sourcePortNum int) error {
-	if addr == fmt.Sprintf(addrTemplate, sourceHostIP, sourcePortNum) {
-		// this is the source node, do nothing
+// runGroupReplicationStep configures the group replication and saves the replication step of all the members,
+// it will be skipped if the replication step of all the members were completed in the previous run
+func (e *Engine) runGroupReplicationStep(members []*OperationDetail) error {
+	completed := true
+	for _, member := range members {
+		if e.getOperationStepStatus(member.HostIP, member.PortNum, installStepReplication) != defaultSuccessStatus {
+			completed = false
+			break
+		}
+	}
+	if completed {
+		for _, member := range members {
+			log.Infof(message.NewMessage(msgMySQL.InfoMySQLEngineSkipStep, e.operationID, member.HostIP, member.PortNum, installStepReplication).Error())
+		}
 		return nil
 	}
 
-	conn, err := mysql.NewConn(addr, constant.EmptyString, constant.DefaultRootUserName, e.MySQLServer.RootPass)
+	status := defaultSuccessStatus
+	msg := constant.EmptyString
+	err := e.ConfigureGroupReplication()
 	if err != nil {
-		return err
+		status = defaultFailedStatus
+		msg = err.Error()
 	}
-	defer func() {
-		err = conn.Close()
-		if err != nil {
-			log.Errorf("mysql Engine.ConfigureReplica(): close mysql connection failed. error:\n%+v", err)
-		}
-	}()
+	for _, member := range members {
+		e.saveOperationStep(member.HostIP, member.PortNum, installStepReplication, status, msg)
+	}
+
+	return err
+}
+
+// getOperationStepStatus returns the status of the step of the host
+func (e *Engine) getOperationStepStatus(hostIP string, portNum, step int) int {
+	e.progressMutex.Lock()
+	defer e.progressMutex.Unlock()
+
+	return e.operationSteps[getOperationStepKey(hostIP, portNum, step)]
+}
 
-	sql := fmt.Sprintf(changeMasterSQLTemplate, sourceHostIP, sourcePortNum, e.MySQLServer.ReplicationUser, e.MySQLServer.ReplicationPass)
-	_, err = conn.Execute(sql)
+// saveOperationStep saves the status of the step of the host, it only logs the error if failed
+func (e *Engine) saveOperationStep(hostIP string, portNum, step, status int, msg string) {
+	e.progressMutex.Lock()
+	e.operationSteps[getOperationStepKey(hostIP, portNum, step)] = status
+	e.progressMutex.Unlock()
+
+	err := e.dboRepo.SaveOperationStep(e.operationID, hostIP, portNum, step, status, msg)
 	if err != nil {
-		return err
+		log.Errorf(constant.LogWithStackString, message.NewMessage(msgMySQL.ErrMySQLEngineSaveOperationStep,
+			err, e.operationID, hostIP, portNum, step, status))
 	}
+}
 
-	_, err = conn.Execute(startReplicaSQL)
-	if err != nil {
-		return err
+// resetOperationSteps resets the given steps of the host, so that they will be run again when resuming the operation
+func (e *Engine) resetOperationSteps(hostIP string, portNum int, steps ...int) {
+	if e.operationID == constant.ZeroInt {
+		return
 	}
 
-	var status string
-	// check io thread
-	for i := constant.ZeroInt; i < maxRetryCount; i++ {
-		result, err := conn.GetReplicationSlavesStatus()
+	for _, step := range steps {
+		e.progressMutex.Lock()
+		delete(e.operationSteps, getOperationStepKey(hostIP, portNum, step))
+		e.progressMutex.Unlock()
+
+		err := e.dboRepo.DeleteOperationStep(e.operationID, hostIP, portNum, step)
 		if err != nil {
-			return err
+			log.Errorf(constant.LogWithStackString, message.NewMessage(msgMySQL.ErrMySQLEngineResetOperationSteps, err, e.operationID, hostIP, portNum, step))
 		}
+	}
+}
 
-		status, err = result.GetStringByName(constant.ZeroInt, SlaveIOThreadRunningField)
+// Remove removes the mysql instances of the addrs, the data and log directories will be archived instead of being deleted if archive is true,
+// and the mysql binary will also be removed if removeBinary is true and no other instance on the host is still using it
+func (e *Engine) Remove(operationID int, archive, removeBinary bool) error {
+	for _, addr := range e.Addrs {
+		hostIP, portNum, err := splitAddr(addr)
 		if err != nil {
 			return err
 		}
-		if status != IsRunningValue {
-			log.Warnf("mysql Engine.ConfigureReplica(): slave io thread is not running, will be retry soon. hostIP: %s, portNum: %d, status: %s, retryCount: %d", e.MySQLServer.HostIP, e.MySQLServer.PortNum, status, i)
-			time.Sleep(time.Duration(i+1) * checkReplicaInterval)
-			continue
+		// init operation detail
+		operationDetailID, err := e.dboRepo.InitOperationDetail(operationID, hostIP, portNum)
+		if err != nil {
+			return err
+		}
+		// remove single instance
+		err = e.RemoveInstance(hostIP, portNum, archive)
+		if err == nil && removeBinary {
+			err = e.RemoveBinary()
 		}
-		status, err = result.GetStringByName(constant.ZeroInt, SlaveSQLThreadRunningField)
 		if err != nil {
+			e.updateOperationDetail(operationID, operationDetailID, hostIP, portNum, defaultFailedStatus, err.Error())
 			return err
 		}
-		if status == IsRunningValue {
-			return nil
+
+		// the instance does not exist anymore, so it is safe to install a new one with the same addr
+		err = e.dboRepo.UnfenceInstance(hostIP, portNum)
+		if err != nil {
+			log.Errorf(constant.LogWithStackString, message.NewMessage(msgMySQL.ErrMySQLEngineUnfenceInstance, err, operationID, hostIP, portNum))
 		}
+		e.unregisterInstance(addr)
 
-		log.Warnf("mysql Engine.ConfigureReplica(): slave sql thread is not running, will be retry soon. hostIP: %s, portNum: %d, status: %s, retryCount: %d", e.MySQLServer.HostIP, e.MySQLServer.PortNum, status, i)
-		time.Sleep(time.Duration(i+1) * checkReplicaInterval)
+		e.updateOperationDetail(operationID, operationDetailID, hostIP, portNum, defaultSuccessStatus, removeSuccessMessage)
+		log.Infof(message.NewMessage(msgMySQL.InfoMySQLEngineRemoveInstance, operationID, operationDetailID, hostIP, portNum).Error())
 	}
 
-	return errors.Errorf("mysql Engine.ConfigureReplica(): slave io/sql thread is not running. hostIP: %s, portNum: %d, status: %s", e.MySQLServer.HostIP, e.MySQLServer.PortNum, status)
-}
-
-// InitPMMClient initializes the pmm client
-func (e *Engine) InitPMMClient() error {
-	pmmExecutor := NewPMMExecutor(e.ose.Conn, e.MySQLServer.HostIP, e.MySQLServer.PortNum, e.PMMClient)
-
-	return pmmExecutor.Init()
+	return nil
 }
 
-// ConfigureGroupReplication configures the group replication
-func (e *Engine) ConfigureGroupReplication() error {
-	// TODO: implement this
-	return errors.Errorf("mysql Engine.ConfigureGroupReplication(): group replication has not been implemented")
+// RemoveInstance removes the single instance, it stops the instance, removes the pmm service,
+// removes the instance section from the config file and deletes or archives the data and log directories
+func (e *Engine) RemoveInstance(hostIP string, portNum int, archive bool) error {
+	// reset MySQL Sever Parameter
+	err := e.MySQLServer.InitWithHostInfo(hostIP, portNum, false)
+	if err != nil {
+		return err
+	}
+	// init os executor
+	err = e.InitOSExecutor()
+	if err != nil {
+		return err
+	}
+	// stop mysql multi instance
+	err = e.stopInstanceWithMySQLDMulti()
+	if err != nil {
+		return err
+	}
+	// remove pmm service
+	err = e.RemovePMMService()
+	if err != nil {
+		return err
+	}
+	// remove the instance section from the config file
+	err = e.removeMultiInstanceConfigSection()
+	if err != nil {
+		return err
+	}
+	// remove the data and log directories
+	return e.removeInstanceDirs(archive)
 }
 
-// initMySQLInstance initializes the mysql instance
-func (e *Engine) initMySQLInstance() (string, error) {
-	// prepare init config file
-	err := e.prepareInitConfigFile()
+// RemoveBinary removes the mysql binary directory, it does nothing if any other instance in the config file is still using it,
+// so it should be called after the instance section was removed from the config file
+func (e *Engine) RemoveBinary() error {
+	inUse, err := e.isBinaryInUse()
 	if err != nil {
-		return constant.EmptyString, err
+		return err
 	}
-	// init mysql instance
-	cmd := fmt.Sprintf(initMySQLInstanceCommandTemplate, e.MySQLServer.BinaryDirBase,
-		e.MySQLServer.PortNum, e.MySQLServer.BinaryDirBase, e.MySQLServer.DataDirBase, defaultMySQLUser)
-	err = e.ose.Conn.ExecuteCommandWithoutOutput(cmd)
+	if inUse {
+		log.Warnf("mysql Engine.RemoveBinary(): mysql binary directory is still used by other instance, will not remove it. hostIP: %s, binaryDirBase: %s",
+			e.MySQLServer.HostIP, e.MySQLServer.BinaryDirBase)
+		return nil
+	}
+
+	exists, err := e.ose.Conn.PathExists(e.MySQLServer.BinaryDirBase)
 	if err != nil {
-		return constant.EmptyString, err
+		return err
+	}
+	if !exists {
+		return nil
 	}
 
-	// return the default root password
-	return e.getDefaultMySQLRootPass()
+	return e.ose.Conn.RemoveAll(e.MySQLServer.BinaryDirBase)
 }
 
-// startInstanceWithMySQLD starts the instance with mysqld
+// RemovePMMService removes the service of the instance from pmm server
+func (e *Engine) RemovePMMService() error {
+	pmmExecutor := NewPMMExecutor(e.ose.Conn, e.MySQLServer.HostIP, e.MySQLServer.PortNum, e.PMMClient)
+
+	return pmmExecutor.RemoveService()
+}
+
+// AddReplica installs new instances of the addrs and provisions them from the existing source with the clone plugin,
+// after cloning, the new instances start replicating from the source
+func (e *Engine) AddReplica(operationID int, sourceAddr string) error {
+	if e.Mode != mode.AsyncReplication && e.Mode != mode.SemiSyncReplication {
+		return errors.Errorf("mysql Engine.AddReplica(): only async replication and semi-sync replication modes are supported. mode: %d", e.Mode)
+	}
+	sourceHostIP, sourcePortNum, err := splitAddr(sourceAddr)
+	if err != nil {
+		return err
+	}
+	err = linux.SortAddrs(e.Addrs)
+	if err != nil {
+		return err
+	}
+	// the fenced instances must not be reattached to the cluster
+	err = e.checkFencedAddrs(append([]string{sourceAddr}, e.Addrs...)...)
+	if err != nil {
+		return err
+	}
+	// check if the source could be the donor
+	err = e.checkCloneSourceVersion(sourceAddr)
+	if err != nil {
+		return err
+	}
+	// load the progress of the previous run
+	err = e.loadOperationProgress(operationID)
+	if err != nil {
+		return err
+	}
+
+	var instances []*InstanceInfo
+	for _, addr := range e.Addrs {
+		hostIP, portNum, err := splitAddr(addr)
+		if err != nil {
+			return err
+		}
+		// init operation detail
+		operationDetailID, err := e.initOperationDetail(operationID, hostIP, portNum)
+		if err != nil {
+			return err
+		}
+		// add single replica
+		err = e.AddReplicaInstance(hostIP, portNum, sourceHostIP, sourcePortNum)
+		if err != nil {
+			e.updateOperationDetail(operationID, operationDetailID, hostIP, portNum, defaultFailedStatus, err.Error())
+			return err
+		}
+		instance, err := e.newInstanceInfo(false)
+		if err != nil {
+			e.updateOperationDetail(operationID, operationDetailID, hostIP, portNum, defaultFailedStatus, err.Error())
+			return err
+		}
+		instances = append(instances, instance)
+
+		e.updateOperationDetail(operationID, operationDetailID, hostIP, portNum, defaultSuccessStatus, addReplicaSuccessMessage)
+		log.Infof(message.NewMessage(msgMySQL.InfoMySQLEngineAddReplica, operationID, operationDetailID, hostIP, portNum, sourceAddr).Error())
+	}
+
+	return e.registerReplicas(sourceHostIP, sourcePortNum, instances)
+}
+
+// AddReplicaInstance installs a fresh instance, clones the data from the source and configures the replication
+func (e *Engine) AddReplicaInstance(hostIP string, portNum int, sourceHostIP string, sourcePortNum int) error {
+	// install a fresh instance
+	err := e.InstallSingleInstance(hostIP, portNum, false)
+	if err != nil {
+		return err
+	}
+	// clone the data from the source
+	err = e.runStep(hostIP, portNum, installStepClone, func() error {
+		return e.cloneFromSource(sourceHostIP, sourcePortNum)
+	})
+	if err != nil {
+		return err
+	}
+	// configure mysql replica
+	addr := fmt.Sprintf(addrTemplate, hostIP, portNum)
+	return e.runStep(hostIP, portNum, installStepReplication, func() error {
+		err := e.ConfigureReplica(addr, sourceHostIP, sourcePortNum)
+		if err != nil || e.Mode != mode.SemiSyncReplication {
+			return err
+		}
+		// check if semi-sync replication is really working
+		return e.CheckSemiSyncReplication(addr, sourceHostIP, sourcePortNum)
+	})
+}
+
+// Upgrade upgrades the mysql instances of the addrs to the version of the engine in place,
+// the replicas will be upgraded before the source
+func (e *Engine) Upgrade(operationID int) error {
+	addrs, err := e.getUpgradeOrder()
+	if err != nil {
+		return err
+	}
+
+	for _, addr := range addrs {
+		hostIP, portNum, err := splitAddr(addr)
+		if err != nil {
+			return err
+		}
+		// init operation detail
+		operationDetailID, err := e.dboRepo.InitOperationDetail(operationID, hostIP, portNum)
+		if err != nil {
+			return err
+		}
+		// upgrade single instance
+		err = e.UpgradeInstance(hostIP, portNum)
+		if err != nil {
+			e.updateOperationDetail(operationID, operationDetailID, hostIP, portNum, defaultFailedStatus, err.Error())
+			return err
+		}
+
+		e.updateOperationDetail(operationID, operationDetailID, hostIP, portNum, defaultSuccessStatus, upgradeSuccessMessage)
+		log.Infof(message.NewMessage(msgMySQL.InfoMySQLEngineUpgradeInstance, operationID, operationDetailID, hostIP, portNum, e.mysqlVersion.String()).Error())
+	}
+
+	return nil
+}
+
+// UpgradeInstance upgrades the single instance in place, it installs the new mysql binary next to the old one,
+// shuts down the instance slowly, points the basedir of the instance to the new binary and restarts the instance
+func (e *Engine) UpgradeInstance(hostIP string, portNum int) error {
+	// reset MySQL Sever Parameter
+	err := e.MySQLServer.InitWithHostInfo(hostIP, portNum, false)
+	if err != nil {
+		return err
+	}
+	// check the running version
+	err = e.checkUpgradeVersion()
+	if err != nil {
+		return err
+	}
+	// init os executor
+	err = e.InitOSExecutor()
+	if err != nil {
+		return err
+	}
+	err = e.ose.InitExecutor()
+	if err != nil {
+		return err
+	}
+	err = e.ose.PrecheckUpgrade()
+	if err != nil {
+		return err
+	}
+	// install the new mysql binary
+	err = e.ose.InstallMySQLBinary()
+	if err != nil {
+		return err
+	}
+	// shut down the instance slowly
+	err = e.setSlowShutdown()
+	if err != nil {
+		return err
+	}
+	err = e.stopInstanceWithMySQLDMulti()
+	if err != nil {
+		return err
+	}
+	// point the basedir to the new mysql binary
+	err = e.updateMultiInstanceConfigBaseDir()
+	if err != nil {
+		return err
+	}
+	// start mysql multi instance
+	err = e.startInstanceWithMySQLDMulti()
+	if err != nil {
+		return err
+	}
+	isRunning, err := e.checkInstanceWithMySQLDMulti()
+	if err != nil {
+		return err
+	}
+	if !isRunning {
+		return errors.Errorf("mysql Engine.UpgradeInstance(): mysql multi instance is not running. hostIP: %s, portNum: %d", e.MySQLServer.HostIP, e.MySQLServer.PortNum)
+	}
+	// check the new version
+	return e.waitForVersion()
+}
+
+// InitOS initializes the os
+func (e *Engine) InitOS() error {
+	err := e.InitOSExecutor()
+	if err != nil {
+		return err
+	}
+
+	return e.ose.Init()
+}
+
+// InitOSExecutor initializes the ssh connection
+func (e *Engine) InitOSExecutor() error {
+	err := e.checkCancelled(e.MySQLServer.HostIP, e.MySQLServer.PortNum)
+	if err != nil {
+		return err
+	}
+	sshConn, err := linux.NewSSHConn(
+		e.MySQLServer.HostIP,
+		constant.DefaultSSHPort,
+		viper.GetString(config.MySQLUserOSUserKey),
+		viper.GetString(config.MySQLUserOSPassKey),
+		defaultUseSudo,
+	)
+	if err != nil {
+		return err
+	}
+
+	e.ose = NewOSExecutor(ssh.NewConnWithContext(e.ctx, sshConn), e.mysqlVersion, e.MySQLServer)
+	e.ose.rollbackStack = e.rollbackStack
+
+	return nil
+}
+
+// InitMySQLInstance initializes the mysql instance
+func (e *Engine) InitMySQLInstance() error {
+	// initialize mysql instance
+	err := e.initializeInstance()
+	if err != nil {
+		return err
+	}
+	// init mysql user
+	err = e.initInstanceUser()
+	if err != nil {
+		return err
+	}
+	// start mysql multi instance
+	return e.startMultiInstance()
+}
+
+// initializeInstance prepares the config file and initializes the data directory of the mysql instance
+func (e *Engine) initializeInstance() error {
+	err := e.autoSizeIfEnabled()
+	if err != nil {
+		return err
+	}
+	// prepare mysql multi instance config file
+	err = e.prepareMultiInstanceConfigFile()
+	if err != nil {
+		return err
+	}
+	// init single instance
+	return e.initMySQLInstance()
+}
+
+// initInstanceUser starts the mysql instance with mysqld, initializes the users with the temporary root password,
+// and then shuts down the instance
+func (e *Engine) initInstanceUser() error {
+	// start mysql single instance asynchronously
+	go func() {
+		err := e.startInstanceWithMySQLD()
+		if err != nil {
+			log.Errorf("mysql Engine.initInstanceUser(): start mysql instance failed. hostIP: %s, portNum: %d, error:\n%+v", e.MySQLServer.HostIP, e.MySQLServer.PortNum, err)
+		}
+	}()
+	// check instance status
+	err := e.checkInstanceWithPID()
+	if err != nil {
+		return err
+	}
+	err = e.sleep(retryInterval)
+	if err != nil {
+		return err
+	}
+	// get the temporary root password
+	rootPass, err := e.getDefaultMySQLRootPass()
+	if err != nil {
+		return err
+	}
+	// init mysql user
+	err = e.initMySQLUser(rootPass)
+	if err != nil {
+		return err
+	}
+	// stop mysql single instance
+	err = e.stopInstance()
+	if err != nil {
+		return err
+	}
+
+	return e.waitForShuttingDown()
+}
+
+// startMultiInstance starts the mysql instance with mysqld_multi and checks if it is running
+func (e *Engine) startMultiInstance() error {
+	// start mysql multi instance
+	err := e.startInstanceWithMySQLDMulti()
+	if err != nil {
+		return err
+	}
+	// check mysql multi instance
+	isRunning, err := e.checkInstanceWithMySQLDMulti()
+	if err != nil {
+		return err
+	}
+	if !isRunning {
+		return errors.Errorf("mysql Engine.startMultiInstance(): mysql multi instance is not running. hostIP: %s, portNum: %d", e.MySQLServer.HostIP, e.MySQLServer.PortNum)
+	}
+
+	return nil
+}
+
+// ConfigureReplica configures the replication
+func (e *Engine) ConfigureReplica(addr, sourceHostIP string, sourcePortNum int) error {
+	if addr == fmt.Sprintf(addrTemplate, sourceHostIP, sourcePortNum) {
+		// this is the source node, do nothing
+		return nil
+	}
+
+	conn, err := mysql.NewConn(addr, constant.EmptyString, constant.DefaultRootUserName, e.MySQLServer.RootPass)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = conn.Close()
+		if err != nil {
+			log.Errorf("mysql Engine.ConfigureReplica(): close mysql connection failed. error:\n%+v", err)
+		}
+	}()
+
+	rs, err := getConnReplicationSyntax(conn)
+	if err != nil {
+		return err
+	}
+	_, err = conn.Execute(rs.getChangeSourceSQL(sourceHostIP, sourcePortNum, e.MySQLServer.ReplicationUser, e.MySQLServer.ReplicationPass))
+	if err != nil {
+		return err
+	}
+
+	_, err = conn.Execute(rs.startReplicaSQL)
+	if err != nil {
+		return err
+	}
+
+	return e.waitForReplicaRunning(conn, addr)
+}
+
+// CheckSemiSyncReplication checks if the semi-sync replication is active on both the replica and the source
+func (e *Engine) CheckSemiSyncReplication(addr, sourceHostIP string, sourcePortNum int) error {
+	err := e.waitForSemiSyncStatusOn(addr, false)
+	if err != nil {
+		return err
+	}
+
+	return e.waitForSemiSyncStatusOn(fmt.Sprintf(addrTemplate, sourceHostIP, sourcePortNum), true)
+}
+
+// waitForSemiSyncStatusOn waits for the semi-sync source or replica status variable of the given mysql server to be ON,
+// the name of the variable depends on the version of the running mysql server
+func (e *Engine) waitForSemiSyncStatusOn(addr string, isSource bool) error {
+	conn, err := mysql.NewConn(addr, constant.EmptyString, constant.DefaultRootUserName, e.MySQLServer.RootPass)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = conn.Close()
+		if err != nil {
+			log.Errorf("mysql Engine.waitForSemiSyncStatusOn(): close mysql connection failed. error:\n%+v", err)
+		}
+	}()
+
+	rs, err := getConnReplicationSyntax(conn)
+	if err != nil {
+		return err
+	}
+	variable := rs.semiSyncReplicaStatusVariable
+	if isSource {
+		variable = rs.semiSyncSourceStatusVariable
+	}
+
+	sql := fmt.Sprintf(getGlobalStatusSQLTemplate, variable)
+	var status string
+	for i := constant.ZeroInt; i < maxRetryCount; i++ {
+		result, err := conn.Execute(sql)
+		if err != nil {
+			return err
+		}
+		if result.RowNumber() == constant.ZeroInt {
+			return errors.Errorf("mysql Engine.waitForSemiSyncStatusOn(): status variable not found, the semi-sync plugin may not be loaded. addr: %s, variable: %s", addr, variable)
+		}
+
+		status, err = result.GetStringByName(constant.ZeroInt, GlobalStatusValueField)
+		if err != nil {
+			return err
+		}
+		if status == SemiSyncStatusOnValue {
+			return nil
+		}
+
+		log.Warnf("mysql Engine.waitForSemiSyncStatusOn(): semi-sync status is not on, will be retry soon. addr: %s, variable: %s, status: %s, retryCount: %d", addr, variable, status, i)
+		err = e.sleep(time.Duration(i+1) * checkReplicaInterval)
+		if err != nil {
+			return err
+		}
+	}
+
+	return errors.Errorf("mysql Engine.waitForSemiSyncStatusOn(): maximum retry count of waiting for semi-sync status exceeded, but the status is still not on. addr: %s, variable: %s, status: %s, maxRetryCount: %d", addr, variable, status, maxRetryCount)
+}
+
+// InitPMMClient initializes the pmm client
+func (e *Engine) InitPMMClient() error {
+	pmmExecutor := NewPMMExecutor(e.ose.Conn, e.MySQLServer.HostIP, e.MySQLServer.PortNum, e.PMMClient)
+
+	// only the service added by this installation should be removed when rolling back
+	installed, err := pmmExecutor.CheckPMMClient()
+	if err != nil {
+		return err
+	}
+	exists := false
+	if installed {
+		exists, err = pmmExecutor.CheckServiceExists()
+		if err != nil {
+			return err
+		}
+	}
+	if !exists {
+		e.rollbackStack.Push("remove pmm service", pmmExecutor.RemoveService)
+	}
+
+	return pmmExecutor.Init()
+}
+
+// ConfigureGroupReplication configures the single-primary group replication,
+// the members which are already online will be skipped, the first member bootstraps the group only if none of the members is online,
+// and the other members join the group one by one
+func (e *Engine) ConfigureGroupReplication() error {
+	err := linux.SortAddrs(e.Addrs)
+	if err != nil {
+		return err
+	}
+
+	states, err := e.getGroupReplicationMemberStates()
+	if err != nil {
+		return err
+	}
+
+	bootstrapped := groupReplicationHasOnlineMember(states)
+	for _, addr := range e.Addrs {
+		if states[addr] == GroupReplicationMemberOnlineValue {
+			log.Infof(message.NewMessage(msgMySQL.InfoMySQLEngineSkipGroupReplicationMember, e.operationID, addr).Error())
+			continue
+		}
+
+		err = e.startGroupReplication(addr, states[addr], !bootstrapped)
+		if err != nil {
+			return err
+		}
+		bootstrapped = true
+	}
+
+	// check if all the members are online
+	return e.checkGroupReplicationMembers()
+}
+
+// initGroupReplicationParameter initializes the group name and the group seeds of the group replication
+func (e *Engine) initGroupReplicationParameter() error {
+	if e.MySQLServer.GroupReplicationGroupName == constant.EmptyString {
+		e.MySQLServer.SetGroupReplicationGroupName(uuid.New().String())
+	} else {
+		_, err := uuid.Parse(e.MySQLServer.GroupReplicationGroupName)
+		if err != nil {
+			return errors.Errorf("mysql Engine.initGroupReplicationParameter(): group replication group name must be a valid uuid, %s is not valid", e.MySQLServer.GroupReplicationGroupName)
+		}
+	}
+
+	groupSeeds := make([]string, len(e.Addrs))
+	for i, addr := range e.Addrs {
+		hostIP, portNum, err := splitAddr(addr)
+		if err != nil {
+			return err
+		}
+		groupReplicationPort, err := parameter.GetGroupReplicationPort(portNum)
+		if err != nil {
+			return err
+		}
+		groupSeeds[i] = fmt.Sprintf(groupReplicationGroupSeedTemplate, hostIP, groupReplicationPort)
+	}
+	e.MySQLServer.SetGroupReplicationGroupSeeds(strings.Join(groupSeeds, constant.CommaString))
+
+	return nil
+}
+
+// startGroupReplication starts the group replication on the given instance and waits until it is online,
+// the group replication will be stopped first if the member was left in any state other than offline by the previous run
+func (e *Engine) startGroupReplication(addr, state string, isBootstrap bool) error {
+	conn, err := mysql.NewConn(addr, constant.EmptyString, constant.DefaultRootUserName, e.MySQLServer.RootPass)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = conn.Close()
+		if err != nil {
+			log.Errorf("mysql Engine.startGroupReplication(): close mysql connection failed. error:\n%+v", err)
+		}
+	}()
+
+	if state != constant.EmptyString && state != GroupReplicationMemberOfflineValue {
+		_, err = conn.Execute(stopGroupReplicationSQL)
+		if err != nil {
+			return err
+		}
+	}
+
+	rs, err := getConnReplicationSyntax(conn)
+	if err != nil {
+		return err
+	}
+	_, err = conn.Execute(rs.getGroupReplicationRecoverySQL(e.MySQLServer.ReplicationUser, e.MySQLServer.ReplicationPass))
+	if err != nil {
+		return err
+	}
+
+	if isBootstrap {
+		_, err = conn.Execute(setGroupReplicationBootstrapGroupOnSQL)
+		if err != nil {
+			return err
+		}
+		_, startErr := conn.Execute(startGroupReplicationSQL)
+		// bootstrap group must be turned off whether the group replication started or not
+		_, err = conn.Execute(setGroupReplicationBootstrapGroupOffSQL)
+		if startErr != nil {
+			return startErr
+		}
+		if err != nil {
+			return err
+		}
+	} else {
+		_, err = conn.Execute(startGroupReplicationSQL)
+		if err != nil {
+			return err
+		}
+	}
+
+	return e.waitForGroupReplicationMemberOnline(conn, addr)
+}
+
+// waitForGroupReplicationMemberOnline waits for the given member to be online
+func (e *Engine) waitForGroupReplicationMemberOnline(conn *mysql.Conn, addr string) error {
+	var state string
+	for i := constant.ZeroInt; i < maxRetryCount; i++ {
+		members, err := e.getGroupReplicationMembers(conn)
+		if err != nil {
+			return err
+		}
+
+		state = members[addr]
+		if state == GroupReplicationMemberOnlineValue {
+			return nil
+		}
+
+		log.Warnf("mysql Engine.waitForGroupReplicationMemberOnline(): group replication member is not online, will be retry soon. addr: %s, state: %s, retryCount: %d", addr, state, i)
+		err = e.sleep(time.Duration(i+1) * checkReplicaInterval)
+		if err != nil {
+			return err
+		}
+	}
+
+	return errors.Errorf("mysql Engine.waitForGroupReplicationMemberOnline(): maximum retry count of waiting for group replication member exceeded, but the member is still not online. addr: %s, state: %s, maxRetryCount: %d", addr, state, maxRetryCount)
+}
+
+// checkGroupReplicationMembers checks if all the addrs are online members of the group
+func (e *Engine) checkGroupReplicationMembers() error {
+	conn, err := mysql.NewConn(e.Addrs[constant.ZeroInt], constant.EmptyString, constant.DefaultRootUserName, e.MySQLServer.RootPass)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = conn.Close()
+		if err != nil {
+			log.Errorf("mysql Engine.checkGroupReplicationMembers(): close mysql connection failed. error:\n%+v", err)
+		}
+	}()
+
+	members, err := e.getGroupReplicationMembers(conn)
+	if err != nil {
+		return err
+	}
+
+	var notOnlineAddrs []string
+	for _, addr := range e.Addrs {
+		if members[addr] != GroupReplicationMemberOnlineValue {
+			notOnlineAddrs = append(notOnlineAddrs, addr)
+		}
+	}
+	if len(notOnlineAddrs) > constant.ZeroInt {
+		return errors.Errorf("mysql Engine.checkGroupReplicationMembers(): some of the group replication members are not online. addrs: %s",
+			strings.Join(notOnlineAddrs, constant.CommaString))
+	}
+
+	return nil
+}
+
+// getGroupReplicationMemberStates gets the state of each addr which is reported by the member itself,
+// the state will be empty if the member has never started the group replication
+func (e *Engine) getGroupReplicationMemberStates() (map[string]string, error) {
+	states := make(map[string]string, len(e.Addrs))
+	for _, addr := range e.Addrs {
+		state, err := e.getGroupReplicationMemberState(addr)
+		if err != nil {
+			return nil, err
+		}
+		states[addr] = state
+	}
+
+	return states, nil
+}
+
+// getGroupReplicationMemberState gets the state of the given member which is reported by the member itself
+func (e *Engine) getGroupReplicationMemberState(addr string) (string, error) {
+	conn, err := mysql.NewConn(addr, constant.EmptyString, constant.DefaultRootUserName, e.MySQLServer.RootPass)
+	if err != nil {
+		return constant.EmptyString, err
+	}
+	defer func() {
+		err = conn.Close()
+		if err != nil {
+			log.Errorf("mysql Engine.getGroupReplicationMemberState(): close mysql connection failed. error:\n%+v", err)
+		}
+	}()
+
+	members, err := e.getGroupReplicationMembers(conn)
+	if err != nil {
+		return constant.EmptyString, err
+	}
+
+	return members[addr], nil
+}
+
+// groupReplicationHasOnlineMember returns if any of the members is online
+func groupReplicationHasOnlineMember(states map[string]string) bool {
+	for _, state := range states {
+		if state == GroupReplicationMemberOnlineValue {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getGroupReplicationMembers gets the group replication members, the key is the member addr, the value is the member state
+func (e *Engine) getGroupReplicationMembers(conn *mysql.Conn) (map[string]string, error) {
+	result, err := conn.Execute(getGroupReplicationMembersSQL)
+	if err != nil {
+		return nil, err
+	}
+
+	members := make(map[string]string, result.RowNumber())
+	for i := constant.ZeroInt; i < result.RowNumber(); i++ {
+		hostIP, err := result.GetStringByName(i, GroupReplicationMemberHostField)
+		if err != nil {
+			return nil, err
+		}
+		portNum, err := result.GetStringByName(i, GroupReplicationMemberPortField)
+		if err != nil {
+			return nil, err
+		}
+		state, err := result.GetStringByName(i, GroupReplicationMemberStateField)
+		if err != nil {
+			return nil, err
+		}
+
+		members[net.JoinHostPort(hostIP, portNum)] = state
+	}
+
+	return members, nil
+}
+
+// updateOperationDetail updates the operation detail, it only logs the error if failed
+func (e *Engine) updateOperationDetail(operationID, operationDetailID int, hostIP string, portNum, status int, msg string) {
+	err := e.dboRepo.UpdateOperationDetail(operationDetailID, status, msg)
+	if err != nil {
+		log.Errorf(constant.LogWithStackString, message.NewMessage(msgMySQL.ErrMySQLEngineUpdateOperationDetail,
+			err, operationID, operationDetailID, hostIP, portNum, status))
+	}
+}
+
+// updateGroupReplicationOperationDetails updates the operation details of all the group replication members
+func (e *Engine) updateGroupReplicationOperationDetails(members []*OperationDetail, status int, msg string) {
+	for _, member := range members {
+		updateErr := e.dboRepo.UpdateOperationDetail(member.ID, status, msg)
+		if updateErr != nil {
+			log.Errorf(constant.LogWithStackString, message.NewMessage(msgMySQL.ErrMySQLEngineUpdateOperationDetail,
+				updateErr, member.OperationID, member.ID, member.HostIP, member.PortNum, status))
+		}
+	}
+}
+
+// initMySQLInstance initializes the mysql instance
+func (e *Engine) initMySQLInstance() error {
+	e.rollbackStack.Push("clean up mysql instance", e.cleanUpInstance)
+	// prepare init config file
+	err := e.prepareInitConfigFile()
+	if err != nil {
+		return err
+	}
+	// init mysql instance
+	cmd := fmt.Sprintf(initMySQLInstanceCommandTemplate, e.MySQLServer.BinaryDirBase,
+		e.MySQLServer.PortNum, e.MySQLServer.BinaryDirBase, e.MySQLServer.DataDirBase, defaultMySQLUser)
+
+	return e.ose.Conn.ExecuteCommandWithoutOutput(cmd)
+}
+
+// cleanUpInstance kills the mysqld processes of the instance and removes the files generated by initializing,
+// it is the compensating action of initializing the instance
+func (e *Engine) cleanUpInstance() error {
+	pidList, err := e.ose.GetMySQLPIDList()
+	if err != nil {
+		return err
+	}
+	for _, pid := range pidList {
+		err = e.ose.Conn.ExecuteCommandWithoutOutput(fmt.Sprintf(killMySQLDCommandTemplate, pid))
+		if err != nil {
+			return err
+		}
+	}
+	err = e.waitForShuttingDown()
+	if err != nil {
+		return err
+	}
+
+	dirs := []string{
+		filepath.Join(e.MySQLServer.DataDirBase, dataDirName),
+		filepath.Join(e.MySQLServer.LogDirBase, binlogDirName),
+		filepath.Join(e.MySQLServer.LogDirBase, relaylogDirName),
+		filepath.Join(constant.DefaultTmpDir, fmt.Sprintf(configFileNameTemplate, e.MySQLServer.PortNum)),
+	}
+	for _, dir := range dirs {
+		err = e.ose.Conn.RemoveAll(dir)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// startInstanceWithMySQLD starts the instance with mysqld
 func (e *Engine) startInstanceWithMySQLD() error {
 	cmd := fmt.Sprintf(startSingleInstanceCommandTemplate, e.MySQLServer.BinaryDirBase, e.MySQLServer.PortNum,
 		e.MySQLServer.BinaryDirBase, e.MySQLServer.DataDirBase, defaultMySQLUser)
 
-	return e.ose.Conn.ExecuteCommandWithoutOutput(cmd)
+	return e.ose.Conn.ExecuteCommandWithoutOutput(cmd)
+}
+
+// checkInstanceWithPID checks the instance with pid
+func (e *Engine) checkInstanceWithPID() error {
+	for i := constant.ZeroInt; i < maxRetryCount; i++ {
+		pidList, err := e.ose.GetMySQLPIDList()
+		if err != nil {
+			return err
+		}
+
+		if len(pidList) > constant.ZeroInt {
+			return nil
+		}
+
+		log.Warnf("mysql Engine.checkInstanceWithPID(): no mysqld pid found, will be retry soon. hostIP: %s, portNum: %d, retryCount: %d", e.MySQLServer.HostIP, e.MySQLServer.PortNum, i)
+		err = e.sleep(time.Duration(i+1) * retryInterval)
+		if err != nil {
+			return err
+		}
+	}
+
+	return errors.Errorf("mysql Engine.checkInstanceWithPID(): maximum retry count of checking mysql pid exceeded, but still no mysqld pid found. hostIP: %s, portNum: %d, maxRetryCount: %d", e.MySQLServer.HostIP, e.MySQLServer.PortNum, maxRetryCount)
+}
+
+// initMySQLUser initializes the user
+func (e *Engine) initMySQLUser(rootPass string) error {
+	sql, err := e.MySQLServer.GetInitUserSQL()
+	if err != nil {
+		return err
+	}
+
+	command := fmt.Sprintf(initMySQLUserCommandTemplate, e.MySQLServer.BinaryDirBase, rootPass, e.MySQLServer.DataDirBase, sql)
+
+	return e.ose.Conn.ExecuteCommandWithoutOutput(command)
+}
+
+// waitForShuttingDown waits for the instance to shut down
+func (e *Engine) waitForShuttingDown() error {
+	for i := constant.ZeroInt; i < maxRetryCount; i++ {
+		pidList, err := e.ose.GetMySQLPIDList()
+		if err != nil {
+			return err
+		}
+
+		if len(pidList) == constant.ZeroInt {
+			return nil
+		}
+
+		log.Warnf("mysql Engine.waitForShuttingDown(): mysqld pid found, will be retry soon. hostIP: %s, portNum: %d, maxRetryCount: %d", e.MySQLServer.HostIP, e.MySQLServer.PortNum, i)
+		err = e.sleep(time.Duration(i+1) * retryInterval)
+		if err != nil {
+			return err
+		}
+	}
+
+	return errors.Errorf("mysql Engine.waitForShuttingDown(): maximum retry count of waiting for shutting down exceeded, but still found mysqld pid. hostIP: %s, portNum: %d, maxRetryCount: %d", e.MySQLServer.HostIP, e.MySQLServer.PortNum, maxRetryCount)
+}
+
+// prepareMultiInstanceConfigFile prepares mysql config file
+func (e *Engine) prepareMultiInstanceConfigFile() error {
+	// check if the config file exists
+	exists, err := e.ose.Conn.PathExists(defaultConfigFileName)
+	if err != nil {
+		return err
+	}
+
+	title := e.getMultiInstanceTitle()
+
+	if !exists {
+		// the config file does not exist, generate a new one
+		configBytes, err := e.MySQLServer.GetConfigWithTitle(title, e.mysqlVersion, e.Mode)
+		if err != nil {
+			return err
+		}
+		e.rollbackStack.Push("remove config file", func() error {
+			return e.ose.Conn.RemoveAll(defaultConfigFileName)
+		})
+
+		return e.transferConfigContent(configBytes, fmt.Sprintf(configFileNameTemplate, e.MySQLServer.PortNum), defaultConfigFileName)
+	}
+
+	// the config file exists
+	// backup the config file
+	backupFileName := fmt.Sprintf(defaultConfigFileBackupNameTemplate, time.Now().Format(constant.TimeLayoutSecondDash))
+	err = e.ose.Conn.Copy(defaultConfigFileName, backupFileName)
+	if err != nil {
+		return err
+	}
+	e.rollbackStack.Push("restore config file", func() error {
+		return e.ose.Conn.Copy(backupFileName, defaultConfigFileName)
+	})
+	// get the config file content
+	existingContent, err := e.ose.Conn.Cat(defaultConfigFileName)
+	if err != nil {
+		return err
+	}
+
+	if strings.Contains(existingContent, mysqldSingleInstanceSectionTemplate) {
+		// mysqld section exists
+		return errors.New("mysql Engine.prepareMultiInstanceConfigFile(): mysqld section exists, db operator does not support converting the single instance to multi instance")
+	}
+
+	if !strings.Contains(existingContent, fmt.Sprintf(mysqldMultiInstanceSectionTemplate, e.MySQLServer.PortNum)) {
+		// the instance section does not exist
+		newContent, err := e.MySQLServer.GetMySQLDConfigWithTitle(title, e.mysqlVersion, e.Mode)
+		if err != nil {
+			return err
+		}
+		// append the instance section to the config file
+		content := existingContent + string(newContent)
+		fileName := fmt.Sprintf(configFileNameTemplate, e.MySQLServer.PortNum)
+
+		return e.transferConfigContent([]byte(content), fileName, defaultConfigFileName)
+	}
+
+	// the instance section exists, do nothing
+	return nil
+}
+
+// stopInstance stops the instance
+func (e *Engine) stopInstance() error {
+	// connect to the mysql instance
+	conn, err := mysql.NewConn(
+		fmt.Sprintf("%s:%d", e.MySQLServer.HostIP, e.MySQLServer.PortNum),
+		constant.EmptyString,
+		constant.DefaultRootUserName,
+		e.MySQLServer.RootPass,
+	)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = conn.Close()
+		if err != nil {
+			log.Errorf("Engine.stopInstance(): close mysql connection failed. error:\n%+v", err)
+		}
+	}()
+
+	// stop the mysql instance
+	_, err = conn.Execute(shutdownSQL)
+
+	return err
+}
+
+// stopInstanceWithMySQLDMulti stops the instance with mysqld_multi and waits for it to shut down
+func (e *Engine) stopInstanceWithMySQLDMulti() error {
+	pidList, err := e.ose.GetMySQLPIDList()
+	if err != nil {
+		return err
+	}
+	if len(pidList) == constant.ZeroInt {
+		// the instance is not running
+		return nil
+	}
+
+	cmd := fmt.Sprintf(stopMultiInstanceCommandTemplate, e.MySQLServer.BinaryDirBase, e.MySQLServer.PortNum)
+	err = e.ose.Conn.ExecuteCommandWithoutOutput(cmd)
+	if err != nil {
+		return err
+	}
+
+	return e.waitForShuttingDown()
+}
+
+// removeMultiInstanceConfigSection removes the instance section from the config file, the config file will be backed up first
+func (e *Engine) removeMultiInstanceConfigSection() error {
+	// check if the config file exists
+	exists, err := e.ose.Conn.PathExists(defaultConfigFileName)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return nil
+	}
+	// get the config file content
+	existingContent, err := e.ose.Conn.Cat(defaultConfigFileName)
+	if err != nil {
+		return err
+	}
+	content, found := removeConfigSection(existingContent, fmt.Sprintf(mysqldMultiInstanceSectionTemplate, e.MySQLServer.PortNum))
+	if !found {
+		// the instance section does not exist, do nothing
+		return nil
+	}
+	// backup the config file
+	err = e.ose.Conn.Copy(defaultConfigFileName, fmt.Sprintf(defaultConfigFileBackupNameTemplate, time.Now().Format(constant.TimeLayoutSecondDash)))
+	if err != nil {
+		return err
+	}
+
+	return e.transferConfigContent([]byte(content), fmt.Sprintf(configFileNameTemplate, e.MySQLServer.PortNum), defaultConfigFileName)
+}
+
+// removeInstanceDirs deletes the data and log directories of the instance, or archives them if archive is true
+func (e *Engine) removeInstanceDirs(archive bool) error {
+	dirs := []string{e.MySQLServer.DataDirBase}
+	if e.MySQLServer.LogDirBase != e.MySQLServer.DataDirBase {
+		dirs = append(dirs, e.MySQLServer.LogDirBase)
+	}
+
+	for _, dir := range dirs {
+		exists, err := e.ose.Conn.PathExists(dir)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			continue
+		}
+
+		if archive {
+			err = e.ose.Conn.Move(dir, fmt.Sprintf(archiveDirNameTemplate, dir, time.Now().Format(constant.TimeLayoutSecondDash)))
+		} else {
+			err = e.ose.Conn.RemoveAll(dir)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// isBinaryInUse checks if any instance in the config file is still using the mysql binary directory
+func (e *Engine) isBinaryInUse() (bool, error) {
+	exists, err := e.ose.Conn.PathExists(defaultConfigFileName)
+	if err != nil {
+		return false, err
+	}
+	if !exists {
+		return false, nil
+	}
+
+	content, err := e.ose.Conn.Cat(defaultConfigFileName)
+	if err != nil {
+		return false, err
+	}
+
+	return isBinaryDirInConfig(content, e.MySQLServer.BinaryDirBase), nil
+}
+
+// getUpgradeOrder returns the addrs in upgrade order, the replicas come first and the others follow,
+// in group replication mode, the secondaries come first and the primary follows
+func (e *Engine) getUpgradeOrder() ([]string, error) {
+	var replicas, others []string
+	for _, addr := range e.Addrs {
+		isReplica, err := e.isReplica(addr)
+		if err != nil {
+			return nil, err
+		}
+		if isReplica {
+			replicas = append(replicas, addr)
+			continue
+		}
+
+		others = append(others, addr)
+	}
+
+	return append(replicas, others...), nil
+}
+
+// isReplica checks if the mysql server of the given addr is a replica,
+// in group replication mode, the member which is not the primary is treated as a replica
+func (e *Engine) isReplica(addr string) (bool, error) {
+	conn, err := mysql.NewConn(addr, constant.EmptyString, constant.DefaultRootUserName, e.MySQLServer.RootPass)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		err = conn.Close()
+		if err != nil {
+			log.Errorf("mysql Engine.isReplica(): close mysql connection failed. error:\n%+v", err)
+		}
+	}()
+
+	rs, err := getConnReplicationSyntax(conn)
+	if err != nil {
+		return false, err
+	}
+	if e.Mode == mode.GroupReplication {
+		// the group replication channels are listed in the replica status, so the role of the member must be checked instead
+		result, err := conn.Execute(rs.groupReplicationMemberRoleSQL)
+		if err != nil {
+			return false, err
+		}
+		if result.RowNumber() == constant.ZeroInt {
+			return false, errors.Errorf("mysql Engine.isReplica(): the instance is not a member of the group replication. addr: %s", addr)
+		}
+		role, err := result.GetString(constant.ZeroInt, constant.ZeroInt)
+		if err != nil {
+			return false, err
+		}
+
+		return role != GroupReplicationMemberPrimaryValue, nil
+	}
+
+	result, err := conn.Execute(rs.showReplicaStatusSQL)
+	if err != nil {
+		return false, err
+	}
+
+	return result.RowNumber() > constant.ZeroInt, nil
+}
+
+// getRunningVersion gets the version of the running instance
+func (e *Engine) getRunningVersion() (*version.Version, error) {
+	return e.getVersion(fmt.Sprintf(addrTemplate, e.MySQLServer.HostIP, e.MySQLServer.PortNum))
+}
+
+// getVersion gets the version of the mysql server of the addr
+func (e *Engine) getVersion(addr string) (*version.Version, error) {
+	conn, err := mysql.NewConn(addr, constant.EmptyString, constant.DefaultRootUserName, e.MySQLServer.RootPass)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = conn.Close()
+		if err != nil {
+			log.Errorf("mysql Engine.getVersion(): close mysql connection failed. error:\n%+v", err)
+		}
+	}()
+
+	result, err := conn.Execute(getVersionSQL)
+	if err != nil {
+		return nil, err
+	}
+	versionStr, err := result.GetString(constant.ZeroInt, constant.ZeroInt)
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := version.NewVersion(versionStr)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return v, nil
+}
+
+// checkUpgradeVersion checks if the running instance could be upgraded to the version of the engine in place,
+// only the upgrade between minor versions of the same release series is supported
+func (e *Engine) checkUpgradeVersion() error {
+	runningVersion, err := e.getRunningVersion()
+	if err != nil {
+		return err
+	}
+
+	runningSegments := runningVersion.Segments()
+	targetSegments := e.mysqlVersion.Segments()
+	if runningSegments[constant.ZeroInt] != targetSegments[constant.ZeroInt] || runningSegments[constant.OneInt] != targetSegments[constant.OneInt] {
+		return errors.Errorf("mysql Engine.checkUpgradeVersion(): only minor version upgrade is supported. hostIP: %s, portNum: %d, runningVersion: %s, targetVersion: %s",
+			e.MySQLServer.HostIP, e.MySQLServer.PortNum, runningVersion.String(), e.mysqlVersion.String())
+	}
+	if !runningVersion.Core().LessThan(e.mysqlVersion.Core()) {
+		return errors.Errorf("mysql Engine.checkUpgradeVersion(): the target version must be newer than the running version. hostIP: %s, portNum: %d, runningVersion: %s, targetVersion: %s",
+			e.MySQLServer.HostIP, e.MySQLServer.PortNum, runningVersion.String(), e.mysqlVersion.String())
+	}
+
+	return nil
 }
 
-// checkInstanceWithPID checks the instance with pid
-func (e *Engine) checkInstanceWithPID() error {
-	for i := constant.ZeroInt; i < maxRetryCount; i++ {
-		pidList, err := e.ose.GetMySQLPIDList()
+// setSlowShutdown sets innodb_fast_shutdown to 0, so that innodb does a full purge and change buffer merge before shutting down
+func (e *Engine) setSlowShutdown() error {
+	conn, err := mysql.NewConn(fmt.Sprintf(addrTemplate, e.MySQLServer.HostIP, e.MySQLServer.PortNum),
+		constant.EmptyString, constant.DefaultRootUserName, e.MySQLServer.RootPass)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = conn.Close()
 		if err != nil {
-			return err
-		}
-
-		if len(pidList) > constant.ZeroInt {
-			return nil
+			log.Errorf("mysql Engine.setSlowShutdown(): close mysql connection failed. error:\n%+v", err)
 		}
+	}()
 
-		log.Warnf("mysql Engine.checkInstanceWithPID(): no mysqld pid found, will be retry soon. hostIP: %s, portNum: %d, retryCount: %d", e.MySQLServer.HostIP, e.MySQLServer.PortNum, i)
-		time.Sleep(time.Duration(i+1) * retryInterval)
-	}
+	_, err = conn.Execute(setSlowShutdownSQL)
 
-	return errors.Errorf("mysql Engine.checkInstanceWithPID(): maximum retry count of checking mysql pid exceeded, but still no mysqld pid found. hostIP: %s, portNum: %d, maxRetryCount: %d", e.MySQLServer.HostIP, e.MySQLServer.PortNum, maxRetryCount)
+	return err
 }
 
-// initMySQLUser initializes the user
-func (e *Engine) initMySQLUser(rootPass string) error {
-	sql, err := e.MySQLServer.GetInitUserSQL()
+// updateMultiInstanceConfigBaseDir points the basedir, mysqld and mysqladmin in the instance section of the config file to the mysql binary directory,
+// the config file will be backed up first
+func (e *Engine) updateMultiInstanceConfigBaseDir() error {
+	existingContent, err := e.ose.Conn.Cat(defaultConfigFileName)
+	if err != nil {
+		return err
+	}
+	section := fmt.Sprintf(mysqldMultiInstanceSectionTemplate, e.MySQLServer.PortNum)
+	content, found := replaceConfigSectionBinaryDir(existingContent, section, e.MySQLServer.BinaryDirBase)
+	if !found {
+		return errors.Errorf("mysql Engine.updateMultiInstanceConfigBaseDir(): basedir not found in the instance section of the config file. hostIP: %s, section: %s",
+			e.MySQLServer.HostIP, section)
+	}
+	// backup the config file
+	err = e.ose.Conn.Copy(defaultConfigFileName, fmt.Sprintf(defaultConfigFileBackupNameTemplate, time.Now().Format(constant.TimeLayoutSecondDash)))
 	if err != nil {
 		return err
 	}
 
-	command := fmt.Sprintf(initMySQLUserCommandTemplate, e.MySQLServer.BinaryDirBase, rootPass, e.MySQLServer.DataDirBase, sql)
-
-	return e.ose.Conn.ExecuteCommandWithoutOutput(command)
+	return e.transferConfigContent([]byte(content), fmt.Sprintf(configFileNameTemplate, e.MySQLServer.PortNum), defaultConfigFileName)
 }
 
-// waitForShuttingDown waits for the instance to shut down
-func (e *Engine) waitForShuttingDown() error {
+// waitForVersion waits for the restarted instance to be available and checks if it is running the version of the engine
+func (e *Engine) waitForVersion() error {
+	var (
+		runningVersion *version.Version
+		err            error
+	)
 	for i := constant.ZeroInt; i < maxRetryCount; i++ {
-		pidList, err := e.ose.GetMySQLPIDList()
-		if err != nil {
-			return err
+		runningVersion, err = e.getRunningVersion()
+		if err == nil {
+			break
 		}
 
-		if len(pidList) == constant.ZeroInt {
-			return nil
+		log.Warnf("mysql Engine.waitForVersion(): get mysql version failed, will be retry soon. hostIP: %s, portNum: %d, retryCount: %d, error:\n%+v",
+			e.MySQLServer.HostIP, e.MySQLServer.PortNum, i, err)
+		sleepErr := e.sleep(time.Duration(i+1) * retryInterval)
+		if sleepErr != nil {
+			return sleepErr
 		}
+	}
+	if err != nil {
+		return err
+	}
 
-		log.Warnf("mysql Engine.waitForShuttingDown(): mysqld pid found, will be retry soon. hostIP: %s, portNum: %d, maxRetryCount: %d", e.MySQLServer.HostIP, e.MySQLServer.PortNum, i)
-		time.Sleep(time.Duration(i+1) * retryInterval)
+	if !runningVersion.Core().Equal(e.mysqlVersion.Core()) {
+		return errors.Errorf("mysql Engine.waitForVersion(): the running version is not the target version. hostIP: %s, portNum: %d, runningVersion: %s, targetVersion: %s",
+			e.MySQLServer.HostIP, e.MySQLServer.PortNum, runningVersion.String(), e.mysqlVersion.String())
 	}
 
-	return errors.Errorf("mysql Engine.waitForShuttingDown(): maximum retry count of waiting for shutting down exceeded, but still found mysqld pid. hostIP: %s, portNum: %d, maxRetryCount: %d", e.MySQLServer.HostIP, e.MySQLServer.PortNum, maxRetryCount)
+	return nil
 }
 
-// prepareMultiInstanceConfigFile prepares mysql config file
-func (e *Engine) prepareMultiInstanceConfigFile() error {
-	// check if the config file exists
-	exists, err := e.ose.Conn.PathExists(defaultConfigFileName)
+// checkCloneSourceVersion checks if the source could be the donor of the clone plugin,
+// the donor and the recipient must run the same version, which must be 8.0.17 or later
+func (e *Engine) checkCloneSourceVersion(sourceAddr string) error {
+	sourceVersion, err := e.getVersion(sourceAddr)
 	if err != nil {
 		return err
 	}
 
-	title := e.getMultiInstanceTitle()
+	minVersion, err := version.NewVersion(minCloneMySQLVersion)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if sourceVersion.Core().LessThan(minVersion) {
+		return errors.Errorf("mysql Engine.checkCloneSourceVersion(): clone plugin requires mysql %s or later. source: %s, sourceVersion: %s",
+			minCloneMySQLVersion, sourceAddr, sourceVersion.String())
+	}
+	if !sourceVersion.Core().Equal(e.mysqlVersion.Core()) {
+		return errors.Errorf("mysql Engine.checkCloneSourceVersion(): the version of the replica must be the same as the source. source: %s, sourceVersion: %s, replicaVersion: %s",
+			sourceAddr, sourceVersion.String(), e.mysqlVersion.String())
+	}
 
-	if !exists {
-		// the config file does not exist, generate a new one
-		configBytes, err := e.MySQLServer.GetConfigWithTitle(title, e.mysqlVersion, e.Mode)
-		if err != nil {
-			return err
-		}
+	return nil
+}
 
-		return e.transferConfigContent(configBytes, fmt.Sprintf(configFileNameTemplate, e.MySQLServer.PortNum), defaultConfigFileName)
+// cloneFromSource clones the data of the source to the instance with the clone plugin,
+// the instance will be restarted after the data is cloned
+func (e *Engine) cloneFromSource(sourceHostIP string, sourcePortNum int) error {
+	// prepare the donor
+	err := e.prepareCloneUser(fmt.Sprintf(addrTemplate, sourceHostIP, sourcePortNum), grantBackupAdminSQLTemplate)
+	if err != nil {
+		return err
 	}
-
-	// the config file exists
-	// backup the config file
-	err = e.ose.Conn.Copy(defaultConfigFileName, fmt.Sprintf(defaultConfigFileBackupNameTemplate, time.Now().Format(constant.TimeLayoutSecondDash)))
+	// prepare the recipient
+	addr := fmt.Sprintf(addrTemplate, e.MySQLServer.HostIP, e.MySQLServer.PortNum)
+	err = e.prepareCloneUser(addr, grantCloneAdminSQLTemplate, fmt.Sprintf(setCloneValidDonorListSQLTemplate, sourceHostIP, sourcePortNum))
 	if err != nil {
 		return err
 	}
-	// get the config file content
-	existingContent, err := e.ose.Conn.Cat(defaultConfigFileName)
+	// clone the data, the connection will be lost when the instance restarts,
+	// so the result will be checked with the clone status after the instance is available again
+	conn, err := mysql.NewConn(addr, constant.EmptyString, e.MySQLServer.CloneUser, e.MySQLServer.ClonePass)
 	if err != nil {
 		return err
 	}
+	_, err = conn.Execute(fmt.Sprintf(cloneInstanceSQLTemplate, e.MySQLServer.CloneUser, sourceHostIP, sourcePortNum, e.MySQLServer.ClonePass))
+	if err != nil {
+		log.Warnf("mysql Engine.cloneFromSource(): clone instance returned an error, will check the clone status after the instance restarts. hostIP: %s, portNum: %d, error:\n%+v",
+			e.MySQLServer.HostIP, e.MySQLServer.PortNum, err)
+	}
+	closeErr := conn.Close()
+	if closeErr != nil {
+		log.Warnf("mysql Engine.cloneFromSource(): close mysql connection failed. error:\n%+v", closeErr)
+	}
 
-	if strings.Contains(existingContent, mysqldSingleInstanceSectionTemplate) {
-		// mysqld section exists
-		return errors.New("mysql Engine.prepareMultiInstanceConfigFile(): mysqld section exists, db operator does not support converting the single instance to multi instance")
+	return e.waitForCloneCompleted()
+}
+
+// prepareCloneUser loads the clone plugin, creates the clone user with the given privilege and runs the extra sqls on the mysql server of the addr
+func (e *Engine) prepareCloneUser(addr, grantSQLTemplate string, extraSQLs ...string) error {
+	conn, err := mysql.NewConn(addr, constant.EmptyString, constant.DefaultRootUserName, e.MySQLServer.RootPass)
+	if err != nil {
+		return err
 	}
+	defer func() {
+		err = conn.Close()
+		if err != nil {
+			log.Errorf("mysql Engine.prepareCloneUser(): close mysql connection failed. error:\n%+v", err)
+		}
+	}()
 
-	if !strings.Contains(existingContent, fmt.Sprintf(mysqldMultiInstanceSectionTemplate, e.MySQLServer.PortNum)) {
-		// the instance section does not exist
-		newContent, err := e.MySQLServer.GetMySQLDConfigWithTitle(title, e.mysqlVersion, e.Mode)
+	// load the clone plugin if it is not active
+	result, err := conn.Execute(getClonePluginStatusSQL)
+	if err != nil {
+		return err
+	}
+	if result.RowNumber() == constant.ZeroInt {
+		_, err = conn.Execute(installClonePluginSQL)
 		if err != nil {
 			return err
 		}
-		// append the instance section to the config file
-		content := existingContent + string(newContent)
-		fileName := fmt.Sprintf(configFileNameTemplate, e.MySQLServer.PortNum)
+	} else {
+		status, err := result.GetString(constant.ZeroInt, constant.ZeroInt)
+		if err != nil {
+			return err
+		}
+		if status != ClonePluginActiveValue {
+			return errors.Errorf("mysql Engine.prepareCloneUser(): clone plugin is not active. addr: %s, status: %s", addr, status)
+		}
+	}
 
-		return e.transferConfigContent([]byte(content), fileName, defaultConfigFileName)
+	sqls := []string{
+		fmt.Sprintf(createCloneUserSQLTemplate, e.MySQLServer.CloneUser, e.MySQLServer.ClonePass),
+		fmt.Sprintf(grantSQLTemplate, e.MySQLServer.CloneUser),
+	}
+	for _, sql := range append(sqls, extraSQLs...) {
+		_, err = conn.Execute(sql)
+		if err != nil {
+			return err
+		}
 	}
 
-	// the instance section exists, do nothing
 	return nil
 }
 
-// stopInstance stops the instance
-func (e *Engine) stopInstance() error {
-	// connect to the mysql instance
-	conn, err := mysql.NewConn(
-		fmt.Sprintf("%s:%d", e.MySQLServer.HostIP, e.MySQLServer.PortNum),
-		constant.EmptyString,
-		constant.DefaultRootUserName,
-		e.MySQLServer.RootPass,
+// waitForCloneCompleted waits for the instance to be available after cloning and checks if the clone is completed,
+// if the instance is not restarted automatically, it will be started with mysqld_multi
+func (e *Engine) waitForCloneCompleted() error {
+	var (
+		state      string
+		errMessage string
+		err        error
 	)
+	for i := constant.ZeroInt; i < maxRetryCount; i++ {
+		state, errMessage, err = e.getCloneStatus()
+		if err == nil {
+			break
+		}
+
+		log.Warnf("mysql Engine.waitForCloneCompleted(): get clone status failed, will be retry soon. hostIP: %s, portNum: %d, retryCount: %d, error:\n%+v",
+			e.MySQLServer.HostIP, e.MySQLServer.PortNum, i, err)
+		isRunning, checkErr := e.checkInstanceWithMySQLDMulti()
+		if checkErr != nil {
+			return checkErr
+		}
+		if !isRunning {
+			startErr := e.startInstanceWithMySQLDMulti()
+			if startErr != nil {
+				return startErr
+			}
+		}
+		sleepErr := e.sleep(time.Duration(i+1) * retryInterval)
+		if sleepErr != nil {
+			return sleepErr
+		}
+	}
 	if err != nil {
 		return err
 	}
+
+	if state != CloneStateCompletedValue {
+		return errors.Errorf("mysql Engine.waitForCloneCompleted(): clone is not completed. hostIP: %s, portNum: %d, state: %s, errorMessage: %s",
+			e.MySQLServer.HostIP, e.MySQLServer.PortNum, state, errMessage)
+	}
+
+	return nil
+}
+
+// getCloneStatus gets the state and the error message of the last clone operation of the instance
+func (e *Engine) getCloneStatus() (string, string, error) {
+	conn, err := mysql.NewConn(fmt.Sprintf(addrTemplate, e.MySQLServer.HostIP, e.MySQLServer.PortNum),
+		constant.EmptyString, constant.DefaultRootUserName, e.MySQLServer.RootPass)
+	if err != nil {
+		return constant.EmptyString, constant.EmptyString, err
+	}
 	defer func() {
 		err = conn.Close()
 		if err != nil {
-			log.Errorf("Engine.stopInstance(): close mysql connection failed. error:\n%+v", err)
+			log.Errorf("mysql Engine.getCloneStatus(): close mysql connection failed. error:\n%+v", err)
 		}
 	}()
 
-	// stop the mysql instance
-	_, err = conn.Execute(shutdownSQL)
+	result, err := conn.Execute(getCloneStatusSQL)
+	if err != nil {
+		return constant.EmptyString, constant.EmptyString, err
+	}
+	if result.RowNumber() == constant.ZeroInt {
+		return constant.EmptyString, constant.EmptyString, errors.Errorf("mysql Engine.getCloneStatus(): clone status not found. hostIP: %s, portNum: %d",
+			e.MySQLServer.HostIP, e.MySQLServer.PortNum)
+	}
+	state, err := result.GetStringByName(constant.ZeroInt, CloneStateField)
+	if err != nil {
+		return constant.EmptyString, constant.EmptyString, err
+	}
+	errMessage, err := result.GetStringByName(constant.ZeroInt, CloneErrorMessageField)
+	if err != nil {
+		return constant.EmptyString, constant.EmptyString, err
+	}
 
-	return err
+	return state, errMessage, nil
 }
 
 // startInstanceWithMySQLDMulti starts the instance with mysqld_multi
@@ -552,8 +2115,10 @@ func (e *Engine) checkInstanceWithMySQLDMulti() (bool, error) {
 		}
 
 		log.Warnf("mysql Engine.checkInstanceWithMySQLDMulti(): mysqld multi instance is not running. hostIP: %s, portNum: %d, retryCount: %d", e.MySQLServer.HostIP, e.MySQLServer.PortNum, i)
-		time.Sleep(time.Duration(i+1) * retryInterval)
-		continue
+		err = e.sleep(time.Duration(i+1) * retryInterval)
+		if err != nil {
+			return false, err
+		}
 	}
 
 	return false, nil
@@ -636,3 +2201,224 @@ func (e *Engine) getSourceNode() (string, int, error) {
 
 	return hostIP, portNum, nil
 }
+
+// splitAddr splits the addr to host ip and port number
+func splitAddr(addr string) (string, int, error) {
+	hostIP, portNumStr, err := net.SplitHostPort(addr)
+	if err != nil {
+		return constant.EmptyString, constant.ZeroInt, errors.Trace(err)
+	}
+	if hostIP == constant.EmptyString || portNumStr == constant.EmptyString {
+		return constant.EmptyString, constant.ZeroInt, errors.Errorf("addr must be formatted as host:port, %s is invalid", addr)
+	}
+	portNum, err := strconv.Atoi(portNumStr)
+	if err != nil {
+		return constant.EmptyString, constant.ZeroInt, errors.Trace(err)
+	}
+
+	return hostIP, portNum, nil
+}
+
+// removeConfigSection removes the given section from the config content,
+// it returns the new content and whether the section was found
+func removeConfigSection(content, section string) (string, bool) {
+	lines := strings.Split(content, constant.CRLFString)
+	newLines := make([]string, constant.ZeroInt, len(lines))
+
+	var found, inSection bool
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, configSectionPrefix) {
+			// a new section starts
+			inSection = trimmed == section
+			if inSection {
+				found = true
+			}
+		}
+		if inSection {
+			continue
+		}
+
+		newLines = append(newLines, line)
+	}
+
+	return strings.Join(newLines, constant.CRLFString), found
+}
+
+// replaceConfigSectionValue replaces the value of the given key in the given section of the config content,
+// it returns the new content and whether the key was found in the section
+func replaceConfigSectionValue(content, section, key, value string) (string, bool) {
+	lines := strings.Split(content, constant.CRLFString)
+
+	var found, inSection bool
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, configSectionPrefix) {
+			// a new section starts
+			inSection = trimmed == section
+			continue
+		}
+		if !inSection {
+			continue
+		}
+
+		kv := strings.SplitN(trimmed, constant.EqualString, constant.TwoInt)
+		if strings.TrimSpace(kv[constant.ZeroInt]) == key {
+			lines[i] = key + constant.EqualString + value
+			found = true
+		}
+	}
+
+	return strings.Join(lines, constant.CRLFString), found
+}
+
+// replaceConfigSectionBinaryDir points the basedir, mysqld and mysqladmin of the given section of the config content to the mysql binary directory,
+// mysqld_multi starts and stops the instance with the mysqld and mysqladmin of the section, so they must be replaced along with the basedir,
+// it returns false if the basedir is not found in the section
+func replaceConfigSectionBinaryDir(content, section, binaryDirBase string) (string, bool) {
+	content, found := replaceConfigSectionValue(content, section, configBaseDirKey, binaryDirBase)
+	if !found {
+		return content, false
+	}
+	// the single instance section does not have mysqld and mysqladmin
+	content, _ = replaceConfigSectionValue(content, section, configMySQLDKey, fmt.Sprintf(configMySQLDValueTemplate, binaryDirBase))
+	content, _ = replaceConfigSectionValue(content, section, configMySQLAdminKey, fmt.Sprintf(configMySQLAdminValueTemplate, binaryDirBase))
+
+	return content, true
+}
+
+// isBinaryDirInConfig checks if the basedir, mysqld or mysqladmin of any section of the config content points to the mysql binary directory
+func isBinaryDirInConfig(content, binaryDirBase string) bool {
+	binaryDirLines := []string{
+		fmt.Sprintf(configBaseDirTemplate, binaryDirBase),
+		configMySQLDKey + constant.EqualString + fmt.Sprintf(configMySQLDValueTemplate, binaryDirBase),
+		configMySQLAdminKey + constant.EqualString + fmt.Sprintf(configMySQLAdminValueTemplate, binaryDirBase),
+	}
+	for _, line := range strings.Split(content, constant.CRLFString) {
+		if common.ElementInSlice(binaryDirLines, strings.TrimSpace(line)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getOperationStepKey returns the key of the operation step of the host
+func getOperationStepKey(hostIP string, portNum, step int) string {
+	return fmt.Sprintf(operationStepKeyTemplate, hostIP, portNum, step)
+}
+
+// groupAddrsByHost groups the addrs by the host ip, the order of the hosts and the addrs is kept
+func groupAddrsByHost(addrs []string) ([][]string, error) {
+	var hostAddrsList [][]string
+	hostIndex := make(map[string]int)
+	for _, addr := range addrs {
+		hostIP, _, err := splitAddr(addr)
+		if err != nil {
+			return nil, err
+		}
+
+		i, exists := hostIndex[hostIP]
+		if !exists {
+			hostIndex[hostIP] = len(hostAddrsList)
+			hostAddrsList = append(hostAddrsList, []string{addr})
+			continue
+		}
+		hostAddrsList[i] = append(hostAddrsList[i], addr)
+	}
+
+	return hostAddrsList, nil
+}
+
+// installSource notifies the replicas when the installation of the source is completed
+type installSource struct {
+	hostIP  string
+	portNum int
+	once    sync.Once
+	done    chan struct{}
+	err     error
+}
+
+// newInstallSource returns a new *installSource
+func newInstallSource(hostIP string, portNum int) *installSource {
+	return &installSource{
+		hostIP:  hostIP,
+		portNum: portNum,
+		done:    make(chan struct{}),
+	}
+}
+
+// is returns if the given host ip and port number is the source
+func (is *installSource) is(hostIP string, portNum int) bool {
+	return is.hostIP == hostIP && is.portNum == portNum
+}
+
+// isOnHost returns if the source is one of the addrs
+func (is *installSource) isOnHost(addrs []string) bool {
+	return common.ElementInSlice(addrs, fmt.Sprintf(addrTemplate, is.hostIP, is.portNum))
+}
+
+// finish records the result of the source installation and notifies the waiting replicas, only the first call takes effect
+func (is *installSource) finish(err error) {
+	is.once.Do(func() {
+		is.err = err
+		close(is.done)
+	})
+}
+
+// wait waits for the source installation to complete, it returns an error if the source installation failed
+func (is *installSource) wait() error {
+	<-is.done
+	if is.err != nil {
+		return errors.Errorf("mysql installSource.wait(): install source failed. source: %s, error: %s",
+			fmt.Sprintf(addrTemplate, is.hostIP, is.portNum), is.err.Error())
+	}
+
+	return nil
+}
+
+// waitForReplicaRunning waits for both the io thread and the sql thread of the replica to be running
+func (e *Engine) waitForReplicaRunning(conn *mysql.Conn, addr string) error {
+	rs, err := getConnReplicationSyntax(conn)
+	if err != nil {
+		return err
+	}
+
+	var status string
+	for i := constant.ZeroInt; i < maxRetryCount; i++ {
+		result, err := conn.Execute(rs.showReplicaStatusSQL)
+		if err != nil {
+			return err
+		}
+
+		// check io thread
+		status, err = result.GetStringByName(constant.ZeroInt, rs.ioThreadRunningField)
+		if err != nil {
+			return err
+		}
+		if status != IsRunningValue {
+			log.Warnf("mysql Engine.waitForReplicaRunning(): slave io thread is not running, will be retry soon. addr: %s, status: %s, retryCount: %d", addr, status, i)
+			err = e.sleep(time.Duration(i+1) * checkReplicaInterval)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		// check sql thread
+		status, err = result.GetStringByName(constant.ZeroInt, rs.sqlThreadRunningField)
+		if err != nil {
+			return err
+		}
+		if status == IsRunningValue {
+			return nil
+		}
+
+		log.Warnf("mysql Engine.waitForReplicaRunning(): slave sql thread is not running, will be retry soon. addr: %s, status: %s, retryCount: %d", addr, status, i)
+		err = e.sleep(time.Duration(i+1) * checkReplicaInterval)
+		if err != nil {
+			return err
+		}
+	}
+
+	return errors.Errorf("mysql Engine.waitForReplicaRunning(): slave io/sql thread is not running. addr: %s, status: %s", addr, status)
+}
@@ -28,6 +28,8 @@ const (
 
 	testMySQLInstallationPackageDir = "/data/software/mysql"
 
+	testClusterName = "test-cluster"
+
 	testHostIP1         = "192.168.137.21"
 	testPortNum1        = 3306
 	testHostIP2         = "192.168.137.21"
@@ -49,6 +51,8 @@ const (
 	testReplicationPass = "replication"
 	testDASUser         = "das"
 	testDASPass         = "das"
+	testCloneUser       = "clone"
+	testClonePass       = "clone"
 
 	defaultTitle                        = "mysqld"
 	testVersion                         = "8.0.32"
@@ -70,10 +74,11 @@ const (
 	testOSPass  = "dba"
 	testUseSudo = true
 
-	testArch            = "aarch64"
-	testOSVersionStr    = "9.0"
-	testMySQLVersionStr = "8.0.32"
-	testMode            = mode.AsyncReplication
+	testArch                   = "aarch64"
+	testOSVersionStr           = "9.0"
+	testMySQLVersionStr        = "8.0.32"
+	testUpgradeMySQLVersionStr = "8.0.34"
+	testMode                   = mode.AsyncReplication
 
 	testPMMServerAddr      = "192.168.137.11:443"
 	testPMMServerUser      = "admin"
@@ -88,8 +93,6 @@ const (
 	testRemoveDataDirCommand = "rm -rf %s"
 	testRemoveBaseDirCommand = "rm -rf %s"
 	testRemove
-
-	testCheckSlaveStatusSQL = "show slave status"
 )
 
 var (
@@ -133,6 +136,7 @@ func testInitViper() {
 	viper.Set(config.DBPoolKeepAliveIntervalKey, mysql.DefaultKeepAliveInterval)
 
 	viper.Set(config.MySQLInstallationPackageDirKey, testMySQLInstallationPackageDir)
+	viper.Set(config.MySQLLockLeaseTimeoutKey, config.DefaultMySQLLockLeaseTimeout)
 	viper.Set(config.MySQLUserOSUserKey, testOSUser)
 	viper.Set(config.MySQLUserOSPassKey, testOSPass)
 	viper.Set(config.MySQLUserMonitorUserKey, testMonitorUser)
@@ -181,6 +185,8 @@ func testInitMySQLServer(hostIP string, portNum, serverID int) *parameter.MySQLS
 		testMonitorPass,
 		testDASUser,
 		testDASPass,
+		testCloneUser,
+		testClonePass,
 		defaultTitle,
 		testBinaryDirBase,
 		testDataDirBaseName,
@@ -202,7 +208,10 @@ func testInitMySQLServer(hostIP string, portNum, serverID int) *parameter.MySQLS
 }
 
 func testInitEngine() *Engine {
-	return NewEngineWithDefault(testMySQLVersion, testMode, testAddrs, testMySQLServer, testPMMClient)
+	e := NewEngineWithDefault(testMySQLVersion, testMode, testAddrs, testMySQLServer, testPMMClient)
+	e.SetClusterName(testClusterName)
+
+	return e
 }
 
 func testInitInstance(addrs []string) error {
@@ -270,8 +279,21 @@ func TestEngine_All(t *testing.T) {
 	TestEngine_InstallSingeInstance(t)
 	TestEngine_InitMySQLInstance(t)
 	TestEngine_ConfigureReplication(t)
+	TestEngine_CheckSemiSyncReplication(t)
 	TestEngine_InitPMMClient(t)
 	TestEngine_ConfigureGroupReplication(t)
+	TestEngine_RemoveInstance(t)
+	TestEngine_Remove(t)
+	TestRemoveConfigSection(t)
+	TestEngine_Upgrade(t)
+	TestReplaceConfigSectionValue(t)
+	TestReplaceConfigSectionBinaryDir(t)
+	TestIsBinaryDirInConfig(t)
+	TestEngine_RunStep(t)
+	TestGroupAddrsByHost(t)
+	TestGroupReplicationHasOnlineMember(t)
+	TestInstallSource(t)
+	TestEngine_AddReplica(t)
 }
 
 func TestEngine_InitOSExecutor(t *testing.T) {
@@ -360,15 +382,272 @@ func TestEngine_ConfigureReplication(t *testing.T) {
 		asst.Nil(err, "test ConfigureReplica() failed")
 	}()
 	// check version
-	result, err := conn.Conn.Execute(testCheckSlaveStatusSQL)
+	rs, err := getConnReplicationSyntax(conn)
+	asst.Nil(err, "test ConfigureReplica() failed")
+	result, err := conn.Execute(rs.showReplicaStatusSQL)
 	asst.Nil(err, "test ConfigureReplica() failed")
 	asst.True(result.RowNumber() == 1, "test ConfigureReplica() failed")
 }
 
+func TestEngine_CheckSemiSyncReplication(t *testing.T) {
+	asst := assert.New(t)
+
+	testEngine.Mode = mode.SemiSyncReplication
+	defer func() {
+		testEngine.Mode = testMode
+	}()
+
+	err := testInitInstance(testAddrs)
+	asst.Nil(err, "test CheckSemiSyncReplication() failed")
+	err = testEngine.ConfigureReplica(testAddr2, testHostIP1, testPortNum1)
+	asst.Nil(err, "test CheckSemiSyncReplication() failed")
+	err = testEngine.CheckSemiSyncReplication(testAddr2, testHostIP1, testPortNum1)
+	asst.Nil(err, "test CheckSemiSyncReplication() failed")
+}
+
 func TestEngine_InitPMMClient(t *testing.T) {
 
 }
 
 func TestEngine_ConfigureGroupReplication(t *testing.T) {
+	asst := assert.New(t)
+
+	testEngine.Mode = mode.GroupReplication
+	defer func() {
+		testEngine.Mode = testMode
+	}()
+
+	err := testEngine.initGroupReplicationParameter()
+	asst.Nil(err, "test ConfigureGroupReplication() failed")
+	err = testInitInstance(testAddrs)
+	asst.Nil(err, "test ConfigureGroupReplication() failed")
+
+	err = testEngine.ConfigureGroupReplication()
+	asst.Nil(err, "test ConfigureGroupReplication() failed")
+	// create connection
+	conn, err := mysql.NewConn(testAddr1, constant.EmptyString, testClientUser, testClientPass)
+	asst.Nil(err, "test ConfigureGroupReplication() failed")
+	defer func() {
+		err = conn.Close()
+		asst.Nil(err, "test ConfigureGroupReplication() failed")
+	}()
+	// check members
+	members, err := testEngine.getGroupReplicationMembers(conn)
+	asst.Nil(err, "test ConfigureGroupReplication() failed")
+	asst.Equal(len(testAddrs), len(members), "test ConfigureGroupReplication() failed")
+	for _, addr := range testAddrs {
+		asst.Equal(GroupReplicationMemberOnlineValue, members[addr], "test ConfigureGroupReplication() failed")
+	}
+}
+
+func TestEngine_RemoveInstance(t *testing.T) {
+	asst := assert.New(t)
+
+	err := testInitInstance([]string{testAddr1})
+	asst.Nil(err, "test RemoveInstance() failed")
+
+	err = testEngine.RemoveInstance(testHostIP1, testPortNum1, false)
+	asst.Nil(err, "test RemoveInstance() failed")
+	// check the data directory
+	exists, err := testEngine.ose.Conn.PathExists(testEngine.MySQLServer.DataDirBase)
+	asst.Nil(err, "test RemoveInstance() failed")
+	asst.False(exists, "test RemoveInstance() failed")
+	// check the config file
+	content, err := testEngine.ose.Conn.Cat(defaultConfigFileName)
+	asst.Nil(err, "test RemoveInstance() failed")
+	asst.NotContains(content, fmt.Sprintf(mysqldMultiInstanceSectionTemplate, testPortNum1), "test RemoveInstance() failed")
+}
+
+func TestEngine_Remove(t *testing.T) {
+	asst := assert.New(t)
+
+	err := testInitInstance(testAddrs)
+	asst.Nil(err, "test Remove() failed")
+
+	err = testEngine.Remove(1, true, true)
+	asst.Nil(err, "test Remove() failed")
+	// check the binary directory
+	exists, err := testEngine.ose.Conn.PathExists(testEngine.MySQLServer.BinaryDirBase)
+	asst.Nil(err, "test Remove() failed")
+	asst.False(exists, "test Remove() failed")
+}
+
+func TestRemoveConfigSection(t *testing.T) {
+	asst := assert.New(t)
+
+	content := "[mysqld_multi]\nuser=mysqld_multi\n\n[mysqld3306]\nport=3306\n\n[mysqld3307]\nport=3307\n"
+	newContent, found := removeConfigSection(content, fmt.Sprintf(mysqldMultiInstanceSectionTemplate, testPortNum1))
+	asst.True(found, "test removeConfigSection() failed")
+	asst.Equal("[mysqld_multi]\nuser=mysqld_multi\n\n[mysqld3307]\nport=3307\n", newContent, "test removeConfigSection() failed")
+	_, found = removeConfigSection(content, fmt.Sprintf(mysqldMultiInstanceSectionTemplate, testPortNum3))
+	asst.False(found, "test removeConfigSection() failed")
+}
 
+func TestEngine_Upgrade(t *testing.T) {
+	asst := assert.New(t)
+
+	err := testInitInstance(testAddrs)
+	asst.Nil(err, "test Upgrade() failed")
+	err = testEngine.ConfigureReplica(testAddr2, testHostIP1, testPortNum1)
+	asst.Nil(err, "test Upgrade() failed")
+	// upgrade
+	upgradeVersion := version.Must(version.NewVersion(testUpgradeMySQLVersionStr))
+	testEngine.mysqlVersion = upgradeVersion
+	testEngine.MySQLServer.SetVersion(testUpgradeMySQLVersionStr)
+	defer func() {
+		testEngine.mysqlVersion = testMySQLVersion
+		testEngine.MySQLServer.SetVersion(testMySQLVersionStr)
+	}()
+	addrs, err := testEngine.getUpgradeOrder()
+	asst.Nil(err, "test Upgrade() failed")
+	asst.Equal([]string{testAddr2, testAddr1}, addrs, "test Upgrade() failed")
+	err = testEngine.Upgrade(1)
+	asst.Nil(err, "test Upgrade() failed")
+	// check version
+	for _, addr := range testAddrs {
+		hostIP, portNum, err := splitAddr(addr)
+		asst.Nil(err, "test Upgrade() failed")
+		err = testEngine.MySQLServer.InitWithHostInfo(hostIP, portNum, false)
+		asst.Nil(err, "test Upgrade() failed")
+		runningVersion, err := testEngine.getRunningVersion()
+		asst.Nil(err, "test Upgrade() failed")
+		asst.True(runningVersion.Core().Equal(upgradeVersion), "test Upgrade() failed")
+	}
+}
+
+func TestReplaceConfigSectionValue(t *testing.T) {
+	asst := assert.New(t)
+
+	content := "[mysqld3306]\nbasedir=/data/mysql/mysql8.0.32\n\n[mysqld3307]\nbasedir=/data/mysql/mysql8.0.32\n"
+	newContent, found := replaceConfigSectionValue(content, fmt.Sprintf(mysqldMultiInstanceSectionTemplate, testPortNum1), configBaseDirKey, "/data/mysql/mysql8.0.34")
+	asst.True(found, "test replaceConfigSectionValue() failed")
+	asst.Equal("[mysqld3306]\nbasedir=/data/mysql/mysql8.0.34\n\n[mysqld3307]\nbasedir=/data/mysql/mysql8.0.32\n", newContent, "test replaceConfigSectionValue() failed")
+	_, found = replaceConfigSectionValue(content, fmt.Sprintf(mysqldMultiInstanceSectionTemplate, testPortNum3), configBaseDirKey, "/data/mysql/mysql8.0.34")
+	asst.False(found, "test replaceConfigSectionValue() failed")
+}
+
+func TestReplaceConfigSectionBinaryDir(t *testing.T) {
+	asst := assert.New(t)
+
+	content := "[mysqld3306]\nbasedir=/data/mysql/mysql8.0.32\nmysqld=/data/mysql/mysql8.0.32/bin/mysqld_safe\nmysqladmin=/data/mysql/mysql8.0.32/bin/mysqladmin\n\n" +
+		"[mysqld3307]\nbasedir=/data/mysql/mysql8.0.32\nmysqld=/data/mysql/mysql8.0.32/bin/mysqld_safe\nmysqladmin=/data/mysql/mysql8.0.32/bin/mysqladmin\n"
+	newContent, found := replaceConfigSectionBinaryDir(content, fmt.Sprintf(mysqldMultiInstanceSectionTemplate, testPortNum1), "/data/mysql/mysql8.0.34")
+	asst.True(found, "test replaceConfigSectionBinaryDir() failed")
+	asst.Equal("[mysqld3306]\nbasedir=/data/mysql/mysql8.0.34\nmysqld=/data/mysql/mysql8.0.34/bin/mysqld_safe\nmysqladmin=/data/mysql/mysql8.0.34/bin/mysqladmin\n\n"+
+		"[mysqld3307]\nbasedir=/data/mysql/mysql8.0.32\nmysqld=/data/mysql/mysql8.0.32/bin/mysqld_safe\nmysqladmin=/data/mysql/mysql8.0.32/bin/mysqladmin\n",
+		newContent, "test replaceConfigSectionBinaryDir() failed")
+	_, found = replaceConfigSectionBinaryDir(content, fmt.Sprintf(mysqldMultiInstanceSectionTemplate, testPortNum3), "/data/mysql/mysql8.0.34")
+	asst.False(found, "test replaceConfigSectionBinaryDir() failed")
+}
+
+func TestIsBinaryDirInConfig(t *testing.T) {
+	asst := assert.New(t)
+
+	// the instance which is not upgraded yet still starts with the old mysqld_safe
+	content := "[mysqld3306]\nbasedir=/data/mysql/mysql8.0.34\nmysqld=/data/mysql/mysql8.0.32/bin/mysqld_safe\n"
+	asst.True(isBinaryDirInConfig(content, "/data/mysql/mysql8.0.32"), "test isBinaryDirInConfig() failed")
+	asst.True(isBinaryDirInConfig(content, "/data/mysql/mysql8.0.34"), "test isBinaryDirInConfig() failed")
+	asst.False(isBinaryDirInConfig(content, "/data/mysql/mysql8.0.36"), "test isBinaryDirInConfig() failed")
+}
+
+func TestEngine_RunStep(t *testing.T) {
+	asst := assert.New(t)
+
+	operationID, err := testEngine.dboRepo.InitOperationHistory(defaultInstallOperation, testAddrs, constant.EmptyString)
+	asst.Nil(err, "test runStep() failed")
+	err = testEngine.loadOperationProgress(operationID)
+	asst.Nil(err, "test runStep() failed")
+	defer func() {
+		testEngine.operationID = constant.ZeroInt
+	}()
+
+	count := constant.ZeroInt
+	stepFunc := func() error {
+		count++
+		return nil
+	}
+	// the step runs for the first time
+	err = testEngine.runStep(testHostIP1, testPortNum1, installStepInitOS, stepFunc)
+	asst.Nil(err, "test runStep() failed")
+	asst.Equal(1, count, "test runStep() failed")
+	// the completed step is skipped after reloading the progress
+	err = testEngine.loadOperationProgress(operationID)
+	asst.Nil(err, "test runStep() failed")
+	err = testEngine.runStep(testHostIP1, testPortNum1, installStepInitOS, stepFunc)
+	asst.Nil(err, "test runStep() failed")
+	asst.Equal(1, count, "test runStep() failed")
+	// the step runs again after it is reset
+	testEngine.resetOperationSteps(testHostIP1, testPortNum1, installStepInitOS)
+	err = testEngine.runStep(testHostIP1, testPortNum1, installStepInitOS, stepFunc)
+	asst.Nil(err, "test runStep() failed")
+	asst.Equal(2, count, "test runStep() failed")
+}
+
+func TestGroupAddrsByHost(t *testing.T) {
+	asst := assert.New(t)
+
+	addrs := []string{testAddr1, "192.168.137.22:3306", testAddr2, "192.168.137.22:3307"}
+	hostAddrsList, err := groupAddrsByHost(addrs)
+	asst.Nil(err, "test groupAddrsByHost() failed")
+	asst.Equal([][]string{{testAddr1, testAddr2}, {"192.168.137.22:3306", "192.168.137.22:3307"}}, hostAddrsList, "test groupAddrsByHost() failed")
+	_, err = groupAddrsByHost([]string{testHostIP1})
+	asst.NotNil(err, "test groupAddrsByHost() failed")
+}
+
+func TestGroupReplicationHasOnlineMember(t *testing.T) {
+	asst := assert.New(t)
+
+	asst.False(groupReplicationHasOnlineMember(map[string]string{testAddr1: constant.EmptyString, testAddr2: GroupReplicationMemberOfflineValue}), "test groupReplicationHasOnlineMember() failed")
+	asst.True(groupReplicationHasOnlineMember(map[string]string{testAddr1: "ERROR", testAddr2: GroupReplicationMemberOnlineValue}), "test groupReplicationHasOnlineMember() failed")
+}
+
+func TestInstallSource(t *testing.T) {
+	asst := assert.New(t)
+
+	source := newInstallSource(testHostIP1, testPortNum1)
+	asst.True(source.is(testHostIP1, testPortNum1), "test installSource failed")
+	asst.True(source.isOnHost(testAddrs), "test installSource failed")
+	asst.False(source.isOnHost([]string{testAddr3}), "test installSource failed")
+	// only the first result takes effect
+	go source.finish(nil)
+	asst.Nil(source.wait(), "test installSource failed")
+	source.finish(errors.New("test error"))
+	asst.Nil(source.wait(), "test installSource failed")
+	// the replicas get the error of the source
+	source = newInstallSource(testHostIP1, testPortNum1)
+	source.finish(errors.New("test error"))
+	asst.NotNil(source.wait(), "test installSource failed")
+}
+
+func TestEngine_AddReplica(t *testing.T) {
+	asst := assert.New(t)
+
+	// init the source
+	err := testInitInstance([]string{testAddr1})
+	asst.Nil(err, "test AddReplica() failed")
+	// clear previous mysql server
+	err = testClearMySQL(testAddr3)
+	asst.Nil(err, "test AddReplica() failed")
+	// add replica
+	e := NewEngineWithDefault(testMySQLVersion, mode.AsyncReplication, []string{testAddr3}, testInitMySQLServer(testHostIP3, testPortNum3, testServerID), testPMMClient)
+	err = e.AddReplica(constant.ZeroInt, testAddr1)
+	asst.Nil(err, "test AddReplica() failed")
+	// check clone status
+	state, errMessage, err := e.getCloneStatus()
+	asst.Nil(err, "test AddReplica() failed")
+	asst.Equal(CloneStateCompletedValue, state, "test AddReplica() failed. error message: %s", errMessage)
+	// check replication
+	conn, err := mysql.NewConn(testAddr3, constant.EmptyString, testClientUser, testClientPass)
+	asst.Nil(err, "test AddReplica() failed")
+	defer func() {
+		err = conn.Close()
+		asst.Nil(err, "test AddReplica() failed")
+	}()
+	rs, err := getConnReplicationSyntax(conn)
+	asst.Nil(err, "test AddReplica() failed")
+	result, err := conn.Execute(rs.showReplicaStatusSQL)
+	asst.Nil(err, "test AddReplica() failed")
+	status, err := result.GetStringByName(constant.ZeroInt, rs.sqlThreadRunningField)
+	asst.Nil(err, "test AddReplica() failed")
+	asst.Equal(IsRunningValue, status, "test AddReplica() failed")
 }
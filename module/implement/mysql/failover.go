@@ -0,0 +1,336 @@
+package mysql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/romberli/go-util/common"
+	"github.com/romberli/go-util/constant"
+	"github.com/romberli/go-util/middleware/mysql"
+	"github.com/romberli/log"
+
+	"github.com/romberli/db-operator/module/implement/mysql/mode"
+	"github.com/romberli/db-operator/pkg/message"
+
+	msgMySQL "github.com/romberli/db-operator/pkg/message/mysql"
+)
+
+const (
+	failoverSuccessMessage = "failover completed."
+	failoverPanicMessage   = "failover failed because of panic, please check the log for more details."
+
+	applyRelayLogSuccessMessage  = "replica applied all the retrieved transactions."
+	promoteReplicaSuccessMessage = "promote replica completed."
+	fenceSourceSuccessMessage    = "fence source completed."
+	fenceSourceReasonTemplate    = "failed over to %s"
+
+	getServerUUIDSQL                  = "select @@server_uuid ;"
+	gtidSubtractSQLTemplate           = "select gtid_subtract('%s', '%s') ;"
+	gtidSubsetSQLTemplate             = "select gtid_subset('%s', '%s') ;"
+	RetrievedGTIDSetField             = "Retrieved_Gtid_Set"
+	ExecutedGTIDSetField              = "Executed_Gtid_Set"
+	gtidSubsetTrueValue               = 1
+	gtidSetUUIDSeparator              = ":"
+	gtidSetSeparator                  = ","
+	gtidSetTrimChars                  = " \t\r\n"
+	defaultWaitForRelayLogGTIDTimeout = 300
+)
+
+// replicaGTIDInfo is the gtid information of a surviving replica
+type replicaGTIDInfo struct {
+	addr            string
+	serverUUID      string
+	executedGTIDSet string
+}
+
+// Failover promotes the most advanced surviving replica to be the new source when the source of the sourceAddr is dead,
+// the other surviving replicas of the addrs are repointed to the new source,
+// it refuses to continue if the source is still reachable or any surviving replica has errant gtids,
+// and the old source will be fenced, so that it will not be reattached to the cluster by accident
+func (e *Engine) Failover(operationID int, sourceAddr string) error {
+	if e.Mode != mode.AsyncReplication && e.Mode != mode.SemiSyncReplication {
+		return errors.Errorf("mysql Engine.Failover(): only async replication and semi-sync replication modes are supported. mode: %d", e.Mode)
+	}
+	if !common.ElementInSlice(e.Addrs, sourceAddr) {
+		return errors.Errorf("mysql Engine.Failover(): the source must be in the addrs. source: %s, addrs: %v", sourceAddr, e.Addrs)
+	}
+	var replicaAddrs []string
+	for _, addr := range e.Addrs {
+		if addr != sourceAddr {
+			replicaAddrs = append(replicaAddrs, addr)
+		}
+	}
+	if len(replicaAddrs) == constant.ZeroInt {
+		return errors.Errorf("mysql Engine.Failover(): there is no surviving replica. source: %s, addrs: %v", sourceAddr, e.Addrs)
+	}
+	sourceHostIP, sourcePortNum, err := splitAddr(sourceAddr)
+	if err != nil {
+		return err
+	}
+	// the planned switchover should be used if the source is still alive, otherwise the cluster may have two writable sources
+	conn, err := mysql.NewConn(sourceAddr, constant.EmptyString, constant.DefaultRootUserName, e.MySQLServer.RootPass)
+	if err == nil {
+		err = conn.Close()
+		if err != nil {
+			log.Errorf("mysql Engine.Failover(): close mysql connection failed. error:\n%+v", err)
+		}
+		return errors.Errorf("mysql Engine.Failover(): the source is still reachable, please use switchover instead. source: %s", sourceAddr)
+	}
+
+	// wait for the replicas to apply all the transactions retrieved from the old source
+	replicaInfoList := make([]*replicaGTIDInfo, len(replicaAddrs))
+	for i, addr := range replicaAddrs {
+		err = e.runOperationDetail(operationID, addr, applyRelayLogSuccessMessage, func() error {
+			replicaInfo, err := e.getReplicaGTIDInfo(addr)
+			if err != nil {
+				return err
+			}
+			replicaInfoList[i] = replicaInfo
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	// choose the most advanced replica
+	candidate, err := e.chooseFailoverCandidate(replicaInfoList)
+	if err != nil {
+		return err
+	}
+	// promote the candidate
+	err = e.runOperationDetail(operationID, candidate, promoteReplicaSuccessMessage, func() error {
+		return e.promoteSource(candidate)
+	})
+	if err != nil {
+		return err
+	}
+	// repoint the other replicas to the new source
+	for _, addr := range replicaAddrs {
+		if addr == candidate {
+			continue
+		}
+
+		err = e.runOperationDetail(operationID, addr, repointReplicaSuccessMessage, func() error {
+			return e.repointReplica(addr, candidate)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	// fence the old source
+	err = e.runOperationDetail(operationID, sourceAddr, fenceSourceSuccessMessage, func() error {
+		return e.dboRepo.FenceInstance(operationID, sourceHostIP, sourcePortNum, fmt.Sprintf(fenceSourceReasonTemplate, candidate))
+	})
+	if err != nil {
+		return err
+	}
+
+	e.setClusterSource(candidate)
+	log.Infof(message.NewMessage(msgMySQL.InfoMySQLEngineFailover, operationID, sourceAddr, candidate).Error())
+
+	return nil
+}
+
+// getReplicaGTIDInfo waits for the replica of the addr to apply all the retrieved transactions
+// and returns the gtid information of the replica
+func (e *Engine) getReplicaGTIDInfo(addr string) (*replicaGTIDInfo, error) {
+	conn, err := mysql.NewConn(addr, constant.EmptyString, constant.DefaultRootUserName, e.MySQLServer.RootPass)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = conn.Close()
+		if err != nil {
+			log.Errorf("mysql Engine.getReplicaGTIDInfo(): close mysql connection failed. error:\n%+v", err)
+		}
+	}()
+
+	rs, err := getConnReplicationSyntax(conn)
+	if err != nil {
+		return nil, err
+	}
+	result, err := conn.Execute(rs.showReplicaStatusSQL)
+	if err != nil {
+		return nil, err
+	}
+	if result.RowNumber() == constant.ZeroInt {
+		return nil, errors.Errorf("mysql Engine.getReplicaGTIDInfo(): replication is not configured. addr: %s", addr)
+	}
+	retrievedGTIDSet, err := result.GetStringByName(constant.ZeroInt, RetrievedGTIDSetField)
+	if err != nil {
+		return nil, err
+	}
+	// the retrieved gtid set only contains the transactions in the relay log,
+	// the transactions executed before must be already applied
+	result, err = conn.Execute(fmt.Sprintf(waitForExecutedGTIDSetSQLTemplate, retrievedGTIDSet, defaultWaitForRelayLogGTIDTimeout))
+	if err != nil {
+		return nil, err
+	}
+	ret, err := result.GetInt(constant.ZeroInt, constant.ZeroInt)
+	if err != nil {
+		return nil, err
+	}
+	if ret != waitForExecutedGTIDSetSuccessValue {
+		return nil, errors.Errorf("mysql Engine.getReplicaGTIDInfo(): the replica did not apply all the retrieved transactions in time. addr: %s, retrievedGTIDSet: %s, timeout: %d",
+			addr, retrievedGTIDSet, defaultWaitForRelayLogGTIDTimeout)
+	}
+
+	result, err = conn.Execute(rs.showReplicaStatusSQL)
+	if err != nil {
+		return nil, err
+	}
+	executedGTIDSet, err := result.GetStringByName(constant.ZeroInt, ExecutedGTIDSetField)
+	if err != nil {
+		return nil, err
+	}
+	result, err = conn.Execute(getServerUUIDSQL)
+	if err != nil {
+		return nil, err
+	}
+	serverUUID, err := result.GetString(constant.ZeroInt, constant.ZeroInt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &replicaGTIDInfo{
+		addr:            addr,
+		serverUUID:      serverUUID,
+		executedGTIDSet: executedGTIDSet,
+	}, nil
+}
+
+// chooseFailoverCandidate checks the errant gtids of the replicas and returns the addr of the replica
+// whose executed gtid set contains the executed gtid sets of all the other replicas.
+// a transaction originated on a replica is errant if none of the other replicas has executed it,
+// as the old source is dead, the errant gtids could not be checked if there is only one surviving replica
+func (e *Engine) chooseFailoverCandidate(replicaInfoList []*replicaGTIDInfo) (string, error) {
+	if len(replicaInfoList) == constant.OneInt {
+		log.Warnf("mysql Engine.chooseFailoverCandidate(): there is only one surviving replica, errant gtids could not be checked. addr: %s",
+			replicaInfoList[constant.ZeroInt].addr)
+		return replicaInfoList[constant.ZeroInt].addr, nil
+	}
+
+	conn, err := mysql.NewConn(replicaInfoList[constant.ZeroInt].addr, constant.EmptyString, constant.DefaultRootUserName, e.MySQLServer.RootPass)
+	if err != nil {
+		return constant.EmptyString, err
+	}
+	defer func() {
+		err = conn.Close()
+		if err != nil {
+			log.Errorf("mysql Engine.chooseFailoverCandidate(): close mysql connection failed. error:\n%+v", err)
+		}
+	}()
+
+	// check errant gtids
+	var errantMessages []string
+	for _, replicaInfo := range replicaInfoList {
+		errantGTIDSet := filterGTIDSetByUUID(replicaInfo.executedGTIDSet, replicaInfo.serverUUID)
+		for _, other := range replicaInfoList {
+			if errantGTIDSet == constant.EmptyString {
+				break
+			}
+			if other == replicaInfo {
+				continue
+			}
+			errantGTIDSet, err = gtidSubtract(conn, errantGTIDSet, other.executedGTIDSet)
+			if err != nil {
+				return constant.EmptyString, err
+			}
+		}
+		if errantGTIDSet != constant.EmptyString {
+			errantMessages = append(errantMessages, fmt.Sprintf("%s: %s", replicaInfo.addr, errantGTIDSet))
+		}
+	}
+	if len(errantMessages) > constant.ZeroInt {
+		return constant.EmptyString, errors.Errorf("mysql Engine.chooseFailoverCandidate(): errant gtids found on some of the replicas, please fix them manually. errantGTIDs: %s",
+			strings.Join(errantMessages, constant.SemicolonString))
+	}
+
+	// choose the most advanced replica
+	for _, replicaInfo := range replicaInfoList {
+		isCandidate := true
+		for _, other := range replicaInfoList {
+			if other == replicaInfo {
+				continue
+			}
+			isSubset, err := isGTIDSubset(conn, other.executedGTIDSet, replicaInfo.executedGTIDSet)
+			if err != nil {
+				return constant.EmptyString, err
+			}
+			if !isSubset {
+				isCandidate = false
+				break
+			}
+		}
+		if isCandidate {
+			return replicaInfo.addr, nil
+		}
+	}
+
+	return constant.EmptyString, errors.New("mysql Engine.chooseFailoverCandidate(): none of the replicas has executed all the transactions of the other replicas, please check the replicas manually")
+}
+
+// gtidSubtract returns the gtids of the gtidSet which are not in the subtractGTIDSet
+func gtidSubtract(conn *mysql.Conn, gtidSet, subtractGTIDSet string) (string, error) {
+	result, err := conn.Execute(fmt.Sprintf(gtidSubtractSQLTemplate, gtidSet, subtractGTIDSet))
+	if err != nil {
+		return constant.EmptyString, err
+	}
+	gtidSet, err = result.GetString(constant.ZeroInt, constant.ZeroInt)
+	if err != nil {
+		return constant.EmptyString, err
+	}
+
+	return strings.Trim(gtidSet, gtidSetTrimChars), nil
+}
+
+// isGTIDSubset checks if all the gtids of the gtidSet are also in the superGTIDSet
+func isGTIDSubset(conn *mysql.Conn, gtidSet, superGTIDSet string) (bool, error) {
+	result, err := conn.Execute(fmt.Sprintf(gtidSubsetSQLTemplate, gtidSet, superGTIDSet))
+	if err != nil {
+		return false, err
+	}
+	ret, err := result.GetInt(constant.ZeroInt, constant.ZeroInt)
+	if err != nil {
+		return false, err
+	}
+
+	return ret == gtidSubsetTrueValue, nil
+}
+
+// filterGTIDSetByUUID returns the gtids of the gtidSet which were originated on the server of the serverUUID
+func filterGTIDSetByUUID(gtidSet, serverUUID string) string {
+	var filtered []string
+	for _, gtid := range strings.Split(gtidSet, gtidSetSeparator) {
+		gtid = strings.Trim(gtid, gtidSetTrimChars)
+		if strings.HasPrefix(strings.ToLower(gtid), strings.ToLower(serverUUID)+gtidSetUUIDSeparator) {
+			filtered = append(filtered, gtid)
+		}
+	}
+
+	return strings.Join(filtered, gtidSetSeparator)
+}
+
+// checkFencedAddrs returns an error if any of the addrs is fenced
+func (e *Engine) checkFencedAddrs(addrs ...string) error {
+	fencedInstances, err := e.dboRepo.GetFencedInstances()
+	if err != nil {
+		return err
+	}
+
+	var fencedAddrs []string
+	for _, fencedInstance := range fencedInstances {
+		addr := fmt.Sprintf(addrTemplate, fencedInstance.HostIP, fencedInstance.PortNum)
+		if common.ElementInSlice(addrs, addr) {
+			fencedAddrs = append(fencedAddrs, addr)
+		}
+	}
+	if len(fencedAddrs) > constant.ZeroInt {
+		return errors.Errorf("mysql Engine.checkFencedAddrs(): some of the instances were fenced by the failover, please remove them before reusing the addrs. fencedAddrs: %s",
+			strings.Join(fencedAddrs, constant.CommaString))
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package mysql
+
+import (
+	"testing"
+
+	"github.com/romberli/go-util/constant"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFailover_All(t *testing.T) {
+	TestFilterGTIDSetByUUID(t)
+	TestEngine_Failover(t)
+}
+
+func TestFilterGTIDSetByUUID(t *testing.T) {
+	asst := assert.New(t)
+
+	gtidSet := "3e11fa47-71ca-11e1-9e33-c80aa9429562:1-5,\n4e11fa47-71ca-11e1-9e33-c80aa9429562:1-3:5"
+	asst.Equal("4e11fa47-71ca-11e1-9e33-c80aa9429562:1-3:5",
+		filterGTIDSetByUUID(gtidSet, "4E11FA47-71CA-11E1-9E33-C80AA9429562"), "test filterGTIDSetByUUID() failed")
+	asst.Equal(constant.EmptyString,
+		filterGTIDSetByUUID(gtidSet, "5e11fa47-71ca-11e1-9e33-c80aa9429562"), "test filterGTIDSetByUUID() failed")
+	asst.Equal(constant.EmptyString, filterGTIDSetByUUID(constant.EmptyString, "3e11fa47-71ca-11e1-9e33-c80aa9429562"),
+		"test filterGTIDSetByUUID() failed")
+}
+
+func TestEngine_Failover(t *testing.T) {
+	asst := assert.New(t)
+
+	err := testInitInstance(testAddrs)
+	asst.Nil(err, "test Failover() failed")
+	err = testEngine.ConfigureReplica(testAddr2, testHostIP1, testPortNum1)
+	asst.Nil(err, "test Failover() failed")
+	// the source is still alive
+	err = testEngine.Failover(1, testAddr1)
+	asst.NotNil(err, "test Failover() failed")
+	// shutdown the source
+	err = testEngine.executeSQLs(testAddr1, shutdownSQL)
+	asst.Nil(err, "test Failover() failed")
+	err = testEngine.Failover(1, testAddr1)
+	asst.Nil(err, "test Failover() failed")
+	isReplica, err := testEngine.isReplica(testAddr2)
+	asst.Nil(err, "test Failover() failed")
+	asst.False(isReplica, "test Failover() failed")
+	// the old source is fenced
+	err = testEngine.checkFencedAddrs(testAddr1)
+	asst.NotNil(err, "test Failover() failed")
+	err = testEngine.dboRepo.UnfenceInstance(testHostIP1, testPortNum1)
+	asst.Nil(err, "test Failover() failed")
+}
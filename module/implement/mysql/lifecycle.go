@@ -0,0 +1,149 @@
+package mysql
+
+import (
+	"github.com/romberli/go-util/constant"
+	"github.com/romberli/log"
+
+	"github.com/romberli/db-operator/pkg/message"
+
+	msgMySQL "github.com/romberli/db-operator/pkg/message/mysql"
+)
+
+const (
+	startSuccessMessage   = "start mysql server completed."
+	startPanicMessage     = "start mysql server failed because of panic, please check the log for more details."
+	stopSuccessMessage    = "stop mysql server completed."
+	stopPanicMessage      = "stop mysql server failed because of panic, please check the log for more details."
+	restartSuccessMessage = "restart mysql server completed."
+	restartPanicMessage   = "restart mysql server failed because of panic, please check the log for more details."
+)
+
+// Start starts the mysql instances of the addrs with mysqld_multi, the instance which is already running will be skipped
+func (e *Engine) Start(operationID int) error {
+	return e.runInstanceOperation(operationID, startSuccessMessage, func(hostIP string, portNum int) error {
+		return e.StartInstance(hostIP, portNum)
+	}, func(operationDetailID int, hostIP string, portNum int) {
+		log.Infof(message.NewMessage(msgMySQL.InfoMySQLEngineStartInstance, operationID, operationDetailID, hostIP, portNum).Error())
+	})
+}
+
+// Stop stops the mysql instances of the addrs with mysqld_multi, the instance which is not running will be skipped,
+// innodb_fast_shutdown will be set to 0 before shutting down if slowShutdown is true
+func (e *Engine) Stop(operationID int, slowShutdown bool) error {
+	return e.runInstanceOperation(operationID, stopSuccessMessage, func(hostIP string, portNum int) error {
+		return e.StopInstance(hostIP, portNum, slowShutdown)
+	}, func(operationDetailID int, hostIP string, portNum int) {
+		log.Infof(message.NewMessage(msgMySQL.InfoMySQLEngineStopInstance, operationID, operationDetailID, hostIP, portNum, slowShutdown).Error())
+	})
+}
+
+// Restart stops and then starts the mysql instances of the addrs one by one,
+// innodb_fast_shutdown will be set to 0 before shutting down if slowShutdown is true
+func (e *Engine) Restart(operationID int, slowShutdown bool) error {
+	return e.runInstanceOperation(operationID, restartSuccessMessage, func(hostIP string, portNum int) error {
+		err := e.StopInstance(hostIP, portNum, slowShutdown)
+		if err != nil {
+			return err
+		}
+
+		return e.StartInstance(hostIP, portNum)
+	}, func(operationDetailID int, hostIP string, portNum int) {
+		log.Infof(message.NewMessage(msgMySQL.InfoMySQLEngineRestartInstance, operationID, operationDetailID, hostIP, portNum, slowShutdown).Error())
+	})
+}
+
+// StartInstance starts the single instance with mysqld_multi and checks if it is running
+func (e *Engine) StartInstance(hostIP string, portNum int) error {
+	err := e.initInstanceExecutor(hostIP, portNum)
+	if err != nil {
+		return err
+	}
+	isRunning, err := e.isInstanceRunning()
+	if err != nil {
+		return err
+	}
+	if isRunning {
+		log.Warnf("mysql Engine.StartInstance(): mysqld pid found, the instance is already running. hostIP: %s, portNum: %d", hostIP, portNum)
+		return nil
+	}
+	// start mysql multi instance
+	err = e.startMultiInstance()
+	if err != nil {
+		return err
+	}
+
+	return e.checkInstanceWithPID()
+}
+
+// StopInstance stops the single instance with mysqld_multi and waits for it to shut down,
+// innodb_fast_shutdown will be set to 0 before shutting down if slowShutdown is true
+func (e *Engine) StopInstance(hostIP string, portNum int, slowShutdown bool) error {
+	err := e.initInstanceExecutor(hostIP, portNum)
+	if err != nil {
+		return err
+	}
+	isRunning, err := e.isInstanceRunning()
+	if err != nil {
+		return err
+	}
+	if !isRunning {
+		log.Warnf("mysql Engine.StopInstance(): no mysqld pid found, the instance is not running. hostIP: %s, portNum: %d", hostIP, portNum)
+		return nil
+	}
+	if slowShutdown {
+		err = e.setSlowShutdown()
+		if err != nil {
+			return err
+		}
+	}
+
+	return e.stopInstanceWithMySQLDMulti()
+}
+
+// runInstanceOperation runs the operate function on the instances of the addrs one by one and records the operation details,
+// it stops at the first failed instance
+func (e *Engine) runInstanceOperation(operationID int, successMessage string, operate func(hostIP string, portNum int) error,
+	logSuccess func(operationDetailID int, hostIP string, portNum int)) error {
+	for _, addr := range e.Addrs {
+		hostIP, portNum, err := splitAddr(addr)
+		if err != nil {
+			return err
+		}
+		// init operation detail
+		operationDetailID, err := e.dboRepo.InitOperationDetail(operationID, hostIP, portNum)
+		if err != nil {
+			return err
+		}
+		err = operate(hostIP, portNum)
+		if err != nil {
+			e.updateOperationDetail(operationID, operationDetailID, hostIP, portNum, defaultFailedStatus, err.Error())
+			return err
+		}
+
+		e.updateOperationDetail(operationID, operationDetailID, hostIP, portNum, defaultSuccessStatus, successMessage)
+		logSuccess(operationDetailID, hostIP, portNum)
+	}
+
+	return nil
+}
+
+// initInstanceExecutor resets the mysql server parameter with the host info and initializes the os executor
+func (e *Engine) initInstanceExecutor(hostIP string, portNum int) error {
+	// reset MySQL Sever Parameter
+	err := e.MySQLServer.InitWithHostInfo(hostIP, portNum, false)
+	if err != nil {
+		return err
+	}
+	// init os executor
+	return e.InitOSExecutor()
+}
+
+// isInstanceRunning returns if the mysqld process of the instance exists
+func (e *Engine) isInstanceRunning() (bool, error) {
+	pidList, err := e.ose.GetMySQLPIDList()
+	if err != nil {
+		return false, err
+	}
+
+	return len(pidList) > constant.ZeroInt, nil
+}
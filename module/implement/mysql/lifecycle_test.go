@@ -0,0 +1,40 @@
+package mysql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLifecycle_All(t *testing.T) {
+	TestEngine_StopInstance(t)
+	TestEngine_StartInstance(t)
+}
+
+func TestEngine_StopInstance(t *testing.T) {
+	asst := assert.New(t)
+
+	err := testInitInstance([]string{testAddr1})
+	asst.Nil(err, "test StopInstance() failed")
+	err = testEngine.StopInstance(testHostIP1, testPortNum1, true)
+	asst.Nil(err, "test StopInstance() failed")
+	isRunning, err := testEngine.isInstanceRunning()
+	asst.Nil(err, "test StopInstance() failed")
+	asst.False(isRunning, "test StopInstance() failed")
+	// stopping a stopped instance does nothing
+	err = testEngine.StopInstance(testHostIP1, testPortNum1, false)
+	asst.Nil(err, "test StopInstance() failed")
+}
+
+func TestEngine_StartInstance(t *testing.T) {
+	asst := assert.New(t)
+
+	err := testEngine.StartInstance(testHostIP1, testPortNum1)
+	asst.Nil(err, "test StartInstance() failed")
+	isRunning, err := testEngine.isInstanceRunning()
+	asst.Nil(err, "test StartInstance() failed")
+	asst.True(isRunning, "test StartInstance() failed")
+	// starting a running instance does nothing
+	err = testEngine.StartInstance(testHostIP1, testPortNum1)
+	asst.Nil(err, "test StartInstance() failed")
+}
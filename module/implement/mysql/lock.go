@@ -0,0 +1,67 @@
+package mysql
+
+import (
+	"context"
+	"time"
+
+	"github.com/romberli/go-util/constant"
+	"github.com/romberli/log"
+	"github.com/spf13/viper"
+
+	"github.com/romberli/db-operator/config"
+	"github.com/romberli/db-operator/global"
+)
+
+const (
+	// the lock is renewed several times within the lease timeout, so that a few failed heartbeats will not expire it
+	lockHeartbeatsPerLease = 3
+)
+
+// getLockLeaseTimeout returns the lease timeout of the operation lock
+func getLockLeaseTimeout() time.Duration {
+	return time.Duration(viper.GetInt(config.MySQLLockLeaseTimeoutKey)) * time.Second
+}
+
+// getLockExpireTime returns the time before which the operation lock is treated as expired if it has not been renewed
+func getLockExpireTime() string {
+	return time.Now().Add(-getLockLeaseTimeout()).Format(constant.TimeLayoutSecond)
+}
+
+// keepLockAlive renews the operation lock periodically in the background until the returned channel is closed,
+// it cancels the operation if the lock has been lost, which happens when the lock was expired and purged or force released,
+// it also checks if the cancellation of the operation has been requested, and cancels the operation if so
+func (s *Service) keepLockAlive(operationID int, cancel context.CancelFunc) chan struct{} {
+	stopChan := make(chan struct{})
+
+	go func() {
+		ticker := time.NewTicker(getLockLeaseTimeout() / lockHeartbeatsPerLease)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stopChan:
+				return
+			case <-ticker.C:
+				renewed, err := s.DBORepo.RenewLock(operationID)
+				if err != nil {
+					log.Errorf(constant.LogWithStackString, err)
+				} else if renewed == constant.ZeroInt {
+					log.Errorf("mysql Service.keepLockAlive(): the operation lock was lost, the operation will be cancelled. operationID: %d, owner: %s", operationID, global.Owner)
+					cancel()
+					continue
+				}
+				cancelRequested, err := s.DBORepo.IsCancelRequested(operationID)
+				if err != nil {
+					log.Errorf(constant.LogWithStackString, err)
+					continue
+				}
+				if cancelRequested {
+					log.Infof("mysql Service.keepLockAlive(): the cancellation of the operation was requested. operationID: %d", operationID)
+					cancel()
+				}
+			}
+		}
+	}()
+
+	return stopChan
+}
@@ -3,6 +3,7 @@ package mysql
 import (
 	"fmt"
 	"path/filepath"
+	"regexp"
 	"strconv"
 	"strings"
 
@@ -54,12 +55,20 @@ const (
 	createMySQLUserCommand  = "/usr/sbin/useradd -u 1001 -g mysql mysql"
 	checkPathEnvCommand     = "/usr/bin/grep PATH %s | /usr/bin/grep -c %s/bin | /usr/bin/grep -v grep"
 	addPathEnvCommand       = `/usr/bin/echo 'export PATH=\$PATH:%s/bin' >> %s`
+
+	getTotalMemoryCommand            = `/usr/bin/grep MemTotal /proc/meminfo | /usr/bin/awk -F' ' '{print \$2}'`
+	getCPUCountCommand               = "/usr/bin/nproc"
+	getDiskRotationalCommandTemplate = "/usr/bin/lsblk -ndo ROTA $(/usr/bin/df --output=source %s | /usr/bin/tail -1)"
+	diskRotationalValue              = "1"
+	kiloBytes                        = 1024
 )
 
 var (
 	minAArchMySQLVersion = version.Must(version.NewVersion(minAArchMySQLVersionStr))
 	minX64MySQLVersion   = version.Must(version.NewVersion(minX64MySQLVersionStr))
 	os9Version           = version.Must(version.NewVersion(os9VersionStr))
+
+	multiInstanceSectionRegexp = regexp.MustCompile(`^\[mysqld([0-9]+)\]$`)
 )
 
 type OSExecutor struct {
@@ -69,6 +78,8 @@ type OSExecutor struct {
 
 	arch      string
 	osVersion *version.Version
+
+	rollbackStack *RollbackStack
 }
 
 // NewOSExecutor returns a new *OSExecutor
@@ -89,8 +100,22 @@ func newOSExecutor(sshConn *ssh.Conn, mysqlVersion *version.Version, mysqlServer
 func (ose *OSExecutor) Init() error {
 	// init executor
 	err := ose.InitExecutor()
+	if err != nil {
+		return err
+	}
+	// prepare os
+	err = ose.PrepareOS()
+	if err != nil {
+		return err
+	}
+	// install mysql binary
+	return ose.InstallBinary()
+}
+
+// PrepareOS prechecks the os, installs the rpm, and initializes the user, group and directories
+func (ose *OSExecutor) PrepareOS() error {
 	// precheck
-	err = ose.Precheck()
+	err := ose.Precheck()
 	if err != nil {
 		return err
 	}
@@ -105,12 +130,13 @@ func (ose *OSExecutor) Init() error {
 		return err
 	}
 	// init dir
-	err = ose.InitDir()
-	if err != nil {
-		return err
-	}
+	return ose.InitDir()
+}
+
+// InstallBinary installs the mysql binary and configures the path environment variable
+func (ose *OSExecutor) InstallBinary() error {
 	// Install mysql binary
-	err = ose.InstallMySQLBinary()
+	err := ose.InstallMySQLBinary()
 	if err != nil {
 		return err
 	}
@@ -143,9 +169,9 @@ func (ose *OSExecutor) InitExecutor() error {
 // Precheck checks the os
 func (ose *OSExecutor) Precheck() error {
 	// check minimum version
-	if (ose.arch == constant.AArch64Arch && ose.mysqlVersion.LessThan(minAArchMySQLVersion)) ||
-		(ose.arch == constant.X64Arch && ose.mysqlVersion.LessThan(minX64MySQLVersion)) {
-		return errors.Errorf("the minimum mysql version on %s is %s, %s not valid", ose.arch, minAArchMySQLVersion.String(), ose.mysqlVersion.String())
+	err := ose.checkMySQLVersion()
+	if err != nil {
+		return err
 	}
 	// check if mysql pid exists
 	pidList, err := ose.GetMySQLPIDList()
@@ -155,16 +181,11 @@ func (ose *OSExecutor) Precheck() error {
 	if len(pidList) > constant.ZeroInt {
 		return errors.Errorf("mysql pid exists, installation aborted. pid list: %v", pidList)
 	}
-
 	// check if mysql installation package exists
-	installationPackagePath := filepath.Join(viper.GetString(config.MySQLInstallationPackageDirKey), ose.getMySQLServerBinaryPackageName())
-	exists, err := linux.PathExists(installationPackagePath)
+	err = ose.checkMySQLInstallationPackage()
 	if err != nil {
 		return err
 	}
-	if !exists {
-		return errors.Errorf("mysql installation package does not exist. installation package path: %s", installationPackagePath)
-	}
 	// check if the mysql data directory exists
 	dataDir := filepath.Join(ose.mysqlServer.DataDirBase, dataDirName)
 	output, err := ose.Conn.ListPath(dataDir)
@@ -187,6 +208,17 @@ func (ose *OSExecutor) Precheck() error {
 	return nil
 }
 
+// PrecheckUpgrade checks the os before upgrading the running mysql instance
+func (ose *OSExecutor) PrecheckUpgrade() error {
+	// check minimum version
+	err := ose.checkMySQLVersion()
+	if err != nil {
+		return err
+	}
+	// check if mysql installation package exists
+	return ose.checkMySQLInstallationPackage()
+}
+
 // GetMySQLPIDList gets the mysql pid list
 func (ose *OSExecutor) GetMySQLPIDList() ([]int, error) {
 	cmd := fmt.Sprintf(getMySQLPIDListCommandTemplate, ose.mysqlServer.PortNum, ose.mysqlServer.DataDirBase)
@@ -212,6 +244,82 @@ func (ose *OSExecutor) GetMySQLPIDList() ([]int, error) {
 	return pidList, nil
 }
 
+// GetHostFacts gets the total memory, cpu count and disk type of the host,
+// and the number of the other instances which are defined in the config file of the host or use the given port numbers
+func (ose *OSExecutor) GetHostFacts(portNums []int) (*HostFacts, error) {
+	// total memory
+	output, err := ose.Conn.ExecuteCommand(getTotalMemoryCommand)
+	if err != nil {
+		return nil, err
+	}
+	totalMemory, err := strconv.ParseInt(strings.TrimSpace(output), 10, 64)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// cpu count
+	output, err = ose.Conn.ExecuteCommand(getCPUCountCommand)
+	if err != nil {
+		return nil, err
+	}
+	cpuCount, err := strconv.Atoi(strings.TrimSpace(output))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// disk type of the data directory
+	output, err = ose.Conn.ExecuteCommand(fmt.Sprintf(getDiskRotationalCommandTemplate, ose.mysqlServer.DataDirBase))
+	if err != nil {
+		return nil, err
+	}
+	rotational := strings.TrimSpace(output) == diskRotationalValue
+	// other instances
+	instanceCount, err := ose.getOtherInstanceCount(portNums)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewHostFacts(totalMemory*kiloBytes, cpuCount, rotational, instanceCount), nil
+}
+
+// getOtherInstanceCount returns the number of the instances which are defined in the config file or use the given port numbers,
+// the current one is not counted
+func (ose *OSExecutor) getOtherInstanceCount(portNums []int) (int, error) {
+	exists, err := ose.Conn.PathExists(defaultConfigFileName)
+	if err != nil {
+		return constant.ZeroInt, err
+	}
+	var content string
+	if exists {
+		content, err = ose.Conn.Cat(defaultConfigFileName)
+		if err != nil {
+			return constant.ZeroInt, err
+		}
+	}
+
+	return countOtherInstances(content, ose.mysqlServer.PortNum, portNums), nil
+}
+
+// countOtherInstances returns the number of the distinct instances which are defined in the config content or use the given port numbers,
+// the instance of the current port number is not counted
+func countOtherInstances(content string, currentPortNum int, portNums []int) int {
+	instances := make(map[int]bool)
+	for _, portNum := range portNums {
+		instances[portNum] = true
+	}
+	for _, line := range strings.Split(content, constant.CRLFString) {
+		matches := multiInstanceSectionRegexp.FindStringSubmatch(strings.TrimSpace(line))
+		if matches == nil {
+			continue
+		}
+		portNum, err := strconv.Atoi(matches[constant.OneInt])
+		if err == nil {
+			instances[portNum] = true
+		}
+	}
+	delete(instances, currentPortNum)
+
+	return len(instances)
+}
+
 // InstallRPM installs the rpm
 func (ose *OSExecutor) InstallRPM() error {
 	err := ose.Conn.ExecuteCommandWithoutOutput(yumInstallCommand)
@@ -269,9 +377,14 @@ func (ose *OSExecutor) InitUserAndGroup() error {
 
 // InitDir initializes the directory
 func (ose *OSExecutor) InitDir() error {
-	// create directories
 	binaryDirParent := filepath.Dir(ose.mysqlServer.BinaryDirBase)
-	err := ose.Conn.MkdirAll(binaryDirParent)
+	// record the directories which do not exist yet, so that they could be removed if the installation fails
+	err := ose.recordDirsToCreate(binaryDirParent, ose.mysqlServer.BackupDir, ose.mysqlServer.DataDirBase, ose.mysqlServer.LogDirBase)
+	if err != nil {
+		return err
+	}
+	// create directories
+	err = ose.Conn.MkdirAll(binaryDirParent)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -331,6 +444,9 @@ func (ose *OSExecutor) InstallMySQLBinary() error {
 		// mysql binary directory exists, maybe just want to add new instance
 		return nil
 	}
+	ose.rollbackStack.Push("remove mysql binary directory", func() error {
+		return ose.Conn.RemoveAll(ose.mysqlServer.BinaryDirBase)
+	})
 	// copy mysql installation package
 	err = ose.copyMySQLServerBinaryPackages()
 	if err != nil {
@@ -382,6 +498,56 @@ func (ose *OSExecutor) ConfigurePathEnv() error {
 	return nil
 }
 
+// checkMySQLVersion checks if the mysql version is supported on the arch of the host
+func (ose *OSExecutor) checkMySQLVersion() error {
+	if (ose.arch == constant.AArch64Arch && ose.mysqlVersion.LessThan(minAArchMySQLVersion)) ||
+		(ose.arch == constant.X64Arch && ose.mysqlVersion.LessThan(minX64MySQLVersion)) {
+		return errors.Errorf("the minimum mysql version on %s is %s, %s not valid", ose.arch, minAArchMySQLVersion.String(), ose.mysqlVersion.String())
+	}
+
+	return nil
+}
+
+// checkMySQLInstallationPackage checks if the mysql installation package exists
+func (ose *OSExecutor) checkMySQLInstallationPackage() error {
+	installationPackagePath := ose.getMySQLInstallationPackagePath()
+	exists, err := linux.PathExists(installationPackagePath)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return errors.Errorf("mysql installation package does not exist. installation package path: %s", installationPackagePath)
+	}
+
+	return nil
+}
+
+// recordDirsToCreate pushes the compensating actions of removing the given directories which do not exist yet to the rollback stack
+func (ose *OSExecutor) recordDirsToCreate(dirs ...string) error {
+	recorded := make(map[string]bool, len(dirs))
+	for _, dir := range dirs {
+		if recorded[dir] {
+			continue
+		}
+		recorded[dir] = true
+
+		exists, err := ose.Conn.PathExists(dir)
+		if err != nil {
+			return err
+		}
+		if exists {
+			continue
+		}
+
+		dirToRemove := dir
+		ose.rollbackStack.Push(fmt.Sprintf("remove directory %s", dirToRemove), func() error {
+			return ose.Conn.RemoveAll(dirToRemove)
+		})
+	}
+
+	return nil
+}
+
 // copyMySQLServerBinaryPackages copies the mysql server binary packages to the remote host
 func (ose *OSExecutor) copyMySQLServerBinaryPackages() error {
 	fileName := ose.getMySQLServerBinaryPackageName()
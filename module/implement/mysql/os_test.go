@@ -107,6 +107,7 @@ func TestOSExecutor_All(t *testing.T) {
 	TestOSExecutor_InitUserAndGroup(t)
 	TestOSExecutor_InitDir(t)
 	TestOSExecutor_InstallMySQLBinary(t)
+	TestCountOtherInstances(t)
 }
 
 func TestOSExecutor_Precheck(t *testing.T) {
@@ -202,3 +203,14 @@ func TestOSExecutor_Init(t *testing.T) {
 	asst.Nil(err, "test InstallMySQLBinary() failed")
 	asst.True(pathExists, "test InstallMySQLBinary() failed")
 }
+
+func TestCountOtherInstances(t *testing.T) {
+	asst := assert.New(t)
+
+	content := "[mysqld3306]\nbasedir=/data/mysql/mysql8.0.32\n\n[mysqld3307]\nbasedir=/data/mysql/mysql8.0.32\n"
+	// the current instance is not counted
+	asst.Equal(1, countOtherInstances(content, 3306, nil), "test countOtherInstances() failed")
+	// the instances of the same request which are not in the config file yet are counted
+	asst.Equal(3, countOtherInstances(content, 3306, []int{3306, 3307, 3308, 3309}), "test countOtherInstances() failed")
+	asst.Equal(2, countOtherInstances(constant.EmptyString, 3306, []int{3306, 3307, 3308}), "test countOtherInstances() failed")
+}
@@ -25,6 +25,8 @@ const (
 	testMonitorPass     = "pmm"
 	testDASUser         = "das"
 	testDASPass         = "das"
+	testCloneUser       = "clone"
+	testClonePass       = "clone"
 
 	testVersion                         = "8.0.32"
 	testBinaryDirBase                   = "/data/mysql/mysql8.0.32"
@@ -34,6 +36,8 @@ const (
 	testGroupReplicationConsistency     = "eventual"
 	testGroupReplicationFlowControlMode = "disabled"
 	testGroupReplicationMemberWeight    = 50
+	testGroupReplicationGroupName       = "b7ef7f4c-6a66-4bb4-9d6f-c2b5a3b0a3a1"
+	testGroupReplicationGroupSeeds      = "192.168.137.21:33061,192.168.137.21:33071"
 	testServerID                        = 3306137011
 	testBinlogExpireLogsSeconds         = 604800
 	testBinlogExpireLogsDays            = 7
@@ -31,6 +31,10 @@ const (
 	DefaultGroupReplicationConsistency     = "eventual"
 	DefaultGroupReplicationFlowControlMode = "disabled"
 	DefaultGroupReplicationMemberWeight    = 50
+	// the group communication port of the instance is the port of the instance plus the offset,
+	// so that the instances on the same host will not conflict with each other
+	GroupReplicationPortOffset = 10000
+	maxPortNum                 = 65535
 
 	DefaultBinaryDirBaseTemplate   = "/data/mysql/mysql%s"
 	DefaultBinlogExpireLogsSeconds = 604800
@@ -39,54 +43,64 @@ const (
 	DefaultMaxConnections          = 2000
 	DefaultInnodbIOCapacity        = 1000
 	DefaultInnodbIOCapacityMax     = 2000
+	DefaultInnodbIOThreads         = 16
+	DefaultReplicaParallelWorkers  = 16
 	DefaultServerIDTemplate        = "%d%03s%03s"
 
 	initUserScriptTemplateName = "InitUserScript"
 )
 
 type MySQLServer struct {
-	Version                         string `json:"version" config:"version"`
-	HostIP                          string `json:"host_ip" config:"host_ip"`
-	PortNum                         int    `json:"port_num" config:"port_num"`
-	RootPass                        string `json:"root_pass" config:"root_pass"`
-	AdminUser                       string `json:"admin_user" config:"admin_user"`
-	AdminPass                       string `json:"admin_pass" config:"admin_pass"`
-	ClientUser                      string `json:"client_user" config:"client_user"`
-	ClientPass                      string `json:"client_pass" config:"client_pass"`
-	MySQLDMultiUser                 string `json:"mysqld_multi_user" config:"mysqld_multi_user"`
-	MySQLDMultiPass                 string `json:"mysqld_multi_pass" config:"mysqld_multi_pass"`
-	ReplicationUser                 string `json:"replication_user" config:"replication_user"`
-	ReplicationPass                 string `json:"replication_pass" config:"replication_pass"`
-	MonitorUser                     string `json:"monitor_user" config:"monitor_user"`
-	MonitorPass                     string `json:"monitor_pass" config:"monitor_pass"`
-	DASUser                         string `json:"das_user" config:"das_user"`
-	DASPass                         string `json:"das_pass" config:"das_pass"`
-	Title                           string `json:"title" config:"title"`
-	BinaryDirBase                   string `json:"binary_dir_base" config:"binary_dir_base"`
-	DataDirBaseName                 string `json:"data_dir_base_name" config:"data_dir_base_name"`
-	DataDirBase                     string `json:"data_dir_base" config:"data_dir_base"`
-	LogDirBaseName                  string `json:"log_dir_base_name" config:"log_dir_base_name"`
-	LogDirBase                      string `json:"log_dir_base" config:"log_dir_base"`
-	SemiSyncSourceEnabled           int    `json:"semi_sync_source_enabled" config:"semi_sync_source_enabled"`
-	SemiSyncReplicaEnabled          int    `json:"semi_sync_replica_enabled" config:"semi_sync_replica_enabled"`
-	SemiSyncSourceTimeout           int    `json:"semi_sync_source_timeout" config:"semi_sync_source_timeout"`
-	GroupReplicationConsistency     string `json:"group_replication_consistency" config:"group_replication_consistency"`
-	GroupReplicationFlowControlMode string `json:"group_replication_flow_control_mode" config:"group_replication_flow_control_mode"`
-	GroupReplicationMemberWeight    int    `json:"group_replication_member_weight" config:"group_replication_member_weight"`
-	ServerID                        int    `json:"server_id" config:"server_id"`
-	BinlogExpireLogsSeconds         int    `json:"binlog_expire_logs_seconds" config:"binlog_expire_logs_seconds"`
-	BinlogExpireLogsDays            int    `json:"binlog_expire_logs_days" config:"binlog_expire_logs_days"`
-	BackupDir                       string `json:"backup_dir" config:"backup_dir"`
-	MaxConnections                  int    `json:"max_connections" config:"max_connections"`
-	InnodbBufferPoolSize            string `json:"innodb_buffer_pool_size" config:"innodb_buffer_pool_size"`
-	InnodbIOCapacity                int    `json:"innodb_io_capacity" config:"innodb_io_capacity"`
-	InnodbIOCapacityMax             int    `json:"innodb_io_capacity_max" config:"innodb_io_capacity_max"`
+	Version                         string            `json:"version" config:"version"`
+	HostIP                          string            `json:"host_ip" config:"host_ip"`
+	PortNum                         int               `json:"port_num" config:"port_num"`
+	RootPass                        string            `json:"root_pass" config:"root_pass"`
+	AdminUser                       string            `json:"admin_user" config:"admin_user"`
+	AdminPass                       string            `json:"admin_pass" config:"admin_pass"`
+	ClientUser                      string            `json:"client_user" config:"client_user"`
+	ClientPass                      string            `json:"client_pass" config:"client_pass"`
+	MySQLDMultiUser                 string            `json:"mysqld_multi_user" config:"mysqld_multi_user"`
+	MySQLDMultiPass                 string            `json:"mysqld_multi_pass" config:"mysqld_multi_pass"`
+	ReplicationUser                 string            `json:"replication_user" config:"replication_user"`
+	ReplicationPass                 string            `json:"replication_pass" config:"replication_pass"`
+	MonitorUser                     string            `json:"monitor_user" config:"monitor_user"`
+	MonitorPass                     string            `json:"monitor_pass" config:"monitor_pass"`
+	DASUser                         string            `json:"das_user" config:"das_user"`
+	DASPass                         string            `json:"das_pass" config:"das_pass"`
+	CloneUser                       string            `json:"clone_user" config:"clone_user"`
+	ClonePass                       string            `json:"clone_pass" config:"clone_pass"`
+	Title                           string            `json:"title" config:"title"`
+	BinaryDirBase                   string            `json:"binary_dir_base" config:"binary_dir_base"`
+	DataDirBaseName                 string            `json:"data_dir_base_name" config:"data_dir_base_name"`
+	DataDirBase                     string            `json:"data_dir_base" config:"data_dir_base"`
+	LogDirBaseName                  string            `json:"log_dir_base_name" config:"log_dir_base_name"`
+	LogDirBase                      string            `json:"log_dir_base" config:"log_dir_base"`
+	SemiSyncSourceEnabled           int               `json:"semi_sync_source_enabled" config:"semi_sync_source_enabled"`
+	SemiSyncReplicaEnabled          int               `json:"semi_sync_replica_enabled" config:"semi_sync_replica_enabled"`
+	SemiSyncSourceTimeout           int               `json:"semi_sync_source_timeout" config:"semi_sync_source_timeout"`
+	GroupReplicationConsistency     string            `json:"group_replication_consistency" config:"group_replication_consistency"`
+	GroupReplicationFlowControlMode string            `json:"group_replication_flow_control_mode" config:"group_replication_flow_control_mode"`
+	GroupReplicationMemberWeight    int               `json:"group_replication_member_weight" config:"group_replication_member_weight"`
+	GroupReplicationGroupName       string            `json:"group_replication_group_name" config:"group_replication_group_name"`
+	GroupReplicationGroupSeeds      string            `json:"group_replication_group_seeds" config:"group_replication_group_seeds"`
+	ServerID                        int               `json:"server_id" config:"server_id"`
+	BinlogExpireLogsSeconds         int               `json:"binlog_expire_logs_seconds" config:"binlog_expire_logs_seconds"`
+	BinlogExpireLogsDays            int               `json:"binlog_expire_logs_days" config:"binlog_expire_logs_days"`
+	BackupDir                       string            `json:"backup_dir" config:"backup_dir"`
+	MaxConnections                  int               `json:"max_connections" config:"max_connections"`
+	InnodbBufferPoolSize            string            `json:"innodb_buffer_pool_size" config:"innodb_buffer_pool_size"`
+	InnodbIOCapacity                int               `json:"innodb_io_capacity" config:"innodb_io_capacity"`
+	InnodbIOCapacityMax             int               `json:"innodb_io_capacity_max" config:"innodb_io_capacity_max"`
+	InnodbReadIOThreads             int               `json:"innodb_read_io_threads" config:"innodb_read_io_threads"`
+	InnodbWriteIOThreads            int               `json:"innodb_write_io_threads" config:"innodb_write_io_threads"`
+	ReplicaParallelWorkers          int               `json:"replica_parallel_workers" config:"replica_parallel_workers"`
+	Parameters                      map[string]string `json:"parameters" config:"parameters"`
 }
 
 // NewMySQLServer returns a new *MySQLServer
 func NewMySQLServer(version, hostIP string, portNum int, rootPass, adminUser, adminPass, clientUser, clientPass,
 	mysqldMultiUser, mysqldMultiPass, replicationUser, replicationPass, monitorUser, monitorPass, dasUser, dasPaas,
-	title, binaryDirBase, dataDirBaseName, logDirBaseName string,
+	cloneUser, clonePass, title, binaryDirBase, dataDirBaseName, logDirBaseName string,
 	semiSyncSourceEnabled, semiSyncReplicaEnabled, semiSyncSourceTimeout int,
 	groupReplicationConsistency, groupReplicationFlowControlMode string, groupReplicationMemberWeight, serverID,
 	binlogExpireLogsSeconds, binlogExpireLogsDays int, backupDir string,
@@ -108,6 +122,8 @@ func NewMySQLServer(version, hostIP string, portNum int, rootPass, adminUser, ad
 		monitorPass,
 		dasUser,
 		dasPaas,
+		cloneUser,
+		clonePass,
 		title,
 		binaryDirBase,
 		dataDirBaseName,
@@ -148,6 +164,8 @@ func NewMySQLServerWithDefault() *MySQLServer {
 		viper.GetString(config.MySQLUserMonitorPassKey),
 		viper.GetString(config.MySQLUserDASUserKey),
 		viper.GetString(config.MySQLUserDASPassKey),
+		viper.GetString(config.MySQLUserCloneUserKey),
+		viper.GetString(config.MySQLUserClonePassKey),
 		defaultTitle,
 		fmt.Sprintf(DefaultBinaryDirBaseTemplate, viper.GetString(config.MySQLVersionKey)),
 		DefaultDataDirBaseName,
@@ -172,7 +190,7 @@ func NewMySQLServerWithDefault() *MySQLServer {
 // newMySQLServer returns a new *MySQLServer
 func newMySQLServer(version, hostIP string, portNum int, rootPass, adminUser, adminPass, clientUser, clientPass,
 	mysqldMultiUser, mysqldMultiPass, replicationUser, replicationPass, monitorUser, monitorPass, dasUser, dasPaas,
-	title, binaryDirBase, dataDirBaseName, logDirBaseName string,
+	cloneUser, clonePass, title, binaryDirBase, dataDirBaseName, logDirBaseName string,
 	semiSyncSourceEnabled, semiSyncReplicaEnabled, semiSyncSourceTimeout int,
 	groupReplicationConsistency, groupReplicationFlowControlMode string,
 	groupReplicationMemberWeight, serverID, binlogExpireLogsSeconds, binlogExpireLogsDays int, backupDir string,
@@ -194,6 +212,8 @@ func newMySQLServer(version, hostIP string, portNum int, rootPass, adminUser, ad
 		MonitorPass:                     monitorPass,
 		DASUser:                         dasUser,
 		DASPass:                         dasPaas,
+		CloneUser:                       cloneUser,
+		ClonePass:                       clonePass,
 		Title:                           title,
 		BinaryDirBase:                   binaryDirBase,
 		DataDirBaseName:                 dataDirBaseName,
@@ -214,6 +234,9 @@ func newMySQLServer(version, hostIP string, portNum int, rootPass, adminUser, ad
 		InnodbBufferPoolSize:            innodbBufferPoolSize,
 		InnodbIOCapacity:                innodbIOCapacity,
 		InnodbIOCapacityMax:             innodbIOCapacityMax,
+		InnodbReadIOThreads:             DefaultInnodbIOThreads,
+		InnodbWriteIOThreads:            DefaultInnodbIOThreads,
+		ReplicaParallelWorkers:          DefaultReplicaParallelWorkers,
 	}
 }
 
@@ -231,7 +254,7 @@ func (ms *MySQLServer) GetCommon() *Common {
 
 // GetMySQLD() gets the MySQLD of MySQLServer
 func (ms *MySQLServer) GetMySQLD() *MySQLD {
-	return NewMySQLD(
+	md := NewMySQLD(
 		ms.Version,
 		ms.HostIP,
 		ms.PortNum,
@@ -252,6 +275,13 @@ func (ms *MySQLServer) GetMySQLD() *MySQLD {
 		ms.InnodbBufferPoolSize,
 		ms.InnodbIOCapacity,
 	)
+	md.SetGroupReplicationGroupName(ms.GroupReplicationGroupName)
+	md.SetGroupReplicationGroupSeeds(ms.GroupReplicationGroupSeeds)
+	md.SetIOThreads(ms.InnodbReadIOThreads, ms.InnodbWriteIOThreads)
+	md.SetReplicaParallelWorkers(ms.ReplicaParallelWorkers)
+	md.SetParameters(ms.Parameters)
+
+	return md
 }
 
 // SetSemiSyncSourceEnabled sets the semi-sync source enabled
@@ -264,6 +294,58 @@ func (ms *MySQLServer) SetSemiSyncReplicaEnabled(semiSyncReplicaEnabled int) {
 	ms.SemiSyncReplicaEnabled = semiSyncReplicaEnabled
 }
 
+// SetGroupReplicationGroupName sets the group replication group name
+func (ms *MySQLServer) SetGroupReplicationGroupName(groupName string) {
+	ms.GroupReplicationGroupName = groupName
+}
+
+// SetGroupReplicationGroupSeeds sets the group replication group seeds
+func (ms *MySQLServer) SetGroupReplicationGroupSeeds(groupSeeds string) {
+	ms.GroupReplicationGroupSeeds = groupSeeds
+}
+
+// SetResourceSizing sets the options which are derived from the hardware of the host
+func (ms *MySQLServer) SetResourceSizing(innodbBufferPoolSize string, innodbIOCapacity, innodbIOThreads, replicaParallelWorkers int) {
+	ms.InnodbBufferPoolSize = innodbBufferPoolSize
+	ms.InnodbIOCapacity = innodbIOCapacity
+	ms.InnodbIOCapacityMax = innodbIOCapacity * 2
+	ms.InnodbReadIOThreads = innodbIOThreads
+	ms.InnodbWriteIOThreads = innodbIOThreads
+	ms.ReplicaParallelWorkers = replicaParallelWorkers
+}
+
+// SetParameters sets the parameters which will be merged into the rendered mysqld section,
+// they are usually resolved from the parameter profile and the extra parameters of the install request
+func (ms *MySQLServer) SetParameters(parameters map[string]string) {
+	ms.Parameters = parameters
+}
+
+// GetGroupReplicationPort returns the group communication port of the instance of the given port,
+// it returns an error if the port exceeds the maximum port number
+func GetGroupReplicationPort(portNum int) (int, error) {
+	groupReplicationPort := portNum + GroupReplicationPortOffset
+	if groupReplicationPort > maxPortNum {
+		return constant.ZeroInt, errors.Errorf("group replication port of the instance exceeds the maximum port number, "+
+			"the port of the instance must not be greater than %d. portNum: %d, groupReplicationPort: %d",
+			maxPortNum-GroupReplicationPortOffset, portNum, groupReplicationPort)
+	}
+
+	return groupReplicationPort, nil
+}
+
+// Clone returns a copy of the MySQLServer, modifying the copy will not affect the original one
+func (ms *MySQLServer) Clone() *MySQLServer {
+	clone := *ms
+	if ms.Parameters != nil {
+		clone.Parameters = make(map[string]string, len(ms.Parameters))
+		for name, value := range ms.Parameters {
+			clone.Parameters[name] = value
+		}
+	}
+
+	return &clone
+}
+
 // SetVersion sets the version, it also sets the binary dir base
 func (ms *MySQLServer) SetVersion(version string) {
 	ms.Version = version
@@ -281,6 +363,9 @@ func (ms *MySQLServer) InitWithHostInfo(hostIP string, portNum int, isSource boo
 	if isSource {
 		ms.SetSemiSyncSourceEnabled(DefaultSemiSyncEnabled)
 		ms.SetSemiSyncReplicaEnabled(DefaultSemiSyncDisabled)
+	} else {
+		ms.SetSemiSyncSourceEnabled(DefaultSemiSyncDisabled)
+		ms.SetSemiSyncReplicaEnabled(DefaultSemiSyncEnabled)
 	}
 
 	ipList := strings.Split(hostIP, constant.DotString)
@@ -38,6 +38,8 @@ func initTestMySQLServer() {
 		testMonitorPass,
 		testDASUser,
 		testDASPass,
+		testCloneUser,
+		testClonePass,
 		defaultTitle,
 		testBinaryDirBase,
 		testDataDirBaseName,
@@ -64,6 +66,8 @@ func TestMySQLServer_All(t *testing.T) {
 	TestMySQLServer_WriteConfig(t)
 	TestMySQLServer_Marshal(t)
 	TestMySQLServer_Unmarshal(t)
+	TestMySQLServer_Clone(t)
+	TestGetGroupReplicationPort(t)
 }
 
 func TestMySQLServer_GetConfig(t *testing.T) {
@@ -100,3 +104,33 @@ func TestMySQLServer_Unmarshal(t *testing.T) {
 	asst.Nil(err, common.CombineMessageWithError("test Unmarshal() failed", err))
 	t.Logf("host_ip: %s, port_num: %d, server_id: %d", testMySQLServer.HostIP, testMySQLServer.PortNum, testMySQLServer.ServerID)
 }
+
+func TestMySQLServer_Clone(t *testing.T) {
+	asst := assert.New(t)
+
+	hostIP := testMySQLServer.HostIP
+	portNum := testMySQLServer.PortNum
+	if testMySQLServer.Parameters == nil {
+		testMySQLServer.Parameters = make(map[string]string)
+	}
+	clone := testMySQLServer.Clone()
+	asst.Equal(testMySQLServer, clone, "test Clone() failed")
+	err := clone.InitWithHostInfo("192.168.137.22", portNum+1, false)
+	asst.Nil(err, common.CombineMessageWithError("test Clone() failed", err))
+	asst.Equal(hostIP, testMySQLServer.HostIP, "test Clone() failed")
+	asst.Equal(portNum, testMySQLServer.PortNum, "test Clone() failed")
+	// the parameters are not shared with the original one
+	clone.Parameters["test_clone_parameter"] = "1"
+	_, ok := testMySQLServer.Parameters["test_clone_parameter"]
+	asst.False(ok, "test Clone() failed")
+}
+
+func TestGetGroupReplicationPort(t *testing.T) {
+	asst := assert.New(t)
+
+	groupReplicationPort, err := GetGroupReplicationPort(3306)
+	asst.Nil(err, common.CombineMessageWithError("test GetGroupReplicationPort() failed", err))
+	asst.Equal(3306+GroupReplicationPortOffset, groupReplicationPort, "test GetGroupReplicationPort() failed")
+	_, err = GetGroupReplicationPort(maxPortNum - GroupReplicationPortOffset + 1)
+	asst.NotNil(err, "test GetGroupReplicationPort() failed")
+}
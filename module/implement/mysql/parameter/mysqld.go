@@ -9,39 +9,109 @@ import (
 	"github.com/romberli/db-operator/module/implement/mysql/mode"
 	"github.com/romberli/db-operator/module/implement/mysql/parameter/tmpl"
 	"github.com/romberli/db-operator/pkg/util/mysql"
+	"github.com/romberli/go-util/constant"
 )
 
 const (
+	mysql57              = "5.7"
 	mysql80              = "8.0"
+	mysql81              = "8.1"
+	mysql84              = "8.4"
+	mysql90              = "9.0"
+	mysql100             = "10.0"
+	mysqld57TemplateName = "mysqld57"
 	mysqld80TemplateName = "mysqld80"
+	mysqld84TemplateName = "mysqld84"
+	mysqld9TemplateName  = "mysqld9"
 
 	defaultTitle = "mysqld"
+
+	semiSyncPluginLoad                = `plugin_load_add="rpl_semi_sync_source=semisync_source.so;rpl_semi_sync_replica=semisync_replica.so"`
+	semiSyncPluginLoadPrefix          = `plugin_load_add="rpl_semi_sync`
+	semiSyncPluginLoadCommentPrefix   = "#" + semiSyncPluginLoadPrefix
+	groupReplicationPluginLoad        = "plugin_load_add='group_replication.so'"
+	groupReplicationPluginLoadComment = "#" + groupReplicationPluginLoad
+	// the semisync_source and semisync_replica plugins are available since 8.0.26,
+	// the older 8.0 versions must load the semisync_master and semisync_slave plugins
+	minSemiSyncSourceVersion = "8.0.26"
+)
+
+// semiSyncMasterReplacer replaces the names of the semi-sync plugins and variables of the 8.0 template with the old names
+var semiSyncMasterReplacer = strings.NewReplacer(
+	"semisync_source.so", "semisync_master.so",
+	"semisync_replica.so", "semisync_slave.so",
+	"rpl_semi_sync_source", "rpl_semi_sync_master",
+	"rpl_semi_sync_replica", "rpl_semi_sync_slave",
+	"wait_for_replica_count", "wait_for_slave_count",
+	"wait_no_replica", "wait_no_slave",
 )
 
+// mysqldTemplate is the template of the mysqld section of the versions in [minVersion, maxVersion)
+type mysqldTemplate struct {
+	name       string
+	minVersion string
+	maxVersion string
+	content    string
+}
+
+// mysqldTemplates is the registry of the mysqld section templates, the version which is not in any range is not supported
+var mysqldTemplates = []*mysqldTemplate{
+	{name: mysqld57TemplateName, minVersion: mysql57, maxVersion: mysql80, content: tmpl.MySQLD57},
+	{name: mysqld80TemplateName, minVersion: mysql80, maxVersion: mysql81, content: tmpl.MySQLD80},
+	{name: mysqld84TemplateName, minVersion: mysql84, maxVersion: mysql90, content: tmpl.MySQLD84},
+	{name: mysqld9TemplateName, minVersion: mysql90, maxVersion: mysql100, content: tmpl.MySQLD9},
+}
+
+// getMySQLDTemplate returns the template of the mysqld section of the given version
+func getMySQLDTemplate(v *version.Version) (*mysqldTemplate, error) {
+	for _, t := range mysqldTemplates {
+		minVersion, err := version.NewVersion(t.minVersion)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		maxVersion, err := version.NewVersion(t.maxVersion)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if v.GreaterThanOrEqual(minVersion) && v.LessThan(maxVersion) {
+			return t, nil
+		}
+	}
+
+	return nil, errors.Errorf("mysql version %s is not supported, supported versions are 5.7.x, 8.0.x, 8.4.x and 9.x", v.String())
+}
+
 type MySQLD struct {
-	Version                         string `json:"version" config:"version"`
-	Title                           string `json:"title" config:"title"`
-	HostIP                          string `json:"host_ip" config:"host_ip"`
-	PortNum                         int    `json:"port_num" config:"port_num"`
-	BinaryDirBase                   string `json:"binary_dir_base" config:"binary_dir_base"`
-	DataDirBaseName                 string `json:"data_dir_base_name" config:"data_dir_base_name"`
-	DataDirBase                     string `json:"data_dir_base" config:"data_dir_base"`
-	LogDirBaseName                  string `json:"log_dir_base_name" config:"log_dir_base_name"`
-	LogDirBase                      string `json:"log_dir_base" config:"log_dir_base"`
-	SemiSyncSourceEnabled           int    `json:"semi_sync_source_enabled" config:"semi_sync_source_enabled"`
-	SemiSyncReplicaEnabled          int    `json:"semi_sync_replica_enabled" config:"semi_sync_replica_enabled"`
-	SemiSyncSourceTimeout           int    `json:"semi_sync_source_timeout" config:"semi_sync_source_timeout"`
-	GroupReplicationConsistency     string `json:"group_replication_consistency" config:"group_replication_consistency"`
-	GroupReplicationFlowControlMode string `json:"group_replication_flow_control_mode" config:"group_replication_flow_control_mode"`
-	GroupReplicationMemberWeight    int    `json:"group_replication_member_weight" config:"group_replication_member_weight"`
-	ServerID                        int    `json:"server_id" config:"server_id"`
-	BinlogExpireLogsSeconds         int    `json:"binlog_expire_logs_seconds" config:"binlog_expire_logs_seconds"`
-	BinlogExpireLogsDays            int    `json:"binlog_expire_logs_days" config:"binlog_expire_logs_days"`
-	BackupDir                       string `json:"backup_dir" config:"backup_dir"`
-	MaxConnections                  int    `json:"max_connections" config:"max_connections"`
-	InnodbBufferPoolSize            string `json:"innodb_buffer_pool_size" config:"innodb_buffer_pool_size"`
-	InnodbIOCapacity                int    `json:"innodb_io_capacity" config:"innodb_io_capacity"`
-	InnodbIOCapacityMax             int    `json:"innodb_io_capacity_max" config:"innodb_io_capacity_max"`
+	Version                         string            `json:"version" config:"version"`
+	Title                           string            `json:"title" config:"title"`
+	HostIP                          string            `json:"host_ip" config:"host_ip"`
+	PortNum                         int               `json:"port_num" config:"port_num"`
+	BinaryDirBase                   string            `json:"binary_dir_base" config:"binary_dir_base"`
+	DataDirBaseName                 string            `json:"data_dir_base_name" config:"data_dir_base_name"`
+	DataDirBase                     string            `json:"data_dir_base" config:"data_dir_base"`
+	LogDirBaseName                  string            `json:"log_dir_base_name" config:"log_dir_base_name"`
+	LogDirBase                      string            `json:"log_dir_base" config:"log_dir_base"`
+	SemiSyncSourceEnabled           int               `json:"semi_sync_source_enabled" config:"semi_sync_source_enabled"`
+	SemiSyncReplicaEnabled          int               `json:"semi_sync_replica_enabled" config:"semi_sync_replica_enabled"`
+	SemiSyncSourceTimeout           int               `json:"semi_sync_source_timeout" config:"semi_sync_source_timeout"`
+	GroupReplicationConsistency     string            `json:"group_replication_consistency" config:"group_replication_consistency"`
+	GroupReplicationFlowControlMode string            `json:"group_replication_flow_control_mode" config:"group_replication_flow_control_mode"`
+	GroupReplicationMemberWeight    int               `json:"group_replication_member_weight" config:"group_replication_member_weight"`
+	GroupReplicationGroupName       string            `json:"group_replication_group_name" config:"group_replication_group_name"`
+	GroupReplicationGroupSeeds      string            `json:"group_replication_group_seeds" config:"group_replication_group_seeds"`
+	GroupReplicationPort            int               `json:"group_replication_port" config:"group_replication_port"`
+	ServerID                        int               `json:"server_id" config:"server_id"`
+	BinlogExpireLogsSeconds         int               `json:"binlog_expire_logs_seconds" config:"binlog_expire_logs_seconds"`
+	BinlogExpireLogsDays            int               `json:"binlog_expire_logs_days" config:"binlog_expire_logs_days"`
+	BackupDir                       string            `json:"backup_dir" config:"backup_dir"`
+	MaxConnections                  int               `json:"max_connections" config:"max_connections"`
+	InnodbBufferPoolSize            string            `json:"innodb_buffer_pool_size" config:"innodb_buffer_pool_size"`
+	InnodbIOCapacity                int               `json:"innodb_io_capacity" config:"innodb_io_capacity"`
+	InnodbIOCapacityMax             int               `json:"innodb_io_capacity_max" config:"innodb_io_capacity_max"`
+	InnodbReadIOThreads             int               `json:"innodb_read_io_threads" config:"innodb_read_io_threads"`
+	InnodbWriteIOThreads            int               `json:"innodb_write_io_threads" config:"innodb_write_io_threads"`
+	ReplicaParallelWorkers          int               `json:"replica_parallel_workers" config:"replica_parallel_workers"`
+	Parameters                      map[string]string `json:"parameters" config:"parameters"`
 }
 
 // NewMySQLD returns a new *MySQLD
@@ -66,6 +136,7 @@ func NewMySQLD(version, hostIP string, portNum int, binaryDirBase, dataDirBaseNa
 		GroupReplicationConsistency:     groupReplicationConsistency,
 		GroupReplicationFlowControlMode: groupReplicationFlowControlMode,
 		GroupReplicationMemberWeight:    groupReplicationMemberWeight,
+		GroupReplicationPort:            portNum + GroupReplicationPortOffset,
 		ServerID:                        serverID,
 		BinlogExpireLogsSeconds:         binlogExpireLogsSeconds,
 		BinlogExpireLogsDays:            binlogExpireLogsDays,
@@ -74,6 +145,9 @@ func NewMySQLD(version, hostIP string, portNum int, binaryDirBase, dataDirBaseNa
 		InnodbBufferPoolSize:            innodbBufferPoolSize,
 		InnodbIOCapacity:                innodbIOCapacity,
 		InnodbIOCapacityMax:             innodbIOCapacity * 2,
+		InnodbReadIOThreads:             DefaultInnodbIOThreads,
+		InnodbWriteIOThreads:            DefaultInnodbIOThreads,
+		ReplicaParallelWorkers:          DefaultReplicaParallelWorkers,
 	}
 }
 
@@ -87,18 +161,64 @@ func (md *MySQLD) SetSemiSyncReplicaEnabled(semiSyncReplicaEnabled int) {
 	md.SemiSyncReplicaEnabled = semiSyncReplicaEnabled
 }
 
+// SetGroupReplicationGroupName sets the group replication group name of MySQLD
+func (md *MySQLD) SetGroupReplicationGroupName(groupName string) {
+	md.GroupReplicationGroupName = groupName
+}
+
+// SetGroupReplicationGroupSeeds sets the group replication group seeds of MySQLD
+func (md *MySQLD) SetGroupReplicationGroupSeeds(groupSeeds string) {
+	md.GroupReplicationGroupSeeds = groupSeeds
+}
+
+// SetIOThreads sets the innodb read and write io threads of MySQLD
+func (md *MySQLD) SetIOThreads(innodbReadIOThreads, innodbWriteIOThreads int) {
+	md.InnodbReadIOThreads = innodbReadIOThreads
+	md.InnodbWriteIOThreads = innodbWriteIOThreads
+}
+
+// SetReplicaParallelWorkers sets the replica parallel workers of MySQLD
+func (md *MySQLD) SetReplicaParallelWorkers(replicaParallelWorkers int) {
+	md.ReplicaParallelWorkers = replicaParallelWorkers
+}
+
+// SetParameters sets the parameters of MySQLD, they will be merged into the rendered configuration
+func (md *MySQLD) SetParameters(parameters map[string]string) {
+	md.Parameters = parameters
+}
+
 // GetConfig returns the configuration of MySQLD
 func (md *MySQLD) GetConfig(v *version.Version, m mode.Mode) ([]byte, error) {
-	mysql80Version, err := version.NewVersion(mysql80)
+	t, err := getMySQLDTemplate(v)
 	if err != nil {
 		return nil, err
 	}
 
-	if v.GreaterThanOrEqual(mysql80Version) {
-		return mysql.GetConfig(mysqld80TemplateName, md.configTemplateContent(tmpl.MySQLD80, m), md)
+	content := md.configTemplateContent(t.content, m)
+	if m == mode.SemiSyncReplication && t.name == mysqld80TemplateName {
+		minVersion, err := version.NewVersion(minSemiSyncSourceVersion)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if v.Core().LessThan(minVersion) {
+			content = semiSyncMasterReplacer.Replace(content)
+		}
+	}
+
+	config, err := mysql.GetConfig(t.name, content, md)
+	if err != nil {
+		return nil, err
+	}
+	if len(md.Parameters) == constant.ZeroInt {
+		return config, nil
+	}
+
+	content, err = mergeParameters(v, string(config), md.Parameters)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil, errors.Errorf("version must be larger than 8.0, %s is not supported", md.Version)
+	return []byte(content), nil
 }
 
 // GetConfigWithTitle returns the configuration of MySQLD with title
@@ -113,9 +233,12 @@ func (md *MySQLD) configTemplateContent(template string, m mode.Mode) string {
 	switch m {
 	case mode.AsyncReplication:
 	case mode.SemiSyncReplication:
-		template = strings.Replace(template, "#plugin_load", "plugin_load", 1)
+		template = strings.Replace(template, semiSyncPluginLoadCommentPrefix, semiSyncPluginLoadPrefix, 1)
 		template = strings.ReplaceAll(template, "#rpl_semi_sync", "rpl_semi_sync")
 	case mode.GroupReplication:
+		template = strings.Replace(template, groupReplicationPluginLoadComment, groupReplicationPluginLoad, 1)
+		template = strings.Replace(template, "#disabled_storage_engines", "disabled_storage_engines", 1)
+		template = strings.Replace(template, "#binlog_checksum", "binlog_checksum", 1)
 		template = strings.ReplaceAll(template, "#group_replication", "group_replication")
 	}
 
@@ -1,6 +1,7 @@
 package parameter
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/hashicorp/go-version"
@@ -56,6 +57,9 @@ func initTestVersion() *version.Version {
 
 func TestMySQLD_All(t *testing.T) {
 	TestMySQLD_GetConfig(t)
+	TestMySQLD_GetConfigWithGroupReplication(t)
+	TestMySQLD_GetConfigWithSemiSyncReplication(t)
+	TestMySQLD_GetConfigWithVersion(t)
 }
 
 func TestMySQLD_GetConfig(t *testing.T) {
@@ -65,3 +69,67 @@ func TestMySQLD_GetConfig(t *testing.T) {
 	asst.Nil(err, common.CombineMessageWithError("test TestMySQLD_GetConfig() failed", err))
 	t.Log(string(config))
 }
+
+func TestMySQLD_GetConfigWithGroupReplication(t *testing.T) {
+	asst := assert.New(t)
+
+	testMySQLD.SetGroupReplicationGroupName(testGroupReplicationGroupName)
+	testMySQLD.SetGroupReplicationGroupSeeds(testGroupReplicationGroupSeeds)
+	config, err := testMySQLD.GetConfig(testMySQLVersion, mode.GroupReplication)
+	asst.Nil(err, common.CombineMessageWithError("test GetConfigWithGroupReplication() failed", err))
+	asst.Contains(string(config), "\nplugin_load_add='group_replication.so'", "test GetConfigWithGroupReplication() failed")
+	asst.Contains(string(config), fmt.Sprintf("\ngroup_replication_group_name='%s'", testGroupReplicationGroupName), "test GetConfigWithGroupReplication() failed")
+	asst.Contains(string(config), fmt.Sprintf("\ngroup_replication_group_seeds='%s'", testGroupReplicationGroupSeeds), "test GetConfigWithGroupReplication() failed")
+	asst.Contains(string(config), fmt.Sprintf("\ngroup_replication_local_address='%s:%d'", testHostIP, testPortNum+GroupReplicationPortOffset), "test GetConfigWithGroupReplication() failed")
+	t.Log(string(config))
+}
+
+func TestMySQLD_GetConfigWithSemiSyncReplication(t *testing.T) {
+	asst := assert.New(t)
+
+	v, err := version.NewVersion(minSemiSyncSourceVersion)
+	asst.Nil(err, common.CombineMessageWithError("test GetConfigWithSemiSyncReplication() failed", err))
+	config, err := testMySQLD.GetConfig(v, mode.SemiSyncReplication)
+	asst.Nil(err, common.CombineMessageWithError("test GetConfigWithSemiSyncReplication() failed", err))
+	asst.Contains(string(config), "\n"+semiSyncPluginLoad, "test GetConfigWithSemiSyncReplication() failed")
+	asst.Contains(string(config), fmt.Sprintf("\nrpl_semi_sync_source_enabled=%d", testSemiSyncSourceEnabled), "test GetConfigWithSemiSyncReplication() failed")
+	asst.Contains(string(config), fmt.Sprintf("\nrpl_semi_sync_replica_enabled=%d", testSemiSyncReplicaEnabled), "test GetConfigWithSemiSyncReplication() failed")
+	asst.Contains(string(config), fmt.Sprintf("\nrpl_semi_sync_source_timeout=%d", testSemiSyncSourceTimeout), "test GetConfigWithSemiSyncReplication() failed")
+	asst.Contains(string(config), "\n#"+groupReplicationPluginLoad, "test GetConfigWithSemiSyncReplication() failed")
+	t.Log(string(config))
+}
+
+func TestMySQLD_GetConfigWithVersion(t *testing.T) {
+	asst := assert.New(t)
+
+	testCases := []struct {
+		version     string
+		contains    []string
+		notContains []string
+	}{
+		{"5.7.44", []string{"\nexpire_logs_days=", "\nlog_slave_updates=1", "\nplugin_load_add=\"rpl_semi_sync_master="}, []string{"binlog_expire_logs_seconds", "mysqlx_port"}},
+		{"8.0.25", []string{"\nplugin_load_add=\"rpl_semi_sync_master=semisync_master.so;rpl_semi_sync_slave=semisync_slave.so\"", "\nrpl_semi_sync_master_wait_for_slave_count=1"}, []string{"rpl_semi_sync_source", "rpl_semi_sync_replica"}},
+		{"8.0.32", []string{"\ninnodb_log_files_in_group=", "\nbinlog_transaction_dependency_tracking=", "\n" + semiSyncPluginLoad}, []string{"innodb_redo_log_capacity", "expire_logs_days=", "rpl_semi_sync_master"}},
+		{"8.4.0", []string{"\ninnodb_redo_log_capacity=", "\nauthentication_policy="}, []string{"innodb_log_files_in_group", "binlog_transaction_dependency_tracking"}},
+		{"9.1.0", []string{"\ninnodb_redo_log_capacity="}, []string{"innodb_log_files_in_group", "binlog_transaction_dependency_tracking"}},
+	}
+	for _, testCase := range testCases {
+		v, err := version.NewVersion(testCase.version)
+		asst.Nil(err, common.CombineMessageWithError("test GetConfigWithVersion() failed", err))
+		config, err := testMySQLD.GetConfig(v, mode.SemiSyncReplication)
+		asst.Nil(err, common.CombineMessageWithError("test GetConfigWithVersion() failed", err))
+		for _, str := range testCase.contains {
+			asst.Contains(string(config), str, "test GetConfigWithVersion() failed, version: %s", testCase.version)
+		}
+		for _, str := range testCase.notContains {
+			asst.NotContains(string(config), str, "test GetConfigWithVersion() failed, version: %s", testCase.version)
+		}
+	}
+
+	for _, unsupported := range []string{"5.6.51", "8.2.0", "10.0.0"} {
+		v, err := version.NewVersion(unsupported)
+		asst.Nil(err, common.CombineMessageWithError("test GetConfigWithVersion() failed", err))
+		_, err = testMySQLD.GetConfig(v, mode.AsyncReplication)
+		asst.NotNil(err, "test GetConfigWithVersion() failed, version: %s", unsupported)
+	}
+}
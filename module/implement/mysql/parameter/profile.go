@@ -0,0 +1,133 @@
+package parameter
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/pingcap/errors"
+	"github.com/romberli/go-util/constant"
+)
+
+const (
+	variableNameSeparator  = "-"
+	variableNameUnderscore = "_"
+	variableLoosePrefix    = "loose_"
+	configCommentPrefix    = "#"
+	configSectionPrefix    = "["
+	configLineBreakChars   = "\r\n"
+)
+
+// protectedVariables are the options which are managed by db operator, they could not be overridden by the parameters,
+// otherwise the instance could not be found or managed anymore
+var protectedVariables = map[string]bool{
+	"port":                      true,
+	"mysqlx_port":               true,
+	"admin_port":                true,
+	"basedir":                   true,
+	"datadir":                   true,
+	"tmpdir":                    true,
+	"socket":                    true,
+	"mysqlx_socket":             true,
+	"pid_file":                  true,
+	"log_error":                 true,
+	"server_id":                 true,
+	"log_bin":                   true,
+	"relay_log":                 true,
+	"innodb_log_group_home_dir": true,
+	"report_host":               true,
+	"report_port":               true,
+	"secure_file_priv":          true,
+	"gtid_mode":                 true,
+	"enforce_gtid_consistency":  true,
+	"lower_case_table_names":    true,
+	"plugin_load_add":           true,
+}
+
+// NormalizeVariableName returns the option name in the form shown by show global variables,
+// e.g. loose-group_replication_group_name is normalized to group_replication_group_name
+func NormalizeVariableName(name string) string {
+	name = strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), variableNameSeparator, variableNameUnderscore)
+
+	return strings.TrimPrefix(name, variableLoosePrefix)
+}
+
+// mergeParameters merges the parameters into the rendered mysqld section, the existing option is overridden in place
+// and the new option is appended to the end of the section, the parameter must be an option of the template
+// or a variable of the catalog of the version, and it must not be a protected option
+func mergeParameters(v *version.Version, content string, parameters map[string]string) (string, error) {
+	lines := strings.Split(content, constant.CRLFString)
+	// get the options of the template
+	optionIndexes := make(map[string]int)
+	lastOptionIndex := constant.DefaultRandomInt
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == constant.EmptyString || strings.HasPrefix(trimmed, configCommentPrefix) {
+			continue
+		}
+		lastOptionIndex = i
+		if strings.HasPrefix(trimmed, configSectionPrefix) {
+			continue
+		}
+		kv := strings.SplitN(trimmed, constant.EqualString, constant.TwoInt)
+		optionIndexes[NormalizeVariableName(kv[constant.ZeroInt])] = i
+	}
+	if lastOptionIndex == constant.DefaultRandomInt {
+		return constant.EmptyString, errors.New("mergeParameters(): the rendered mysqld section is empty")
+	}
+	catalog, err := getMergeableCatalog(v)
+	if err != nil {
+		return constant.EmptyString, err
+	}
+
+	names := make([]string, constant.ZeroInt, len(parameters))
+	for name := range parameters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var appendedLines []string
+	for _, name := range names {
+		value := parameters[name]
+		normalizedName := NormalizeVariableName(name)
+		if protectedVariables[normalizedName] {
+			return constant.EmptyString, errors.Errorf("mergeParameters(): option is managed by db operator and could not be overridden. option: %s", name)
+		}
+		if strings.ContainsAny(value, configLineBreakChars) {
+			return constant.EmptyString, errors.Errorf("mergeParameters(): value of the option must not contain line breaks. option: %s", name)
+		}
+		line := name + constant.EqualString + value
+		index, exists := optionIndexes[normalizedName]
+		if exists {
+			lines[index] = line
+			continue
+		}
+		_, exists = catalog[normalizedName]
+		if !exists {
+			return constant.EmptyString, errors.Errorf("mergeParameters(): option is unknown, it is neither in the template nor in the variable catalog. version: %s, option: %s",
+				v.String(), name)
+		}
+		appendedLines = append(appendedLines, line)
+	}
+
+	newLines := make([]string, constant.ZeroInt, len(lines)+len(appendedLines))
+	newLines = append(newLines, lines[:lastOptionIndex+constant.OneInt]...)
+	newLines = append(newLines, appendedLines...)
+	newLines = append(newLines, lines[lastOptionIndex+constant.OneInt:]...)
+
+	return strings.Join(newLines, constant.CRLFString), nil
+}
+
+// getMergeableCatalog returns the variable catalog of the version which could be appended to the mysqld section,
+// the catalog only covers mysql 8.0 and later, so only the options of the template could be overridden for the earlier versions
+func getMergeableCatalog(v *version.Version) (map[string]bool, error) {
+	mysql80Version, err := version.NewVersion(mysql80)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if v.LessThan(mysql80Version) {
+		return map[string]bool{}, nil
+	}
+
+	return GetVariableCatalog(v)
+}
@@ -0,0 +1,46 @@
+package parameter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/romberli/go-util/common"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testMergeContent = "[mysqld3306]\nport=3306\nsort_buffer_size=4M\n\n#binlog_checksum=NONE\n"
+)
+
+func TestProfile_All(t *testing.T) {
+	TestNormalizeVariableName(t)
+	TestMergeParameters(t)
+}
+
+func TestNormalizeVariableName(t *testing.T) {
+	asst := assert.New(t)
+
+	asst.Equal("group_replication_group_name", NormalizeVariableName("loose-group_replication_group_name"), "test NormalizeVariableName() failed")
+	asst.Equal("pid_file", NormalizeVariableName(" PID-File "), "test NormalizeVariableName() failed")
+}
+
+func TestMergeParameters(t *testing.T) {
+	asst := assert.New(t)
+
+	// override the option of the template and append the option of the catalog
+	content, err := mergeParameters(testMySQLVersion, testMergeContent, map[string]string{"sort-buffer-size": "2M"})
+	asst.Nil(err, common.CombineMessageWithError("test mergeParameters() failed", err))
+	asst.Equal("[mysqld3306]\nport=3306\nsort-buffer-size=2M\n\n#binlog_checksum=NONE\n", content, "test mergeParameters() failed")
+	content, err = mergeParameters(testMySQLVersion, testMergeContent, map[string]string{"max_connections": "200"})
+	asst.Nil(err, common.CombineMessageWithError("test mergeParameters() failed", err))
+	asst.True(strings.Contains(content, "sort_buffer_size=4M\nmax_connections=200\n"), "test mergeParameters() failed")
+	// protected option
+	_, err = mergeParameters(testMySQLVersion, testMergeContent, map[string]string{"port": "3307"})
+	asst.NotNil(err, "test mergeParameters() failed")
+	// unknown option
+	_, err = mergeParameters(testMySQLVersion, testMergeContent, map[string]string{"not_a_variable": "1"})
+	asst.NotNil(err, "test mergeParameters() failed")
+	// line break in the value
+	_, err = mergeParameters(testMySQLVersion, testMergeContent, map[string]string{"sort_buffer_size": "2M\nport=3307"})
+	asst.NotNil(err, "test mergeParameters() failed")
+}
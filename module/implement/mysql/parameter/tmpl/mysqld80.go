@@ -30,7 +30,14 @@ sql_mode=STRICT_TRANS_TABLES,NO_ENGINE_SUBSTITUTION,PIPES_AS_CONCAT,ONLY_FULL_GR
 #rpl_semi_sync_source_wait_no_replica=1
 
 #plugin_load_add='group_replication.so'
+#disabled_storage_engines='MyISAM,BLACKHOLE,FEDERATED,ARCHIVE,MEMORY'
+#group_replication_group_name='{{.GroupReplicationGroupName}}'
+#group_replication_start_on_boot=off
+#group_replication_bootstrap_group=off
+#group_replication_local_address='{{.HostIP}}:{{.GroupReplicationPort}}'
+#group_replication_group_seeds='{{.GroupReplicationGroupSeeds}}'
 #group_replication_single_primary_mode=on
+#group_replication_enforce_update_everywhere_checks=off
 #group_replication_consistency={{.GroupReplicationConsistency}}
 #group_replication_flow_control_mode={{.GroupReplicationFlowControlMode}}
 #group_replication_member_weight={{.GroupReplicationMemberWeight}}
@@ -55,7 +62,7 @@ relay_log_purge=1
 relay_log_recovery=1
 report_host={{.HostIP}}
 report_port={{.PortNum}}
-replica_parallel_workers=16
+replica_parallel_workers={{.ReplicaParallelWorkers}}
 replica_preserve_commit_order=1
 replica_transaction_retries=128
 binlog_transaction_dependency_tracking=writeset
@@ -85,8 +92,8 @@ innodb_buffer_pool_instances=8
 innodb_buffer_pool_size={{.InnodbBufferPoolSize}}
 innodb_sort_buffer_size=4M
 innodb_log_buffer_size=32M
-innodb_read_io_threads=16
-innodb_write_io_threads=16
+innodb_read_io_threads={{.InnodbReadIOThreads}}
+innodb_write_io_threads={{.InnodbWriteIOThreads}}
 innodb_io_capacity={{.InnodbIOCapacity}}
 innodb_io_capacity_max={{.InnodbIOCapacityMax}}
 innodb_page_cleaners=16
@@ -0,0 +1,127 @@
+package parameter
+
+import (
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/pingcap/errors"
+
+	"github.com/romberli/db-operator/module/implement/mysql/mode"
+)
+
+const (
+	mysql8030 = "8.0.30"
+
+	groupReplicationVariablePrefix = "group_replication_"
+	semiSyncVariablePrefix         = "rpl_semi_sync_"
+)
+
+var (
+	// mysqlServerVariables are the variables which are rendered into the config file from the fields of MySQLServer,
+	// the value is true if the variable is dynamic
+	mysqlServerVariables = map[string]bool{
+		"max_connections":                     true,
+		"innodb_buffer_pool_size":             true,
+		"innodb_io_capacity":                  true,
+		"innodb_io_capacity_max":              true,
+		"binlog_expire_logs_seconds":          true,
+		"rpl_semi_sync_source_timeout":        true,
+		"group_replication_consistency":       true,
+		"group_replication_flow_control_mode": true,
+		"group_replication_member_weight":     true,
+	}
+	// mysql80Variables are the other variables of mysql 8.0 which are allowed to be changed,
+	// the value is true if the variable is dynamic
+	mysql80Variables = map[string]bool{
+		// dynamic
+		"max_allowed_packet":             true,
+		"max_connect_errors":             true,
+		"wait_timeout":                   true,
+		"interactive_timeout":            true,
+		"long_query_time":                true,
+		"slow_query_log":                 true,
+		"table_open_cache":               true,
+		"table_definition_cache":         true,
+		"thread_cache_size":              true,
+		"tmp_table_size":                 true,
+		"max_heap_table_size":            true,
+		"sort_buffer_size":               true,
+		"join_buffer_size":               true,
+		"read_buffer_size":               true,
+		"read_rnd_buffer_size":           true,
+		"binlog_cache_size":              true,
+		"sync_binlog":                    true,
+		"innodb_flush_log_at_trx_commit": true,
+		"innodb_lock_wait_timeout":       true,
+		"innodb_log_buffer_size":         true,
+		"innodb_thread_concurrency":      true,
+		"innodb_adaptive_hash_index":     true,
+		"innodb_print_all_deadlocks":     true,
+		// static
+		"back_log":                     false,
+		"open_files_limit":             false,
+		"table_open_cache_instances":   false,
+		"performance_schema":           false,
+		"innodb_buffer_pool_instances": false,
+		"innodb_read_io_threads":       false,
+		"innodb_write_io_threads":      false,
+		"innodb_page_cleaners":         false,
+		"innodb_purge_threads":         false,
+		"innodb_flush_method":          false,
+		"innodb_log_file_size":         false,
+	}
+	// mysql8030Variables are the variables introduced in mysql 8.0.30, the value is true if the variable is dynamic
+	mysql8030Variables = map[string]bool{
+		"innodb_redo_log_capacity": true,
+	}
+)
+
+// GetVariableCatalog returns the variables which are allowed to be changed of the given version,
+// the key is the variable name and the value is true if the variable is dynamic
+func GetVariableCatalog(v *version.Version) (map[string]bool, error) {
+	mysql80Version, err := version.NewVersion(mysql80)
+	if err != nil {
+		return nil, err
+	}
+	if v.LessThan(mysql80Version) {
+		return nil, errors.Errorf("version must be larger than 8.0, %s is not supported", v.String())
+	}
+	mysql8030Version, err := version.NewVersion(mysql8030)
+	if err != nil {
+		return nil, err
+	}
+
+	catalog := make(map[string]bool, len(mysqlServerVariables)+len(mysql80Variables)+len(mysql8030Variables))
+	for name, isDynamic := range mysqlServerVariables {
+		catalog[name] = isDynamic
+	}
+	for name, isDynamic := range mysql80Variables {
+		catalog[name] = isDynamic
+	}
+	if v.GreaterThanOrEqual(mysql8030Version) {
+		for name, isDynamic := range mysql8030Variables {
+			catalog[name] = isDynamic
+		}
+	}
+
+	return catalog, nil
+}
+
+// GetModeVariableCatalog returns the variables which are allowed to be changed of the given version in the given mode,
+// the group replication variables are only allowed in the group replication mode,
+// and the semi-sync variables are only allowed in the semi-sync replication mode
+func GetModeVariableCatalog(v *version.Version, m mode.Mode) (map[string]bool, error) {
+	catalog, err := GetVariableCatalog(v)
+	if err != nil {
+		return nil, err
+	}
+
+	for name := range catalog {
+		if (strings.HasPrefix(name, groupReplicationVariablePrefix) && m != mode.GroupReplication) ||
+			(strings.HasPrefix(name, semiSyncVariablePrefix) && m != mode.SemiSyncReplication) {
+			delete(catalog, name)
+		}
+	}
+
+	return catalog, nil
+}
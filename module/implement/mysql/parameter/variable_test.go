@@ -0,0 +1,64 @@
+package parameter
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-version"
+	"github.com/romberli/go-util/common"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/romberli/db-operator/module/implement/mysql/mode"
+)
+
+func TestVariable_All(t *testing.T) {
+	TestGetVariableCatalog(t)
+	TestGetModeVariableCatalog(t)
+}
+
+func TestGetVariableCatalog(t *testing.T) {
+	asst := assert.New(t)
+
+	catalog, err := GetVariableCatalog(testMySQLVersion)
+	asst.Nil(err, common.CombineMessageWithError("test GetVariableCatalog() failed", err))
+	asst.True(catalog["max_connections"], "test GetVariableCatalog() failed")
+	asst.False(catalog["innodb_buffer_pool_instances"], "test GetVariableCatalog() failed")
+	_, exists := catalog["innodb_redo_log_capacity"]
+	asst.False(exists, "test GetVariableCatalog() failed")
+	_, exists = catalog["datadir"]
+	asst.False(exists, "test GetVariableCatalog() failed")
+
+	v, err := version.NewVersion(testVersion)
+	asst.Nil(err, common.CombineMessageWithError("test GetVariableCatalog() failed", err))
+	catalog, err = GetVariableCatalog(v)
+	asst.Nil(err, common.CombineMessageWithError("test GetVariableCatalog() failed", err))
+	asst.True(catalog["innodb_redo_log_capacity"], "test GetVariableCatalog() failed")
+
+	v, err = version.NewVersion("5.7.40")
+	asst.Nil(err, common.CombineMessageWithError("test GetVariableCatalog() failed", err))
+	_, err = GetVariableCatalog(v)
+	asst.NotNil(err, "test GetVariableCatalog() failed")
+}
+
+func TestGetModeVariableCatalog(t *testing.T) {
+	asst := assert.New(t)
+
+	catalog, err := GetModeVariableCatalog(testMySQLVersion, mode.AsyncReplication)
+	asst.Nil(err, common.CombineMessageWithError("test GetModeVariableCatalog() failed", err))
+	asst.True(catalog["max_connections"], "test GetModeVariableCatalog() failed")
+	_, exists := catalog["rpl_semi_sync_source_timeout"]
+	asst.False(exists, "test GetModeVariableCatalog() failed")
+	_, exists = catalog["group_replication_consistency"]
+	asst.False(exists, "test GetModeVariableCatalog() failed")
+
+	catalog, err = GetModeVariableCatalog(testMySQLVersion, mode.SemiSyncReplication)
+	asst.Nil(err, common.CombineMessageWithError("test GetModeVariableCatalog() failed", err))
+	asst.True(catalog["rpl_semi_sync_source_timeout"], "test GetModeVariableCatalog() failed")
+	_, exists = catalog["group_replication_consistency"]
+	asst.False(exists, "test GetModeVariableCatalog() failed")
+
+	catalog, err = GetModeVariableCatalog(testMySQLVersion, mode.GroupReplication)
+	asst.Nil(err, common.CombineMessageWithError("test GetModeVariableCatalog() failed", err))
+	asst.True(catalog["group_replication_consistency"], "test GetModeVariableCatalog() failed")
+	_, exists = catalog["rpl_semi_sync_source_timeout"]
+	asst.False(exists, "test GetModeVariableCatalog() failed")
+}
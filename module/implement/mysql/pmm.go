@@ -29,6 +29,7 @@ const (
 	pmmClientCheckServiceCommandTemplate       = "/usr/local/bin/pmm-admin list | grep ^MySQL | grep %d | grep -v grep | wc -l"
 	pmmClientAddServiceCommandTemplateV1       = "/usr/local/bin/pmm-admin add mysql --host=127.0.0.1 --port=%d --username=%s --password=%s %s"
 	pmmClientAddServiceCommandTemplateV2       = "/usr/local/bin/pmm-admin add mysql --host=127.0.0.1 --port=%d --username=%s --password=%s --replication-set=%s %s"
+	pmmClientRemoveServiceCommandTemplate      = "/usr/local/bin/pmm-admin remove mysql %s"
 
 	pmmClientServiceNameTemplate = "%s-%d"
 	pmmClientNodeExporterOutput  = "node_exporter"
@@ -222,6 +223,46 @@ func (pe *PMMExecutor) AddService() error {
 	return nil
 }
 
+// RemoveService removes the service from pmm server, it does nothing if pmm client is not installed or the service does not exist
+func (pe *PMMExecutor) RemoveService() error {
+	// check if pmm client is installed
+	installed, err := pe.CheckPMMClient()
+	if err != nil {
+		return err
+	}
+	if !installed {
+		return nil
+	}
+	// check if the service exists
+	exists, err := pe.CheckServiceExists()
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return nil
+	}
+	// get service name
+	serviceName, err := pe.getServiceName()
+	if err != nil {
+		return err
+	}
+	// remove service
+	err = pe.sshConn.ExecuteCommandWithoutOutput(fmt.Sprintf(pmmClientRemoveServiceCommandTemplate, serviceName))
+	if err != nil {
+		return err
+	}
+	// check if the service still exists
+	exists, err = pe.CheckServiceExists()
+	if err != nil {
+		return err
+	}
+	if exists {
+		return errors.Errorf("pmm client remove service failed. host_ip: %s, port_num: %d, service_name: %s", pe.hostIP, pe.portNum, serviceName)
+	}
+
+	return nil
+}
+
 // getInstallationPackageName returns the installation package name
 func (pe *PMMExecutor) getInstallationPackageName() string {
 	return fmt.Sprintf(pmmClientInstallationPackageNameTemplate, pe.pmmClient.ClientVersion)
@@ -35,6 +35,7 @@ func TestPMMExecutor_All(t *testing.T) {
 	TestPMMExecutor_ConfigureServer(t)
 	TestPMMExecutor_CheckServiceExists(t)
 	TestPMMExecutor_AddService(t)
+	TestPMMExecutor_RemoveService(t)
 }
 
 func TestPMMExecutor_CheckPMMClient(t *testing.T) {
@@ -104,3 +105,13 @@ func TestPMMExecutor_AddService(t *testing.T) {
 		t.Skip("skip test AddService() for existing service")
 	}
 }
+
+func TestPMMExecutor_RemoveService(t *testing.T) {
+	asst := assert.New(t)
+
+	err := testPMMExecutor.RemoveService()
+	asst.Nil(err, "test RemoveService() failed")
+	exists, err := testPMMExecutor.CheckServiceExists()
+	asst.Nil(err, "test RemoveService() failed")
+	asst.False(exists, "test RemoveService() failed")
+}
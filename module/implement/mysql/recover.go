@@ -0,0 +1,142 @@
+package mysql
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/romberli/go-util/constant"
+	"github.com/romberli/go-util/linux"
+	"github.com/romberli/log"
+
+	"github.com/romberli/db-operator/global"
+	"github.com/romberli/db-operator/pkg/message"
+
+	msgMySQL "github.com/romberli/db-operator/pkg/message/mysql"
+)
+
+const (
+	ownerSeparator = ":"
+
+	orphanedOperationExitedMessageTemplate  = "operation interrupted, the db-operator process %s which ran it has exited. failed install operations could be resumed."
+	orphanedOperationExpiredMessageTemplate = "operation interrupted, the db-operator process %s which ran it has not renewed the operation lock within the lease timeout. failed install operations could be resumed."
+)
+
+type OperationRecoverer struct {
+	*DBORepo
+}
+
+// NewOperationRecoverer returns a new *OperationRecoverer
+func NewOperationRecoverer(repo *DBORepo) *OperationRecoverer {
+	return newOperationRecoverer(repo)
+}
+
+// NewOperationRecovererWithDefault returns a new *OperationRecoverer with default repository
+func NewOperationRecovererWithDefault() *OperationRecoverer {
+	return newOperationRecoverer(NewDBORepoWithDefault())
+}
+
+// newOperationRecoverer returns a new *OperationRecoverer
+func newOperationRecoverer(repo *DBORepo) *OperationRecoverer {
+	return &OperationRecoverer{
+		DBORepo: repo,
+	}
+}
+
+// RecoverOrphanedOperations marks the running operations whose owner process has gone as failed,
+// it should be called when the db-operator process starts, so that the operations interrupted by the previous process
+// will not be shown as running forever
+func (or *OperationRecoverer) RecoverOrphanedOperations() error {
+	operations, err := or.GetRunningOperationHistories()
+	if err != nil {
+		return message.NewMessage(msgMySQL.ErrMySQLServiceRecoverOrphanedOperations, err)
+	}
+
+	for _, operation := range operations {
+		msg, err := or.getOrphanedMessage(operation)
+		if err != nil {
+			return message.NewMessage(msgMySQL.ErrMySQLServiceRecoverOrphanedOperations, err)
+		}
+		if msg == constant.EmptyString {
+			continue
+		}
+
+		err = or.FailOrphanedOperation(operation.ID, msg)
+		if err != nil {
+			return message.NewMessage(msgMySQL.ErrMySQLServiceRecoverOrphanedOperations, err)
+		}
+		log.Warn(message.NewMessage(msgMySQL.InfoMySQLServiceRecoverOrphanedOperation, operation.ID, operation.Owner, msg).Error())
+	}
+
+	return nil
+}
+
+// getOrphanedMessage returns the message which explains why the operation is orphaned, it returns an empty string if the operation is not orphaned.
+// the operation is orphaned if its owner process on this host is not running,
+// or its owner has not renewed the operation lock within the lease timeout, which works for the owner on the other hosts.
+// as the owner is unique per process start, the owner on this host with the same pid as this process is a previous process which has exited
+func (or *OperationRecoverer) getOrphanedMessage(operation *OperationInfo) (string, error) {
+	if operation.Owner == global.Owner {
+		return constant.EmptyString, nil
+	}
+
+	isLocal, pid, err := parseLocalOwner(operation.Owner)
+	if err == nil && isLocal {
+		if pid == os.Getpid() {
+			return fmt.Sprintf(orphanedOperationExitedMessageTemplate, operation.Owner), nil
+		}
+		isRunning, err := linux.IsRunningWithPid(pid)
+		if err != nil {
+			return constant.EmptyString, err
+		}
+		if !isRunning {
+			return fmt.Sprintf(orphanedOperationExitedMessageTemplate, operation.Owner), nil
+		}
+	}
+
+	// the lock is acquired right after the operation is initialized, give the owner a lease timeout to get it
+	if operation.CreateTime.After(time.Now().Add(-getLockLeaseTimeout())) {
+		return constant.EmptyString, nil
+	}
+	hasAliveLock, err := or.HasAliveLock(operation.ID)
+	if err != nil {
+		return constant.EmptyString, err
+	}
+	if !hasAliveLock {
+		return fmt.Sprintf(orphanedOperationExpiredMessageTemplate, operation.Owner), nil
+	}
+
+	return constant.EmptyString, nil
+}
+
+// parseLocalOwner returns if the owner is a db-operator process on this host and the pid of the owner,
+// the owner is formatted as hostname:pid:startTime, the owners recorded by the older versions are formatted as hostname:pid
+func parseLocalOwner(owner string) (bool, int, error) {
+	ownerHostName, pid, err := splitOwner(owner)
+	if err != nil {
+		return false, constant.ZeroInt, err
+	}
+	hostName, _, err := splitOwner(global.Owner)
+	if err != nil {
+		return false, constant.ZeroInt, err
+	}
+
+	return ownerHostName == hostName, pid, nil
+}
+
+// splitOwner splits the owner into the hostname and the pid
+func splitOwner(owner string) (string, int, error) {
+	fields := strings.Split(owner, ownerSeparator)
+	if len(fields) < constant.TwoInt {
+		return constant.EmptyString, constant.ZeroInt, errors.Errorf("owner must be formatted as hostname:pid:startTime. owner: %s", owner)
+	}
+	pid, err := strconv.Atoi(fields[constant.OneInt])
+	if err != nil {
+		return constant.EmptyString, constant.ZeroInt, errors.Trace(err)
+	}
+
+	return fields[constant.ZeroInt], pid, nil
+}
@@ -0,0 +1,101 @@
+package mysql
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/romberli/go-util/constant"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/romberli/db-operator/global"
+)
+
+const (
+	// the pid which could not be used by any process
+	testDeadPid     = 4194305
+	testRemoteOwner = "remote-host:12345:1700000000000000000"
+)
+
+// testInitOrphanedOperation initializes a running operation which is owned by the given owner
+func testInitOrphanedOperation(owner string) (int, error) {
+	operationID, err := testDBORepo.InitOperationHistory(defaultInstallOperation, []string{testAddr1}, constant.EmptyString)
+	if err != nil {
+		return constant.ZeroInt, err
+	}
+	sql := `UPDATE t_mysql_operation_info SET owner = ? WHERE id = ? ;`
+	_, err = testDBORepo.Execute(sql, owner, operationID)
+	if err != nil {
+		return constant.ZeroInt, err
+	}
+
+	return operationID, nil
+}
+
+func TestOperationRecoverer_All(t *testing.T) {
+	TestOperationRecoverer_RecoverOrphanedOperations(t)
+	TestSplitOwner(t)
+}
+
+func TestOperationRecoverer_RecoverOrphanedOperations(t *testing.T) {
+	asst := assert.New(t)
+
+	testOperationRecoverer := NewOperationRecoverer(testDBORepo)
+	hostName, _, err := splitOwner(global.Owner)
+	asst.Nil(err, "test RecoverOrphanedOperations() failed")
+	// the owner on this host has exited
+	deadOperationID, err := testInitOrphanedOperation(fmt.Sprintf("%s:%d", hostName, testDeadPid))
+	asst.Nil(err, "test RecoverOrphanedOperations() failed")
+	// the previous process on this host had the same pid as this process, e.g. the pid 1 in the containers
+	reusedOperationID, err := testInitOrphanedOperation(fmt.Sprintf("%s:%d:%d", hostName, os.Getpid(), constant.ZeroInt))
+	asst.Nil(err, "test RecoverOrphanedOperations() failed")
+	// the owner on the other host has just started the operation
+	remoteOperationID, err := testInitOrphanedOperation(testRemoteOwner)
+	asst.Nil(err, "test RecoverOrphanedOperations() failed")
+	// the operation of this process is still running
+	ownOperationID, err := testDBORepo.InitOperationHistory(defaultInstallOperation, []string{testAddr2}, constant.EmptyString)
+	asst.Nil(err, "test RecoverOrphanedOperations() failed")
+
+	err = testOperationRecoverer.RecoverOrphanedOperations()
+	asst.Nil(err, "test RecoverOrphanedOperations() failed")
+	operationInfo, err := testDBORepo.GetOperationHistory(deadOperationID)
+	asst.Nil(err, "test RecoverOrphanedOperations() failed")
+	asst.Equal(defaultFailedStatus, operationInfo.Status, "test RecoverOrphanedOperations() failed")
+	operationInfo, err = testDBORepo.GetOperationHistory(reusedOperationID)
+	asst.Nil(err, "test RecoverOrphanedOperations() failed")
+	asst.Equal(defaultFailedStatus, operationInfo.Status, "test RecoverOrphanedOperations() failed")
+	operationInfo, err = testDBORepo.GetOperationHistory(remoteOperationID)
+	asst.Nil(err, "test RecoverOrphanedOperations() failed")
+	asst.Equal(defaultRunningStatus, operationInfo.Status, "test RecoverOrphanedOperations() failed")
+	operationInfo, err = testDBORepo.GetOperationHistory(ownOperationID)
+	asst.Nil(err, "test RecoverOrphanedOperations() failed")
+	asst.Equal(defaultRunningStatus, operationInfo.Status, "test RecoverOrphanedOperations() failed")
+	// the remote owner has not got the lock within the lease timeout
+	sql := `UPDATE t_mysql_operation_info SET create_time = ? WHERE id = ? ;`
+	_, err = testDBORepo.Execute(sql, "2024-01-01 00:00:00", remoteOperationID)
+	asst.Nil(err, "test RecoverOrphanedOperations() failed")
+	err = testOperationRecoverer.RecoverOrphanedOperations()
+	asst.Nil(err, "test RecoverOrphanedOperations() failed")
+	operationInfo, err = testDBORepo.GetOperationHistory(remoteOperationID)
+	asst.Nil(err, "test RecoverOrphanedOperations() failed")
+	asst.Equal(defaultFailedStatus, operationInfo.Status, "test RecoverOrphanedOperations() failed")
+	// truncate operation info
+	err = testTruncateOperationInfo()
+	asst.Nil(err, "test RecoverOrphanedOperations() failed")
+}
+
+func TestSplitOwner(t *testing.T) {
+	asst := assert.New(t)
+
+	hostName, pid, err := splitOwner(testRemoteOwner)
+	asst.Nil(err, "test splitOwner() failed")
+	asst.Equal("remote-host", hostName, "test splitOwner() failed")
+	asst.Equal(12345, pid, "test splitOwner() failed")
+	// the owner recorded by the older versions
+	hostName, pid, err = splitOwner("remote-host:12345")
+	asst.Nil(err, "test splitOwner() failed")
+	asst.Equal("remote-host", hostName, "test splitOwner() failed")
+	asst.Equal(12345, pid, "test splitOwner() failed")
+	_, _, err = splitOwner("remote-host")
+	asst.NotNil(err, "test splitOwner() failed")
+}
@@ -0,0 +1,249 @@
+package mysql
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-version"
+	"github.com/pingcap/errors"
+	"github.com/romberli/go-util/constant"
+	"github.com/romberli/go-util/middleware/mysql"
+)
+
+const (
+	changeReplicationSourceSQLTemplate = "change replication source to source_host='%s', source_port=%d, source_user='%s', source_password='%s', source_auto_position=1 ;"
+	changeMasterSQLTemplate            = "change master to master_host='%s', master_port=%d, master_user='%s', master_password='%s', master_auto_position=1 ;"
+	startReplicaSQL                    = "start replica ;"
+	startSlaveSQL                      = "start slave ;"
+	stopReplicaSQL                     = "stop replica ;"
+	stopSlaveSQL                       = "stop slave ;"
+	resetReplicaAllSQL                 = "reset replica all ;"
+	resetSlaveAllSQL                   = "reset slave all ;"
+	showReplicaStatusSQL               = "show replica status ;"
+	showSlaveStatusSQL                 = "show slave status ;"
+	setPersistVariableSQLTemplate      = "set persist %s = %s ;"
+	setGlobalVariableSQLTemplate       = "set global %s = %s ;"
+
+	ReplicaIOThreadRunningField    = "Replica_IO_Running"
+	SlaveIOThreadRunningField      = "Slave_IO_Running"
+	ReplicaSQLThreadRunningField   = "Replica_SQL_Running"
+	SlaveSQLThreadRunningField     = "Slave_SQL_Running"
+	SecondsBehindSourceField       = "Seconds_Behind_Source"
+	SecondsBehindMasterField       = "Seconds_Behind_Master"
+	SemiSyncSourceStatusVariable   = "Rpl_semi_sync_source_status"
+	SemiSyncMasterStatusVariable   = "Rpl_semi_sync_master_status"
+	SemiSyncReplicaStatusVariable  = "Rpl_semi_sync_replica_status"
+	SemiSyncSlaveStatusVariable    = "Rpl_semi_sync_slave_status"
+	semiSyncSourceEnabledVariable  = "rpl_semi_sync_source_enabled"
+	semiSyncMasterEnabledVariable  = "rpl_semi_sync_master_enabled"
+	semiSyncReplicaEnabledVariable = "rpl_semi_sync_replica_enabled"
+	semiSyncSlaveEnabledVariable   = "rpl_semi_sync_slave_enabled"
+	superReadOnlyVariable          = "super_read_only"
+	readOnlyVariable               = "read_only"
+	variableOnValue                = "on"
+	variableOffValue               = "off"
+
+	// the joiner gets the public key of the donor, so that the recovery user with caching_sha2_password could connect without ssl
+	changeGroupReplicationRecoverySQLTemplate       = "change replication source to source_user='%s', source_password='%s', get_source_public_key=1 for channel 'group_replication_recovery' ;"
+	changeGroupReplicationRecoveryMasterSQLTemplate = "change master to master_user='%s', master_password='%s', get_master_public_key=1 for channel 'group_replication_recovery' ;"
+	changeGroupReplicationRecovery57SQLTemplate     = "change master to master_user='%s', master_password='%s' for channel 'group_replication_recovery' ;"
+	getGroupReplicationMemberRoleSQL                = "select member_role from performance_schema.replication_group_members where member_id = @@server_uuid ;"
+	// the member_role column is not available in 5.7, the primary is reported by the status variable in single-primary mode
+	getGroupReplicationMemberRole57SQL = "select if(variable_value = @@server_uuid, 'PRIMARY', 'SECONDARY') from performance_schema.global_status where variable_name = 'group_replication_primary_member' ;"
+
+	minSemiSyncSourceMySQLVersionStr          = "8.0.26"
+	minChangeReplicationSourceMySQLVersionStr = "8.0.23"
+	minReplicaStatementMySQLVersionStr        = "8.0.22"
+	minGetSourcePublicKeyMySQLVersionStr      = "8.0.4"
+	minReplicationSyntaxMySQLVersionStr       = "0.0.0"
+)
+
+// replicationSyntax is the replication statements and the names of the replication status fields and variables
+// of the versions which are equal to or greater than minVersion.
+// mysql renamed the master/slave terms step by step in 8.0.x and removed the old statements in 8.4,
+// so the statements must be chosen by the version of the running mysql server
+type replicationSyntax struct {
+	minVersion                          *version.Version
+	changeSourceSQLTemplate             string
+	groupReplicationRecoverySQLTemplate string
+	groupReplicationMemberRoleSQL       string
+	startReplicaSQL                     string
+	stopReplicaSQL                      string
+	resetReplicaAllSQL                  string
+	showReplicaStatusSQL                string
+	setVariableSQLTemplate              string
+	ioThreadRunningField                string
+	sqlThreadRunningField               string
+	secondsBehindSourceField            string
+	semiSyncSourceStatusVariable        string
+	semiSyncReplicaStatusVariable       string
+	semiSyncSourceEnabledVariable       string
+	semiSyncReplicaEnabledVariable      string
+}
+
+// replicationSyntaxes is the registry of the replication syntax, it is sorted by the min version in descending order
+var replicationSyntaxes = []*replicationSyntax{
+	// the semisync_source and semisync_replica plugins are available since 8.0.26
+	{
+		minVersion:                          version.Must(version.NewVersion(minSemiSyncSourceMySQLVersionStr)),
+		changeSourceSQLTemplate:             changeReplicationSourceSQLTemplate,
+		groupReplicationRecoverySQLTemplate: changeGroupReplicationRecoverySQLTemplate,
+		groupReplicationMemberRoleSQL:       getGroupReplicationMemberRoleSQL,
+		startReplicaSQL:                     startReplicaSQL,
+		stopReplicaSQL:                      stopReplicaSQL,
+		resetReplicaAllSQL:                  resetReplicaAllSQL,
+		showReplicaStatusSQL:                showReplicaStatusSQL,
+		setVariableSQLTemplate:              setPersistVariableSQLTemplate,
+		ioThreadRunningField:                ReplicaIOThreadRunningField,
+		sqlThreadRunningField:               ReplicaSQLThreadRunningField,
+		secondsBehindSourceField:            SecondsBehindSourceField,
+		semiSyncSourceStatusVariable:        SemiSyncSourceStatusVariable,
+		semiSyncReplicaStatusVariable:       SemiSyncReplicaStatusVariable,
+		semiSyncSourceEnabledVariable:       semiSyncSourceEnabledVariable,
+		semiSyncReplicaEnabledVariable:      semiSyncReplicaEnabledVariable,
+	},
+	// change replication source is available since 8.0.23
+	{
+		minVersion:                          version.Must(version.NewVersion(minChangeReplicationSourceMySQLVersionStr)),
+		changeSourceSQLTemplate:             changeReplicationSourceSQLTemplate,
+		groupReplicationRecoverySQLTemplate: changeGroupReplicationRecoverySQLTemplate,
+		groupReplicationMemberRoleSQL:       getGroupReplicationMemberRoleSQL,
+		startReplicaSQL:                     startReplicaSQL,
+		stopReplicaSQL:                      stopReplicaSQL,
+		resetReplicaAllSQL:                  resetReplicaAllSQL,
+		showReplicaStatusSQL:                showReplicaStatusSQL,
+		setVariableSQLTemplate:              setPersistVariableSQLTemplate,
+		ioThreadRunningField:                ReplicaIOThreadRunningField,
+		sqlThreadRunningField:               ReplicaSQLThreadRunningField,
+		secondsBehindSourceField:            SecondsBehindSourceField,
+		semiSyncSourceStatusVariable:        SemiSyncMasterStatusVariable,
+		semiSyncReplicaStatusVariable:       SemiSyncSlaveStatusVariable,
+		semiSyncSourceEnabledVariable:       semiSyncMasterEnabledVariable,
+		semiSyncReplicaEnabledVariable:      semiSyncSlaveEnabledVariable,
+	},
+	// start replica, stop replica, reset replica and show replica status are available since 8.0.22
+	{
+		minVersion:                          version.Must(version.NewVersion(minReplicaStatementMySQLVersionStr)),
+		changeSourceSQLTemplate:             changeMasterSQLTemplate,
+		groupReplicationRecoverySQLTemplate: changeGroupReplicationRecoveryMasterSQLTemplate,
+		groupReplicationMemberRoleSQL:       getGroupReplicationMemberRoleSQL,
+		startReplicaSQL:                     startReplicaSQL,
+		stopReplicaSQL:                      stopReplicaSQL,
+		resetReplicaAllSQL:                  resetReplicaAllSQL,
+		showReplicaStatusSQL:                showReplicaStatusSQL,
+		setVariableSQLTemplate:              setPersistVariableSQLTemplate,
+		ioThreadRunningField:                ReplicaIOThreadRunningField,
+		sqlThreadRunningField:               ReplicaSQLThreadRunningField,
+		secondsBehindSourceField:            SecondsBehindSourceField,
+		semiSyncSourceStatusVariable:        SemiSyncMasterStatusVariable,
+		semiSyncReplicaStatusVariable:       SemiSyncSlaveStatusVariable,
+		semiSyncSourceEnabledVariable:       semiSyncMasterEnabledVariable,
+		semiSyncReplicaEnabledVariable:      semiSyncSlaveEnabledVariable,
+	},
+	// set persist and the public key option of the replication channel are available since 8.0.4
+	{
+		minVersion:                          version.Must(version.NewVersion(minGetSourcePublicKeyMySQLVersionStr)),
+		changeSourceSQLTemplate:             changeMasterSQLTemplate,
+		groupReplicationRecoverySQLTemplate: changeGroupReplicationRecoveryMasterSQLTemplate,
+		groupReplicationMemberRoleSQL:       getGroupReplicationMemberRoleSQL,
+		startReplicaSQL:                     startSlaveSQL,
+		stopReplicaSQL:                      stopSlaveSQL,
+		resetReplicaAllSQL:                  resetSlaveAllSQL,
+		showReplicaStatusSQL:                showSlaveStatusSQL,
+		setVariableSQLTemplate:              setPersistVariableSQLTemplate,
+		ioThreadRunningField:                SlaveIOThreadRunningField,
+		sqlThreadRunningField:               SlaveSQLThreadRunningField,
+		secondsBehindSourceField:            SecondsBehindMasterField,
+		semiSyncSourceStatusVariable:        SemiSyncMasterStatusVariable,
+		semiSyncReplicaStatusVariable:       SemiSyncSlaveStatusVariable,
+		semiSyncSourceEnabledVariable:       semiSyncMasterEnabledVariable,
+		semiSyncReplicaEnabledVariable:      semiSyncSlaveEnabledVariable,
+	},
+	// 5.7 only supports the master/slave terms, and the variables could not be persisted
+	{
+		minVersion:                          version.Must(version.NewVersion(minReplicationSyntaxMySQLVersionStr)),
+		changeSourceSQLTemplate:             changeMasterSQLTemplate,
+		groupReplicationRecoverySQLTemplate: changeGroupReplicationRecovery57SQLTemplate,
+		groupReplicationMemberRoleSQL:       getGroupReplicationMemberRole57SQL,
+		startReplicaSQL:                     startSlaveSQL,
+		stopReplicaSQL:                      stopSlaveSQL,
+		resetReplicaAllSQL:                  resetSlaveAllSQL,
+		showReplicaStatusSQL:                showSlaveStatusSQL,
+		setVariableSQLTemplate:              setGlobalVariableSQLTemplate,
+		ioThreadRunningField:                SlaveIOThreadRunningField,
+		sqlThreadRunningField:               SlaveSQLThreadRunningField,
+		secondsBehindSourceField:            SecondsBehindMasterField,
+		semiSyncSourceStatusVariable:        SemiSyncMasterStatusVariable,
+		semiSyncReplicaStatusVariable:       SemiSyncSlaveStatusVariable,
+		semiSyncSourceEnabledVariable:       semiSyncMasterEnabledVariable,
+		semiSyncReplicaEnabledVariable:      semiSyncSlaveEnabledVariable,
+	},
+}
+
+// getReplicationSyntax returns the replication syntax of the given mysql version
+func getReplicationSyntax(v *version.Version) *replicationSyntax {
+	// the pre-release part of the version is ignored, e.g. 8.0.23-log is treated as 8.0.23
+	core := v.Core()
+	for _, rs := range replicationSyntaxes {
+		if core.GreaterThanOrEqual(rs.minVersion) {
+			return rs
+		}
+	}
+
+	return replicationSyntaxes[len(replicationSyntaxes)-constant.OneInt]
+}
+
+// getReplicationSyntaxByVersionStr returns the replication syntax of the given mysql version string
+func getReplicationSyntaxByVersionStr(versionStr string) (*replicationSyntax, error) {
+	v, err := version.NewVersion(versionStr)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return getReplicationSyntax(v), nil
+}
+
+// getConnReplicationSyntax returns the replication syntax of the running mysql server which the connection connects to
+func getConnReplicationSyntax(conn *mysql.Conn) (*replicationSyntax, error) {
+	result, err := conn.Execute(getVersionSQL)
+	if err != nil {
+		return nil, err
+	}
+	versionStr, err := result.GetString(constant.ZeroInt, constant.ZeroInt)
+	if err != nil {
+		return nil, err
+	}
+
+	return getReplicationSyntaxByVersionStr(versionStr)
+}
+
+// getChangeSourceSQL returns the sql which makes the replica replicate from the source with gtid auto position
+func (rs *replicationSyntax) getChangeSourceSQL(sourceHostIP string, sourcePortNum int, replicationUser, replicationPass string) string {
+	return fmt.Sprintf(rs.changeSourceSQLTemplate, sourceHostIP, sourcePortNum, replicationUser, replicationPass)
+}
+
+// getGroupReplicationRecoverySQL returns the sql which configures the recovery channel of the group replication
+func (rs *replicationSyntax) getGroupReplicationRecoverySQL(replicationUser, replicationPass string) string {
+	return fmt.Sprintf(rs.groupReplicationRecoverySQLTemplate, replicationUser, replicationPass)
+}
+
+// getSetVariableSQL returns the sql which sets the global variable, the variable is persisted if the version supports it
+func (rs *replicationSyntax) getSetVariableSQL(name, value string) string {
+	return fmt.Sprintf(rs.setVariableSQLTemplate, name, value)
+}
+
+// getSetSemiSyncSQLs returns the sqls which enable or disable the semi-sync source and replica
+func (rs *replicationSyntax) getSetSemiSyncSQLs(sourceEnabled, replicaEnabled bool) []string {
+	return []string{
+		rs.getSetVariableSQL(rs.semiSyncSourceEnabledVariable, getSwitchValue(sourceEnabled)),
+		rs.getSetVariableSQL(rs.semiSyncReplicaEnabledVariable, getSwitchValue(replicaEnabled)),
+	}
+}
+
+// getSwitchValue returns the on or off value of the boolean variable
+func getSwitchValue(enabled bool) string {
+	if enabled {
+		return variableOnValue
+	}
+
+	return variableOffValue
+}
@@ -0,0 +1,41 @@
+package mysql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplication_All(t *testing.T) {
+	TestGetReplicationSyntax(t)
+}
+
+func TestGetReplicationSyntax(t *testing.T) {
+	asst := assert.New(t)
+
+	testCases := []struct {
+		version                      string
+		showReplicaStatusSQL         string
+		changeSourceSQLTemplate      string
+		ioThreadRunningField         string
+		semiSyncSourceStatusVariable string
+		setVariableSQLTemplate       string
+	}{
+		{"5.7.44-log", showSlaveStatusSQL, changeMasterSQLTemplate, SlaveIOThreadRunningField, SemiSyncMasterStatusVariable, setGlobalVariableSQLTemplate},
+		{"8.0.21", showSlaveStatusSQL, changeMasterSQLTemplate, SlaveIOThreadRunningField, SemiSyncMasterStatusVariable, setPersistVariableSQLTemplate},
+		{"8.0.22", showReplicaStatusSQL, changeMasterSQLTemplate, ReplicaIOThreadRunningField, SemiSyncMasterStatusVariable, setPersistVariableSQLTemplate},
+		{"8.0.23-log", showReplicaStatusSQL, changeReplicationSourceSQLTemplate, ReplicaIOThreadRunningField, SemiSyncMasterStatusVariable, setPersistVariableSQLTemplate},
+		{"8.0.26", showReplicaStatusSQL, changeReplicationSourceSQLTemplate, ReplicaIOThreadRunningField, SemiSyncSourceStatusVariable, setPersistVariableSQLTemplate},
+		{"8.4.3", showReplicaStatusSQL, changeReplicationSourceSQLTemplate, ReplicaIOThreadRunningField, SemiSyncSourceStatusVariable, setPersistVariableSQLTemplate},
+		{"9.1.0", showReplicaStatusSQL, changeReplicationSourceSQLTemplate, ReplicaIOThreadRunningField, SemiSyncSourceStatusVariable, setPersistVariableSQLTemplate},
+	}
+	for _, testCase := range testCases {
+		rs, err := getReplicationSyntaxByVersionStr(testCase.version)
+		asst.Nil(err, "test getReplicationSyntax() failed")
+		asst.Equal(testCase.showReplicaStatusSQL, rs.showReplicaStatusSQL, "test getReplicationSyntax() failed, version: %s", testCase.version)
+		asst.Equal(testCase.changeSourceSQLTemplate, rs.changeSourceSQLTemplate, "test getReplicationSyntax() failed, version: %s", testCase.version)
+		asst.Equal(testCase.ioThreadRunningField, rs.ioThreadRunningField, "test getReplicationSyntax() failed, version: %s", testCase.version)
+		asst.Equal(testCase.semiSyncSourceStatusVariable, rs.semiSyncSourceStatusVariable, "test getReplicationSyntax() failed, version: %s", testCase.version)
+		asst.Equal(testCase.setVariableSQLTemplate, rs.setVariableSQLTemplate, "test getReplicationSyntax() failed, version: %s", testCase.version)
+	}
+}
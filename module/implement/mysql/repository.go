@@ -20,10 +20,35 @@ const (
 	defaultUpgradeOperation
 	defaultRemoveInstanceOperation
 	defaultRemoveBinaryOperation
+	defaultAddReplicaOperation
+	defaultSwitchoverOperation
+	defaultFailoverOperation
+	defaultStartOperation
+	defaultStopOperation
+	defaultRestartOperation
+	defaultSetParameterOperation
+	defaultBackupOperation
+	defaultRestoreOperation
+
+	defaultRunningStatus   = 1
+	defaultSuccessStatus   = 2
+	defaultFailedStatus    = 3
+	defaultCancelledStatus = 4
+
+	defaultCancelRequestedFlag = 1
+)
 
-	defaultRunningStatus = 1
-	defaultSuccessStatus = 2
-	defaultFailedStatus  = 3
+const (
+	installStepInitOS = iota + 1
+	installStepInstallBinary
+	installStepInitialize
+	installStepInitUser
+	installStepStartMySQLDMulti
+	installStepReplication
+	installStepPMM
+	installStepClone
+	installStepRestore
+	installStepReplayBinlog
 )
 
 type DBORepo struct {
@@ -70,42 +95,74 @@ func (dr *DBORepo) Transaction() (middleware.Transaction, error) {
 
 // GetOperationHistory gets the mysql operation history from the middleware
 func (dr *DBORepo) GetOperationHistory(id int) (*OperationInfo, error) {
+	operationInfoList, err := dr.getOperationHistories(`WHERE del_flag = 0 AND id = ?`, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(operationInfoList) == constant.ZeroInt {
+		return nil, errors.Errorf("mysql DBORepo.GetOperationHistory(): no operation history found. id: %d", id)
+	}
+
+	return operationInfoList[constant.ZeroInt], nil
+}
+
+// GetRunningOperationHistories gets the mysql operation histories which are still running from the middleware
+func (dr *DBORepo) GetRunningOperationHistories() ([]*OperationInfo, error) {
+	return dr.getOperationHistories(`WHERE del_flag = 0 AND status = ? ORDER BY id ASC`, defaultRunningStatus)
+}
+
+// getOperationHistories gets the mysql operation histories which match the given condition from the middleware
+func (dr *DBORepo) getOperationHistories(condition string, args ...interface{}) ([]*OperationInfo, error) {
 	sql := `
 		SELECT id,
 			   operation_type,
 			   addrs,
+			   owner,
 			   status,
+			   cancel_flag,
 			   message,
+			   request_body,
 			   del_flag,
 			   create_time,
 			   last_update_time
 		FROM t_mysql_operation_info
-		WHERE del_flag = 0
-		  AND id = ?
-		ORDER BY id DESC
-	`
-	log.Debugf("mysql DBORepo.GetOperationHistory() select sql: \n%s\nplaceholders: %d", sql, id)
+	` + condition
+	log.Debugf("mysql DBORepo.getOperationHistories() select sql: \n%s\nplaceholders: %v", sql, args)
 
-	result, err := dr.Execute(sql, id)
+	result, err := dr.Execute(sql, args...)
 	if err != nil {
 		return nil, err
 	}
 
-	if result.RowNumber() == constant.ZeroInt {
-		return nil, errors.Errorf("mysql DBORepo.GetOperationHistory(): no operation history found. id: %d", id)
+	operationInfoList := make([]*OperationInfo, result.RowNumber())
+	for i := constant.ZeroInt; i < result.RowNumber(); i++ {
+		operationInfoList[i] = NewOperationInfoWithDefault()
 	}
 
-	operationInfo := NewOperationInfoWithDefault()
-	err = result.MapToStructByRowIndex(operationInfo, constant.ZeroInt, constant.DefaultMiddlewareTag)
+	err = result.MapToStructSlice(operationInfoList, constant.DefaultMiddlewareTag)
 	if err != nil {
 		return nil, err
 	}
 
-	return operationInfo, nil
+	return operationInfoList, nil
 }
 
 // GetOperationDetails gets the mysql operation detail from the middleware
 func (dr *DBORepo) GetOperationDetails(operationID int) ([]*OperationDetail, error) {
+	operationDetailList, err := dr.getOperationDetails(operationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(operationDetailList) == constant.ZeroInt {
+		return nil, errors.Errorf("mysql DBORepo.GetOperationDetails(): no operation history detail found. id: %d", operationID)
+	}
+
+	return operationDetailList, nil
+}
+
+// getOperationDetails gets the mysql operation detail from the middleware, it returns an empty slice if no detail found
+func (dr *DBORepo) getOperationDetails(operationID int) ([]*OperationDetail, error) {
 	sql := `
 		SELECT id,
 			   operation_id,
@@ -121,17 +178,13 @@ func (dr *DBORepo) GetOperationDetails(operationID int) ([]*OperationDetail, err
 		  AND operation_id = ?
 		ORDER BY id ASC
 	`
-	log.Debugf("mysql DBORepo.GetOperationDetails() select sql: \n%s\nplaceholders: %d", sql, operationID)
+	log.Debugf("mysql DBORepo.getOperationDetails() select sql: \n%s\nplaceholders: %d", sql, operationID)
 
 	result, err := dr.Execute(sql, operationID)
 	if err != nil {
 		return nil, err
 	}
 
-	if result.RowNumber() == constant.ZeroInt {
-		return nil, errors.Errorf("mysql DBORepo.GetOperationDetails(): no operation history detail found. id: %d", operationID)
-	}
-
 	operationDetailList := make([]*OperationDetail, result.RowNumber())
 	for i := constant.ZeroInt; i < result.RowNumber(); i++ {
 		operationDetailList[i] = NewOperationDetailWithDefault()
@@ -145,25 +198,34 @@ func (dr *DBORepo) GetOperationDetails(operationID int) ([]*OperationDetail, err
 	return operationDetailList, nil
 }
 
-// GetLock gets the operation lock of the given host info
+// GetLock gets the operation lock of the given host info, the lock is owned by this db-operator process,
+// the expired locks of the addrs are purged first, so that a crashed process will not block the addrs after its lease expired
 func (dr *DBORepo) GetLock(operationID int, addrs []string) error {
+	if len(addrs) == constant.ZeroInt {
+		return message.NewMessage(msgMySQL.ErrMySQLRepositoryGetLock, errors.New("addrs should not be empty"), operationID, addrs)
+	}
+	err := dr.purgeExpiredLocks(addrs)
+	if err != nil {
+		return message.NewMessage(msgMySQL.ErrMySQLRepositoryGetLock, err, operationID, addrs)
+	}
 	// prepare sql
-	sql := `INSERT INTO t_mysql_operation_lock(operation_id, addr) VALUES`
+	sql := `INSERT INTO t_mysql_operation_lock(operation_id, addr, owner) VALUES`
 	for i := constant.ZeroInt; i < len(addrs); i++ {
-		sql = sql + `(?, ?),`
+		sql = sql + `(?, ?, ?),`
 	}
 	sql = strings.Trim(sql, constant.CommaString) + constant.SemicolonString
 	// prepare placeholders
-	placeHolders := make([]interface{}, len(addrs)*constant.TwoInt)
+	placeHolders := make([]interface{}, len(addrs)*constant.ThreeInt)
 	for i := constant.ZeroInt; i < len(addrs); i++ {
-		placeHolders[i*constant.TwoInt] = operationID
-		placeHolders[i*constant.TwoInt+constant.OneInt] = addrs[i]
+		placeHolders[i*constant.ThreeInt] = operationID
+		placeHolders[i*constant.ThreeInt+constant.OneInt] = addrs[i]
+		placeHolders[i*constant.ThreeInt+constant.TwoInt] = global.Owner
 	}
-	log.Debugf("mysql DBORepo.GetLock() insert sql: \n%s\noperation_id: %d, addrs: %v",
-		sql, operationID, common.ConvertSliceToString(addrs, constant.CommaString))
+	log.Debugf("mysql DBORepo.GetLock() insert sql: \n%s\noperation_id: %d, addrs: %v, owner: %s",
+		sql, operationID, common.ConvertSliceToString(addrs, constant.CommaString), global.Owner)
 
 	// execute sql
-	_, err := dr.Execute(sql, placeHolders...)
+	_, err = dr.Execute(sql, placeHolders...)
 	if err != nil {
 		return message.NewMessage(msgMySQL.ErrMySQLRepositoryGetLock, err, operationID, addrs)
 	}
@@ -171,6 +233,60 @@ func (dr *DBORepo) GetLock(operationID int, addrs []string) error {
 	return nil
 }
 
+// purgeExpiredLocks deletes the locks of the addrs which have not been renewed within the lease timeout
+func (dr *DBORepo) purgeExpiredLocks(addrs []string) error {
+	if len(addrs) == constant.ZeroInt {
+		return nil
+	}
+	sql := `DELETE FROM t_mysql_operation_lock WHERE last_update_time < ? AND addr IN (?` +
+		strings.Repeat(`, ?`, len(addrs)-constant.OneInt) + `) ;`
+	placeHolders := make([]interface{}, len(addrs)+constant.OneInt)
+	placeHolders[constant.ZeroInt] = getLockExpireTime()
+	for i, addr := range addrs {
+		placeHolders[i+constant.OneInt] = addr
+	}
+	log.Debugf("mysql DBORepo.purgeExpiredLocks() delete sql: \n%s\nplaceholders: %v", sql, placeHolders)
+
+	_, err := dr.Execute(sql, placeHolders...)
+
+	return err
+}
+
+// RenewLock renews the lease of the operation lock which is owned by this db-operator process,
+// it returns the number of the renewed locks, zero means the lock has been lost
+func (dr *DBORepo) RenewLock(operationID int) (int, error) {
+	sql := `UPDATE t_mysql_operation_lock SET last_update_time = NOW(6) WHERE operation_id = ? AND owner = ? ;`
+	log.Debugf("mysql DBORepo.RenewLock() update sql: \n%s\nplaceholders: %d, %s", sql, operationID, global.Owner)
+
+	result, err := dr.Execute(sql, operationID, global.Owner)
+	if err != nil {
+		return constant.ZeroInt, message.NewMessage(msgMySQL.ErrMySQLRepositoryRenewLock, err, operationID, global.Owner)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return constant.ZeroInt, message.NewMessage(msgMySQL.ErrMySQLRepositoryRenewLock, err, operationID, global.Owner)
+	}
+
+	return rowsAffected, nil
+}
+
+// HasAliveLock returns if the operation holds any lock which has been renewed within the lease timeout
+func (dr *DBORepo) HasAliveLock(operationID int) (bool, error) {
+	sql := `SELECT count(*) FROM t_mysql_operation_lock WHERE operation_id = ? AND last_update_time >= ? ;`
+	log.Debugf("mysql DBORepo.HasAliveLock() select sql: \n%s\nplaceholders: %d", sql, operationID)
+
+	result, err := dr.Execute(sql, operationID, getLockExpireTime())
+	if err != nil {
+		return false, err
+	}
+	count, err := result.GetInt(constant.ZeroInt, constant.ZeroInt)
+	if err != nil {
+		return false, err
+	}
+
+	return count > constant.ZeroInt, nil
+}
+
 // ReleaseLock releases the operation lock of the given host info
 func (dr *DBORepo) ReleaseLock(operationID int) error {
 	sql := `DELETE FROM t_mysql_operation_lock WHERE operation_id = ? ;`
@@ -184,14 +300,80 @@ func (dr *DBORepo) ReleaseLock(operationID int) error {
 	return nil
 }
 
-// InitOperationHistory initializes the mysql operation history in the middleware
-func (dr *DBORepo) InitOperationHistory(operationType int, addrs []string) (int, error) {
+// GetLocks gets all the operation locks from the middleware, the locks which have not been renewed within the lease timeout are marked as expired
+func (dr *DBORepo) GetLocks() ([]*OperationLock, error) {
+	return dr.getLocks(constant.EmptyString)
+}
+
+// GetLockByAddr gets the operation lock of the addr from the middleware, it returns nil if the addr is not locked
+func (dr *DBORepo) GetLockByAddr(addr string) (*OperationLock, error) {
+	locks, err := dr.getLocks(`WHERE addr = ?`, addr)
+	if err != nil {
+		return nil, err
+	}
+	if len(locks) == constant.ZeroInt {
+		return nil, nil
+	}
+
+	return locks[constant.ZeroInt], nil
+}
+
+// getLocks gets the operation locks which match the given condition from the middleware
+func (dr *DBORepo) getLocks(condition string, args ...interface{}) ([]*OperationLock, error) {
+	sql := `
+		SELECT id,
+			   operation_id,
+			   addr,
+			   owner,
+			   IF(last_update_time < ?, 1, 0) AS expired,
+			   del_flag,
+			   create_time,
+			   last_update_time
+		FROM t_mysql_operation_lock
+	` + condition + `
+		ORDER BY id ASC
+	`
+	args = append([]interface{}{getLockExpireTime()}, args...)
+	log.Debugf("mysql DBORepo.getLocks() select sql: \n%s\nplaceholders: %v", sql, args)
+
+	result, err := dr.Execute(sql, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	lockList := make([]*OperationLock, result.RowNumber())
+	for i := constant.ZeroInt; i < result.RowNumber(); i++ {
+		lockList[i] = NewOperationLockWithDefault()
+	}
+
+	err = result.MapToStructSlice(lockList, constant.DefaultMiddlewareTag)
+	if err != nil {
+		return nil, err
+	}
+
+	return lockList, nil
+}
+
+// ForceReleaseLock releases the operation lock of the addr no matter which process owns it,
+// it is used by the operators to unblock the addr when the owner could not release the lock by itself
+func (dr *DBORepo) ForceReleaseLock(addr string) error {
+	sql := `DELETE FROM t_mysql_operation_lock WHERE addr = ? ;`
+	log.Debugf("mysql DBORepo.ForceReleaseLock() delete sql: \n%s\nplaceholders: %s", sql, addr)
+
+	_, err := dr.Execute(sql, addr)
+
+	return err
+}
+
+// InitOperationHistory initializes the mysql operation history in the middleware, the operation is owned by this db-operator process,
+// the request body will be stored with the operation, so that the operation could be resumed later
+func (dr *DBORepo) InitOperationHistory(operationType int, addrs []string, requestBody string) (int, error) {
 	addrsStr := common.ConvertSliceToString(addrs, constant.CommaString)
-	sql := `INSERT INTO t_mysql_operation_info(operation_type, addrs, status) VALUES(?, ?, ?) ;`
-	log.Debugf("mysql DBORepo.InitOperationHistory() insert sql: \n%s\nplaceholders: %d, %s",
-		sql, operationType, addrsStr, defaultRunningStatus)
+	sql := `INSERT INTO t_mysql_operation_info(operation_type, addrs, owner, status, request_body) VALUES(?, ?, ?, ?, ?) ;`
+	log.Debugf("mysql DBORepo.InitOperationHistory() insert sql: \n%s\nplaceholders: %d, %s, %s, %d",
+		sql, operationType, addrsStr, global.Owner, defaultRunningStatus)
 
-	result, err := dr.Execute(sql, operationType, addrsStr, defaultRunningStatus)
+	result, err := dr.Execute(sql, operationType, addrsStr, global.Owner, defaultRunningStatus, requestBody)
 	if err != nil {
 		return constant.ZeroInt, err
 	}
@@ -210,6 +392,182 @@ func (dr *DBORepo) UpdateOperationHistory(id int, status int, message string) er
 	return err
 }
 
+// ResumeOperationHistory marks the mysql operation history as running again, and the operation is owned by this db-operator process
+func (dr *DBORepo) ResumeOperationHistory(id int) error {
+	sql := `UPDATE t_mysql_operation_info SET owner = ?, status = ?, cancel_flag = 0, message = ? WHERE id = ? ;`
+	log.Debugf("mysql DBORepo.ResumeOperationHistory() update sql: \n%s\nplaceholders: %s, %d, %s, %d",
+		sql, global.Owner, defaultRunningStatus, constant.EmptyString, id)
+
+	_, err := dr.Execute(sql, global.Owner, defaultRunningStatus, constant.EmptyString, id)
+
+	return err
+}
+
+// CancelOperation requests the running mysql operation to be cancelled, the process which runs the operation
+// will notice the request within a heartbeat of the operation lock and stop at the next step boundary
+func (dr *DBORepo) CancelOperation(id int) error {
+	sql := `UPDATE t_mysql_operation_info SET cancel_flag = ? WHERE id = ? AND status = ? ;`
+	log.Debugf("mysql DBORepo.CancelOperation() update sql: \n%s\nplaceholders: %d, %d, %d",
+		sql, defaultCancelRequestedFlag, id, defaultRunningStatus)
+
+	_, err := dr.Execute(sql, defaultCancelRequestedFlag, id, defaultRunningStatus)
+	if err != nil {
+		return message.NewMessage(msgMySQL.ErrMySQLRepositoryCancelOperation, err, id)
+	}
+
+	return nil
+}
+
+// IsCancelRequested returns if the cancellation of the mysql operation has been requested
+func (dr *DBORepo) IsCancelRequested(id int) (bool, error) {
+	sql := `SELECT cancel_flag FROM t_mysql_operation_info WHERE id = ? ;`
+	log.Debugf("mysql DBORepo.IsCancelRequested() select sql: \n%s\nplaceholders: %d", sql, id)
+
+	result, err := dr.Execute(sql, id)
+	if err != nil {
+		return false, err
+	}
+	if result.RowNumber() == constant.ZeroInt {
+		return false, nil
+	}
+	cancelFlag, err := result.GetInt(constant.ZeroInt, constant.ZeroInt)
+	if err != nil {
+		return false, err
+	}
+
+	return cancelFlag == defaultCancelRequestedFlag, nil
+}
+
+// FailOrphanedOperation marks the running mysql operation history and its running details as failed with the message,
+// and releases its operation lock, it is used when the process which ran the operation has gone
+func (dr *DBORepo) FailOrphanedOperation(id int, msg string) error {
+	sqls := []string{
+		`UPDATE t_mysql_operation_info SET status = ?, message = ? WHERE id = ? AND status = ? ;`,
+		`UPDATE t_mysql_operation_detail SET status = ?, message = ? WHERE operation_id = ? AND status = ? ;`,
+	}
+
+	tx, err := dr.Transaction()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = tx.Close()
+		if err != nil {
+			log.Errorf("mysql DBORepo.FailOrphanedOperation(): close database connection failed.\n%+v", err)
+		}
+	}()
+
+	err = tx.Begin()
+	if err != nil {
+		return err
+	}
+	err = dr.failOrphanedOperation(tx, sqls, id, msg)
+	if err != nil {
+		rollbackErr := tx.Rollback()
+		if rollbackErr != nil {
+			log.Errorf("mysql DBORepo.FailOrphanedOperation(): rollback failed.\n%+v", rollbackErr)
+		}
+		return message.NewMessage(msgMySQL.ErrMySQLRepositoryFailOrphanedOperation, err, id)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return message.NewMessage(msgMySQL.ErrMySQLRepositoryFailOrphanedOperation, err, id)
+	}
+
+	return nil
+}
+
+// failOrphanedOperation executes the sqls of failing the orphaned operation in the transaction
+func (dr *DBORepo) failOrphanedOperation(tx middleware.Transaction, sqls []string, id int, msg string) error {
+	for _, sql := range sqls {
+		log.Debugf("mysql DBORepo.failOrphanedOperation() update sql: \n%s\nplaceholders: %d, %s, %d, %d",
+			sql, defaultFailedStatus, msg, id, defaultRunningStatus)
+		_, err := tx.Execute(sql, defaultFailedStatus, msg, id, defaultRunningStatus)
+		if err != nil {
+			return err
+		}
+	}
+
+	sql := `DELETE FROM t_mysql_operation_lock WHERE operation_id = ? ;`
+	log.Debugf("mysql DBORepo.failOrphanedOperation() delete sql: \n%s\nplaceholders: %d", sql, id)
+	_, err := tx.Execute(sql, id)
+
+	return err
+}
+
+// PurgeOperationHistory deletes the finished mysql operation histories which were created before the given time,
+// including their details and steps, the operations which are still referenced by the backup sets or the fenced instances are kept,
+// it returns the number of the purged operation histories
+func (dr *DBORepo) PurgeOperationHistory(minTime string) (int, error) {
+	tx, err := dr.Transaction()
+	if err != nil {
+		return constant.ZeroInt, err
+	}
+	defer func() {
+		err = tx.Close()
+		if err != nil {
+			log.Errorf("mysql DBORepo.PurgeOperationHistory(): close database connection failed.\n%+v", err)
+		}
+	}()
+
+	err = tx.Begin()
+	if err != nil {
+		return constant.ZeroInt, err
+	}
+	count, err := dr.purgeOperationHistory(tx, minTime)
+	if err != nil {
+		rollbackErr := tx.Rollback()
+		if rollbackErr != nil {
+			log.Errorf("mysql DBORepo.PurgeOperationHistory(): rollback failed.\n%+v", rollbackErr)
+		}
+		return constant.ZeroInt, message.NewMessage(msgMySQL.ErrMySQLRepositoryPurgeOperation, err, minTime)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return constant.ZeroInt, message.NewMessage(msgMySQL.ErrMySQLRepositoryPurgeOperation, err, minTime)
+	}
+
+	return count, nil
+}
+
+// purgeOperationHistory executes the sqls of purging the operation histories in the transaction
+func (dr *DBORepo) purgeOperationHistory(tx middleware.Transaction, minTime string) (int, error) {
+	condition := `
+		WHERE status <> ?
+		  AND create_time < ?
+		  AND id NOT IN (SELECT operation_id FROM t_mysql_backup WHERE del_flag = 0)
+		  AND id NOT IN (SELECT operation_id FROM t_mysql_fenced_instance WHERE del_flag = 0)
+	`
+	countSQL := `SELECT count(*) FROM t_mysql_operation_info ` + condition
+	log.Debugf("mysql DBORepo.purgeOperationHistory() select sql: \n%s\nplaceholders: %d, %s", countSQL, defaultRunningStatus, minTime)
+	result, err := tx.Execute(countSQL, defaultRunningStatus, minTime)
+	if err != nil {
+		return constant.ZeroInt, err
+	}
+	count, err := result.GetInt(constant.ZeroInt, constant.ZeroInt)
+	if err != nil {
+		return constant.ZeroInt, err
+	}
+
+	// the details and steps must be deleted before the operation histories
+	sqls := []string{
+		`DELETE FROM t_mysql_operation_step WHERE operation_id IN (SELECT id FROM (SELECT id FROM t_mysql_operation_info ` + condition + `) t) ;`,
+		`DELETE FROM t_mysql_operation_detail WHERE operation_id IN (SELECT id FROM (SELECT id FROM t_mysql_operation_info ` + condition + `) t) ;`,
+		`DELETE FROM t_mysql_operation_info ` + condition + ` ;`,
+	}
+	for _, sql := range sqls {
+		log.Debugf("mysql DBORepo.purgeOperationHistory() delete sql: \n%s\nplaceholders: %d, %s", sql, defaultRunningStatus, minTime)
+		_, err = tx.Execute(sql, defaultRunningStatus, minTime)
+		if err != nil {
+			return constant.ZeroInt, err
+		}
+	}
+
+	return count, nil
+}
+
 // InitOperationDetail initializes the mysql operation detail in the middleware
 func (dr *DBORepo) InitOperationDetail(operationID int, hostIP string, portNum int) (int, error) {
 	sql := `INSERT INTO t_mysql_operation_detail(operation_id, host_ip, port_num, status) VALUES(?, ?, ?, ?) ;`
@@ -234,3 +592,346 @@ func (dr *DBORepo) UpdateOperationDetail(operationDetailID int, status int, mess
 
 	return err
 }
+
+// GetOperationSteps gets the mysql operation steps of all the hosts from the middleware, it returns an empty slice if no step found
+func (dr *DBORepo) GetOperationSteps(operationID int) ([]*OperationStep, error) {
+	sql := `
+		SELECT id,
+			   operation_id,
+			   host_ip,
+			   port_num,
+			   step,
+			   status,
+			   message,
+			   del_flag,
+			   create_time,
+			   last_update_time
+		FROM t_mysql_operation_step
+		WHERE del_flag = 0
+		  AND operation_id = ?
+		ORDER BY id ASC
+	`
+	log.Debugf("mysql DBORepo.GetOperationSteps() select sql: \n%s\nplaceholders: %d", sql, operationID)
+
+	result, err := dr.Execute(sql, operationID)
+	if err != nil {
+		return nil, err
+	}
+
+	operationStepList := make([]*OperationStep, result.RowNumber())
+	for i := constant.ZeroInt; i < result.RowNumber(); i++ {
+		operationStepList[i] = NewOperationStepWithDefault()
+	}
+
+	err = result.MapToStructSlice(operationStepList, constant.DefaultMiddlewareTag)
+	if err != nil {
+		return nil, err
+	}
+
+	return operationStepList, nil
+}
+
+// SaveOperationStep saves the status of the mysql operation step of the host in the middleware
+func (dr *DBORepo) SaveOperationStep(operationID int, hostIP string, portNum, step, status int, message string) error {
+	sql := `
+		INSERT INTO t_mysql_operation_step(operation_id, host_ip, port_num, step, status, message) VALUES(?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE status = VALUES(status), message = VALUES(message), del_flag = 0 ;
+	`
+	log.Debugf("mysql DBORepo.SaveOperationStep() insert sql: \n%s\nplaceholders: %d, %s, %d, %d, %d, %s",
+		sql, operationID, hostIP, portNum, step, status, message)
+
+	_, err := dr.Execute(sql, operationID, hostIP, portNum, step, status, message)
+
+	return err
+}
+
+// DeleteOperationStep deletes the mysql operation step of the host in the middleware
+func (dr *DBORepo) DeleteOperationStep(operationID int, hostIP string, portNum, step int) error {
+	sql := `UPDATE t_mysql_operation_step SET del_flag = 1 WHERE operation_id = ? AND host_ip = ? AND port_num = ? AND step = ? ;`
+	log.Debugf("mysql DBORepo.DeleteOperationStep() update sql: \n%s\nplaceholders: %d, %s, %d, %d",
+		sql, operationID, hostIP, portNum, step)
+
+	_, err := dr.Execute(sql, operationID, hostIP, portNum, step)
+
+	return err
+}
+
+// GetFencedInstances gets all the fenced mysql instances from the middleware, it returns an empty slice if no instance is fenced
+func (dr *DBORepo) GetFencedInstances() ([]*FencedInstance, error) {
+	sql := `
+		SELECT id,
+			   operation_id,
+			   host_ip,
+			   port_num,
+			   reason,
+			   del_flag,
+			   create_time,
+			   last_update_time
+		FROM t_mysql_fenced_instance
+		WHERE del_flag = 0
+		ORDER BY id ASC
+	`
+	log.Debugf("mysql DBORepo.GetFencedInstances() select sql: \n%s", sql)
+
+	result, err := dr.Execute(sql)
+	if err != nil {
+		return nil, err
+	}
+
+	fencedInstanceList := make([]*FencedInstance, result.RowNumber())
+	for i := constant.ZeroInt; i < result.RowNumber(); i++ {
+		fencedInstanceList[i] = NewFencedInstanceWithDefault()
+	}
+
+	err = result.MapToStructSlice(fencedInstanceList, constant.DefaultMiddlewareTag)
+	if err != nil {
+		return nil, err
+	}
+
+	return fencedInstanceList, nil
+}
+
+// FenceInstance marks the mysql instance as fenced in the middleware,
+// the fenced instance will not be reattached to any cluster until it is unfenced
+func (dr *DBORepo) FenceInstance(operationID int, hostIP string, portNum int, reason string) error {
+	sql := `
+		INSERT INTO t_mysql_fenced_instance(operation_id, host_ip, port_num, reason) VALUES(?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE operation_id = VALUES(operation_id), reason = VALUES(reason), del_flag = 0 ;
+	`
+	log.Debugf("mysql DBORepo.FenceInstance() insert sql: \n%s\nplaceholders: %d, %s, %d, %s",
+		sql, operationID, hostIP, portNum, reason)
+
+	_, err := dr.Execute(sql, operationID, hostIP, portNum, reason)
+	if err != nil {
+		return message.NewMessage(msgMySQL.ErrMySQLRepositoryFenceInstance, err, operationID, hostIP, portNum)
+	}
+
+	return nil
+}
+
+// UnfenceInstance removes the fenced mark of the mysql instance in the middleware
+func (dr *DBORepo) UnfenceInstance(hostIP string, portNum int) error {
+	sql := `UPDATE t_mysql_fenced_instance SET del_flag = 1 WHERE host_ip = ? AND port_num = ? ;`
+	log.Debugf("mysql DBORepo.UnfenceInstance() update sql: \n%s\nplaceholders: %s, %d", sql, hostIP, portNum)
+
+	_, err := dr.Execute(sql, hostIP, portNum)
+	if err != nil {
+		return message.NewMessage(msgMySQL.ErrMySQLRepositoryUnfenceInstance, err, hostIP, portNum)
+	}
+
+	return nil
+}
+
+// GetParameterProfiles gets all the mysql parameter profiles from the middleware
+func (dr *DBORepo) GetParameterProfiles() ([]*ParameterProfile, error) {
+	sql := `
+		SELECT id,
+			   profile_name,
+			   description,
+			   parameters,
+			   del_flag,
+			   create_time,
+			   last_update_time
+		FROM t_mysql_parameter_profile
+		WHERE del_flag = 0
+		ORDER BY id ASC
+	`
+	log.Debugf("mysql DBORepo.GetParameterProfiles() select sql: \n%s", sql)
+
+	result, err := dr.Execute(sql)
+	if err != nil {
+		return nil, err
+	}
+
+	parameterProfileList := make([]*ParameterProfile, result.RowNumber())
+	for i := constant.ZeroInt; i < result.RowNumber(); i++ {
+		parameterProfileList[i] = NewParameterProfileWithDefault()
+	}
+
+	err = result.MapToStructSlice(parameterProfileList, constant.DefaultMiddlewareTag)
+	if err != nil {
+		return nil, err
+	}
+
+	return parameterProfileList, nil
+}
+
+// GetParameterProfileByName gets the mysql parameter profile of the given name from the middleware
+func (dr *DBORepo) GetParameterProfileByName(profileName string) (*ParameterProfile, error) {
+	sql := `
+		SELECT id,
+			   profile_name,
+			   description,
+			   parameters,
+			   del_flag,
+			   create_time,
+			   last_update_time
+		FROM t_mysql_parameter_profile
+		WHERE del_flag = 0
+		  AND profile_name = ?
+	`
+	log.Debugf("mysql DBORepo.GetParameterProfileByName() select sql: \n%s\nplaceholders: %s", sql, profileName)
+
+	result, err := dr.Execute(sql, profileName)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.RowNumber() == constant.ZeroInt {
+		return nil, errors.Errorf("mysql DBORepo.GetParameterProfileByName(): no parameter profile found. profileName: %s", profileName)
+	}
+
+	parameterProfile := NewParameterProfileWithDefault()
+	err = result.MapToStructByRowIndex(parameterProfile, constant.ZeroInt, constant.DefaultMiddlewareTag)
+	if err != nil {
+		return nil, err
+	}
+
+	return parameterProfile, nil
+}
+
+// InitBackup initializes the mysql backup set in the middleware, it returns the id of the backup set
+func (dr *DBORepo) InitBackup(operationID int, hostIP string, portNum, backupType, backupMethod, baseBackupID int, backupDir string) (int, error) {
+	sql := `
+		INSERT INTO t_mysql_backup(operation_id, host_ip, port_num, backup_type, backup_method, base_backup_id, backup_dir, status)
+		VALUES(?, ?, ?, ?, ?, ?, ?, ?) ;
+	`
+	log.Debugf("mysql DBORepo.InitBackup() insert sql: \n%s\nplaceholders: %d, %s, %d, %d, %d, %d, %s, %d",
+		sql, operationID, hostIP, portNum, backupType, backupMethod, baseBackupID, backupDir, defaultRunningStatus)
+
+	result, err := dr.Execute(sql, operationID, hostIP, portNum, backupType, backupMethod, baseBackupID, backupDir, defaultRunningStatus)
+	if err != nil {
+		return constant.ZeroInt, err
+	}
+
+	return result.LastInsertID()
+}
+
+// UpdateBackup updates the result of the mysql backup set in the middleware, the end time will be set to the current time
+func (dr *DBORepo) UpdateBackup(backupInfo *BackupInfo) error {
+	sql := `
+		UPDATE t_mysql_backup SET from_lsn = ?, to_lsn = ?, gtid_set = ?, backup_size = ?, checksum = ?, status = ?, message = ?, end_time = NOW(6)
+		WHERE id = ? ;
+	`
+	log.Debugf("mysql DBORepo.UpdateBackup() update sql: \n%s\nplaceholders: %d, %d, %s, %d, %s, %d, %s, %d",
+		sql, backupInfo.FromLSN, backupInfo.ToLSN, backupInfo.GTIDSet, backupInfo.BackupSize, backupInfo.Checksum,
+		backupInfo.Status, backupInfo.Message, backupInfo.ID)
+
+	_, err := dr.Execute(sql, backupInfo.FromLSN, backupInfo.ToLSN, backupInfo.GTIDSet, backupInfo.BackupSize, backupInfo.Checksum,
+		backupInfo.Status, backupInfo.Message, backupInfo.ID)
+	if err != nil {
+		return message.NewMessage(msgMySQL.ErrMySQLRepositoryUpdateBackup, err, backupInfo.ID)
+	}
+
+	return nil
+}
+
+// GetBackup gets the mysql backup set of the given id from the middleware
+func (dr *DBORepo) GetBackup(id int) (*BackupInfo, error) {
+	backups, err := dr.getBackups(`WHERE del_flag = 0 AND id = ?`, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(backups) == constant.ZeroInt {
+		return nil, errors.Errorf("mysql DBORepo.GetBackup(): no backup found. id: %d", id)
+	}
+
+	return backups[constant.ZeroInt], nil
+}
+
+// GetBackups gets the mysql backup sets of the instance from the middleware, the latest one is the first
+func (dr *DBORepo) GetBackups(hostIP string, portNum int) ([]*BackupInfo, error) {
+	return dr.getBackups(`WHERE del_flag = 0 AND host_ip = ? AND port_num = ? ORDER BY id DESC`, hostIP, portNum)
+}
+
+// GetLastBackup gets the latest successful mysql backup set of the instance which was taken with the given method,
+// it returns nil if no backup set found
+func (dr *DBORepo) GetLastBackup(hostIP string, portNum, backupMethod int) (*BackupInfo, error) {
+	backups, err := dr.getBackups(`WHERE del_flag = 0 AND host_ip = ? AND port_num = ? AND backup_method = ? AND status = ? ORDER BY id DESC LIMIT 1`,
+		hostIP, portNum, backupMethod, defaultSuccessStatus)
+	if err != nil {
+		return nil, err
+	}
+	if len(backups) == constant.ZeroInt {
+		return nil, nil
+	}
+
+	return backups[constant.ZeroInt], nil
+}
+
+// GetExpiredBackups gets the finished mysql backup sets which ended before the given time, the latest one is the first,
+// so that the incremental backup sets are always purged before their base backup sets
+func (dr *DBORepo) GetExpiredBackups(minTime string) ([]*BackupInfo, error) {
+	return dr.getBackups(`WHERE del_flag = 0 AND status <> ? AND end_time < ? ORDER BY id DESC`, defaultRunningStatus, minTime)
+}
+
+// getBackups gets the mysql backup sets which match the given condition from the middleware
+func (dr *DBORepo) getBackups(condition string, args ...interface{}) ([]*BackupInfo, error) {
+	sql := `
+		SELECT id,
+			   operation_id,
+			   host_ip,
+			   port_num,
+			   backup_type,
+			   backup_method,
+			   base_backup_id,
+			   backup_dir,
+			   from_lsn,
+			   to_lsn,
+			   gtid_set,
+			   backup_size,
+			   checksum,
+			   status,
+			   message,
+			   start_time,
+			   end_time,
+			   del_flag,
+			   create_time,
+			   last_update_time
+		FROM t_mysql_backup
+	` + condition
+	log.Debugf("mysql DBORepo.getBackups() select sql: \n%s\nplaceholders: %v", sql, args)
+
+	result, err := dr.Execute(sql, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	backupList := make([]*BackupInfo, result.RowNumber())
+	for i := constant.ZeroInt; i < result.RowNumber(); i++ {
+		backupList[i] = NewBackupInfoWithDefault()
+	}
+
+	err = result.MapToStructSlice(backupList, constant.DefaultMiddlewareTag)
+	if err != nil {
+		return nil, err
+	}
+
+	return backupList, nil
+}
+
+// GetDependentBackupCount gets the number of the mysql backup sets which are based on the backup set of the given id
+func (dr *DBORepo) GetDependentBackupCount(id int) (int, error) {
+	sql := `SELECT count(*) FROM t_mysql_backup WHERE del_flag = 0 AND base_backup_id = ? ;`
+	log.Debugf("mysql DBORepo.GetDependentBackupCount() select sql: \n%s\nplaceholders: %d", sql, id)
+
+	result, err := dr.Execute(sql, id)
+	if err != nil {
+		return constant.ZeroInt, err
+	}
+
+	return result.GetInt(constant.ZeroInt, constant.ZeroInt)
+}
+
+// DeleteBackup marks the mysql backup set as deleted in the middleware
+func (dr *DBORepo) DeleteBackup(id int) error {
+	sql := `UPDATE t_mysql_backup SET del_flag = 1 WHERE id = ? ;`
+	log.Debugf("mysql DBORepo.DeleteBackup() update sql: \n%s\nplaceholders: %d", sql, id)
+
+	_, err := dr.Execute(sql, id)
+	if err != nil {
+		return message.NewMessage(msgMySQL.ErrMySQLRepositoryDeleteBackup, err, id)
+	}
+
+	return nil
+}
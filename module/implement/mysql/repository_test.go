@@ -3,7 +3,9 @@ package mysql
 import (
 	"fmt"
 	"testing"
+	"time"
 
+	"github.com/romberli/db-operator/global"
 	"github.com/romberli/go-util/constant"
 	"github.com/romberli/go-util/middleware"
 	"github.com/stretchr/testify/assert"
@@ -12,6 +14,10 @@ import (
 const (
 	testOperationID       = 1
 	testOperationDetailID = 1
+
+	testParameterProfileName = "oltp"
+
+	testBackupSetDir = "/data/backup/3306/2024-01-01-00-00-00_full"
 )
 
 var (
@@ -42,25 +48,68 @@ func testTruncateOperationInfo() error {
 		return err
 	}
 
+	sql = `truncate table t_mysql_operation_step ;`
+	_, err = testDBORepo.Execute(sql)
+	if err != nil {
+		return err
+	}
+
 	sql = `truncate table t_mysql_operation_lock ;`
 	_, err = testDBORepo.Execute(sql)
 	if err != nil {
 		return err
 	}
 
+	sql = `truncate table t_mysql_fenced_instance ;`
+	_, err = testDBORepo.Execute(sql)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
+// testExpireLock makes the lock of the operation look like it has not been renewed for two lease timeouts
+func testExpireLock(operationID int) error {
+	sql := `UPDATE t_mysql_operation_lock SET last_update_time = ? WHERE operation_id = ? ;`
+	_, err := testDBORepo.Execute(sql, time.Now().Add(-constant.TwoInt*getLockLeaseTimeout()).Format(constant.TimeLayoutSecond), operationID)
+
+	return err
+}
+
+func testTruncateBackupInfo() error {
+	sql := `truncate table t_mysql_backup ;`
+	_, err := testDBORepo.Execute(sql)
+
+	return err
+}
+
 func TestDBRepo_All(t *testing.T) {
 	TestDBRepo_Execute(t)
 	TestDBRepo_GetOperationHistory(t)
 	TestDBRepo_GetOperationDetail(t)
 	TestDBRepo_GetLock(t)
 	TestDBRepo_ReleaseLock(t)
+	TestDBRepo_RenewLock(t)
+	TestDBRepo_GetLocks(t)
+	TestDBRepo_ForceReleaseLock(t)
 	TestDBRepo_InitOperationHistory(t)
 	TestDBRepo_UpdateOperationHistory(t)
+	TestDBRepo_ResumeOperationHistory(t)
+	TestDBRepo_CancelOperation(t)
+	TestDBRepo_FailOrphanedOperation(t)
+	TestDBRepo_PurgeOperationHistory(t)
 	TestDBRepo_InitOperationDetail(t)
 	TestDBRepo_UpdateOperationDetail(t)
+	TestDBRepo_SaveOperationStep(t)
+	TestDBRepo_DeleteOperationStep(t)
+	TestDBRepo_FenceInstance(t)
+	TestDBRepo_UnfenceInstance(t)
+	TestDBRepo_GetParameterProfiles(t)
+	TestDBRepo_GetParameterProfileByName(t)
+	TestDBRepo_InitBackup(t)
+	TestDBRepo_UpdateBackup(t)
+	TestDBRepo_DeleteBackup(t)
 }
 
 func TestDBRepo_Execute(t *testing.T) {
@@ -76,7 +125,7 @@ func TestDBRepo_Execute(t *testing.T) {
 func TestDBRepo_GetOperationHistory(t *testing.T) {
 	asst := assert.New(t)
 	// init operation history
-	operationID, err := testDBORepo.InitOperationHistory(defaultInstallOperation, []string{testAddr1, testAddr2})
+	operationID, err := testDBORepo.InitOperationHistory(defaultInstallOperation, []string{testAddr1, testAddr2}, constant.EmptyString)
 	asst.Nil(err, "test GetOperationHistory() failed")
 	// get operation history
 	operationInfo, err := testDBORepo.GetOperationHistory(operationID)
@@ -90,7 +139,7 @@ func TestDBRepo_GetOperationHistory(t *testing.T) {
 func TestDBRepo_GetOperationDetail(t *testing.T) {
 	asst := assert.New(t)
 	// init operation history
-	operationID, err := testDBORepo.InitOperationHistory(defaultInstallOperation, []string{testAddr1, testAddr2})
+	operationID, err := testDBORepo.InitOperationHistory(defaultInstallOperation, []string{testAddr1, testAddr2}, constant.EmptyString)
 	asst.Nil(err, "test GetOperationDetail() failed")
 	operationDetailID, err := testDBORepo.InitOperationDetail(operationID, testHostIP1, testPortNum1)
 	asst.Nil(err, "test GetOperationDetail() failed")
@@ -117,6 +166,9 @@ func TestDBRepo_GetLock(t *testing.T) {
 	count, err := result.GetInt(constant.ZeroInt, constant.ZeroInt)
 	asst.Nil(err, "test GetLock() failed")
 	asst.Equal(constant.TwoInt, count, "test GetLock() failed")
+	// the empty addrs could not be locked
+	err = testDBORepo.GetLock(testOperationID, []string{})
+	asst.NotNil(err, "test GetLock() failed")
 	// truncate operation info
 	err = testTruncateOperationInfo()
 	asst.Nil(err, "test GetLock() failed")
@@ -144,10 +196,75 @@ func TestDBRepo_ReleaseLock(t *testing.T) {
 	asst.Nil(err, "test ReleaseLock() failed")
 }
 
+func TestDBRepo_RenewLock(t *testing.T) {
+	asst := assert.New(t)
+	// get lock
+	err := testDBORepo.GetLock(testOperationID, []string{testAddr1})
+	asst.Nil(err, "test RenewLock() failed")
+	// expire the lock, it must be alive again after renewed
+	err = testExpireLock(testOperationID)
+	asst.Nil(err, "test RenewLock() failed")
+	renewed, err := testDBORepo.RenewLock(testOperationID)
+	asst.Nil(err, "test RenewLock() failed")
+	asst.Equal(1, renewed, "test RenewLock() failed")
+	lock, err := testDBORepo.GetLockByAddr(testAddr1)
+	asst.Nil(err, "test RenewLock() failed")
+	asst.Equal(constant.ZeroInt, lock.Expired, "test RenewLock() failed")
+	// release the lock, it can not be renewed any more
+	err = testDBORepo.ReleaseLock(testOperationID)
+	asst.Nil(err, "test RenewLock() failed")
+	renewed, err = testDBORepo.RenewLock(testOperationID)
+	asst.Nil(err, "test RenewLock() failed")
+	asst.Equal(constant.ZeroInt, renewed, "test RenewLock() failed")
+	// truncate operation info
+	err = testTruncateOperationInfo()
+	asst.Nil(err, "test RenewLock() failed")
+}
+
+func TestDBRepo_GetLocks(t *testing.T) {
+	asst := assert.New(t)
+	// get lock
+	err := testDBORepo.GetLock(testOperationID, []string{testAddr1, testAddr2})
+	asst.Nil(err, "test GetLocks() failed")
+	locks, err := testDBORepo.GetLocks()
+	asst.Nil(err, "test GetLocks() failed")
+	asst.Equal(constant.TwoInt, len(locks), "test GetLocks() failed")
+	asst.Equal(global.Owner, locks[constant.ZeroInt].Owner, "test GetLocks() failed")
+	asst.Equal(constant.ZeroInt, locks[constant.ZeroInt].Expired, "test GetLocks() failed")
+	// the addrs could not be locked by the other operation until the lock expired
+	err = testDBORepo.GetLock(testOperationID+constant.OneInt, []string{testAddr1})
+	asst.NotNil(err, "test GetLocks() failed")
+	err = testExpireLock(testOperationID)
+	asst.Nil(err, "test GetLocks() failed")
+	lock, err := testDBORepo.GetLockByAddr(testAddr1)
+	asst.Nil(err, "test GetLocks() failed")
+	asst.Equal(constant.OneInt, lock.Expired, "test GetLocks() failed")
+	err = testDBORepo.GetLock(testOperationID+constant.OneInt, []string{testAddr1})
+	asst.Nil(err, "test GetLocks() failed")
+	// truncate operation info
+	err = testTruncateOperationInfo()
+	asst.Nil(err, "test GetLocks() failed")
+}
+
+func TestDBRepo_ForceReleaseLock(t *testing.T) {
+	asst := assert.New(t)
+	// get lock
+	err := testDBORepo.GetLock(testOperationID, []string{testAddr1})
+	asst.Nil(err, "test ForceReleaseLock() failed")
+	err = testDBORepo.ForceReleaseLock(testAddr1)
+	asst.Nil(err, "test ForceReleaseLock() failed")
+	lock, err := testDBORepo.GetLockByAddr(testAddr1)
+	asst.Nil(err, "test ForceReleaseLock() failed")
+	asst.Nil(lock, "test ForceReleaseLock() failed")
+	// truncate operation info
+	err = testTruncateOperationInfo()
+	asst.Nil(err, "test ForceReleaseLock() failed")
+}
+
 func TestDBRepo_InitOperationHistory(t *testing.T) {
 	asst := assert.New(t)
 
-	operationID, err := testDBORepo.InitOperationHistory(defaultInstallOperation, []string{testAddr1, testAddr2})
+	operationID, err := testDBORepo.InitOperationHistory(defaultInstallOperation, []string{testAddr1, testAddr2}, constant.EmptyString)
 	asst.Nil(err, "test InitOperationHistory() failed")
 	asst.Equal(testOperationID, operationID, "test InitOperationHistory() failed")
 	// truncate operation info
@@ -158,7 +275,7 @@ func TestDBRepo_InitOperationHistory(t *testing.T) {
 func TestDBRepo_UpdateOperationHistory(t *testing.T) {
 	asst := assert.New(t)
 	// init operation history
-	operationID, err := testDBORepo.InitOperationHistory(defaultInstallOperation, []string{testAddr1, testAddr2})
+	operationID, err := testDBORepo.InitOperationHistory(defaultInstallOperation, []string{testAddr1, testAddr2}, constant.EmptyString)
 	asst.Nil(err, "test UpdateOperationHistory() failed")
 	// update operation history
 	err = testDBORepo.UpdateOperationHistory(operationID, defaultSuccessStatus, constant.EmptyString)
@@ -172,6 +289,105 @@ func TestDBRepo_UpdateOperationHistory(t *testing.T) {
 	asst.Nil(err, "test UpdateOperationHistory() failed")
 }
 
+func TestDBRepo_ResumeOperationHistory(t *testing.T) {
+	asst := assert.New(t)
+	// init operation history
+	operationID, err := testDBORepo.InitOperationHistory(defaultInstallOperation, []string{testAddr1, testAddr2}, constant.EmptyString)
+	asst.Nil(err, "test ResumeOperationHistory() failed")
+	err = testDBORepo.UpdateOperationHistory(operationID, defaultFailedStatus, constant.EmptyString)
+	asst.Nil(err, "test ResumeOperationHistory() failed")
+	// resume operation history
+	err = testDBORepo.ResumeOperationHistory(operationID)
+	asst.Nil(err, "test ResumeOperationHistory() failed")
+	operationInfo, err := testDBORepo.GetOperationHistory(operationID)
+	asst.Nil(err, "test ResumeOperationHistory() failed")
+	asst.Equal(defaultRunningStatus, operationInfo.Status, "test ResumeOperationHistory() failed")
+	asst.Equal(global.Owner, operationInfo.Owner, "test ResumeOperationHistory() failed")
+	// truncate operation info
+	err = testTruncateOperationInfo()
+	asst.Nil(err, "test ResumeOperationHistory() failed")
+}
+
+func TestDBRepo_CancelOperation(t *testing.T) {
+	asst := assert.New(t)
+	// init operation history
+	operationID, err := testDBORepo.InitOperationHistory(defaultInstallOperation, []string{testAddr1}, constant.EmptyString)
+	asst.Nil(err, "test CancelOperation() failed")
+	cancelRequested, err := testDBORepo.IsCancelRequested(operationID)
+	asst.Nil(err, "test CancelOperation() failed")
+	asst.False(cancelRequested, "test CancelOperation() failed")
+	// cancel operation
+	err = testDBORepo.CancelOperation(operationID)
+	asst.Nil(err, "test CancelOperation() failed")
+	cancelRequested, err = testDBORepo.IsCancelRequested(operationID)
+	asst.Nil(err, "test CancelOperation() failed")
+	asst.True(cancelRequested, "test CancelOperation() failed")
+	// the cancel flag is reset when the operation is resumed
+	err = testDBORepo.UpdateOperationHistory(operationID, defaultCancelledStatus, constant.EmptyString)
+	asst.Nil(err, "test CancelOperation() failed")
+	err = testDBORepo.ResumeOperationHistory(operationID)
+	asst.Nil(err, "test CancelOperation() failed")
+	cancelRequested, err = testDBORepo.IsCancelRequested(operationID)
+	asst.Nil(err, "test CancelOperation() failed")
+	asst.False(cancelRequested, "test CancelOperation() failed")
+	// truncate operation info
+	err = testTruncateOperationInfo()
+	asst.Nil(err, "test CancelOperation() failed")
+}
+
+func TestDBRepo_FailOrphanedOperation(t *testing.T) {
+	asst := assert.New(t)
+	// init operation history
+	operationID, err := testDBORepo.InitOperationHistory(defaultInstallOperation, []string{testAddr1}, constant.EmptyString)
+	asst.Nil(err, "test FailOrphanedOperation() failed")
+	_, err = testDBORepo.InitOperationDetail(operationID, testHostIP1, testPortNum1)
+	asst.Nil(err, "test FailOrphanedOperation() failed")
+	err = testDBORepo.GetLock(operationID, []string{testAddr1})
+	asst.Nil(err, "test FailOrphanedOperation() failed")
+	hasAliveLock, err := testDBORepo.HasAliveLock(operationID)
+	asst.Nil(err, "test FailOrphanedOperation() failed")
+	asst.True(hasAliveLock, "test FailOrphanedOperation() failed")
+	// fail orphaned operation, the details are failed and the lock is released
+	err = testDBORepo.FailOrphanedOperation(operationID, constant.EmptyString)
+	asst.Nil(err, "test FailOrphanedOperation() failed")
+	operationInfo, err := testDBORepo.GetOperationHistory(operationID)
+	asst.Nil(err, "test FailOrphanedOperation() failed")
+	asst.Equal(defaultFailedStatus, operationInfo.Status, "test FailOrphanedOperation() failed")
+	operationDetails, err := testDBORepo.GetOperationDetails(operationID)
+	asst.Nil(err, "test FailOrphanedOperation() failed")
+	asst.Equal(defaultFailedStatus, operationDetails[constant.ZeroInt].Status, "test FailOrphanedOperation() failed")
+	hasAliveLock, err = testDBORepo.HasAliveLock(operationID)
+	asst.Nil(err, "test FailOrphanedOperation() failed")
+	asst.False(hasAliveLock, "test FailOrphanedOperation() failed")
+	// truncate operation info
+	err = testTruncateOperationInfo()
+	asst.Nil(err, "test FailOrphanedOperation() failed")
+}
+
+func TestDBRepo_PurgeOperationHistory(t *testing.T) {
+	asst := assert.New(t)
+	// init operation histories
+	operationID, err := testDBORepo.InitOperationHistory(defaultInstallOperation, []string{testAddr1, testAddr2}, constant.EmptyString)
+	asst.Nil(err, "test PurgeOperationHistory() failed")
+	err = testDBORepo.UpdateOperationHistory(operationID, defaultSuccessStatus, constant.EmptyString)
+	asst.Nil(err, "test PurgeOperationHistory() failed")
+	_, err = testDBORepo.InitOperationDetail(operationID, testHostIP1, testPortNum1)
+	asst.Nil(err, "test PurgeOperationHistory() failed")
+	runningOperationID, err := testDBORepo.InitOperationHistory(defaultInstallOperation, []string{testAddr1, testAddr2}, constant.EmptyString)
+	asst.Nil(err, "test PurgeOperationHistory() failed")
+	// purge operation history, the running operation must be kept
+	purgedNum, err := testDBORepo.PurgeOperationHistory(time.Now().Add(time.Hour).Format(constant.TimeLayoutSecond))
+	asst.Nil(err, "test PurgeOperationHistory() failed")
+	asst.Equal(constant.OneInt, purgedNum, "test PurgeOperationHistory() failed")
+	_, err = testDBORepo.GetOperationHistory(operationID)
+	asst.NotNil(err, "test PurgeOperationHistory() failed")
+	_, err = testDBORepo.GetOperationHistory(runningOperationID)
+	asst.Nil(err, "test PurgeOperationHistory() failed")
+	// truncate operation info
+	err = testTruncateOperationInfo()
+	asst.Nil(err, "test PurgeOperationHistory() failed")
+}
+
 func TestDBRepo_InitOperationDetail(t *testing.T) {
 	asst := assert.New(t)
 
@@ -197,3 +413,145 @@ func TestDBRepo_UpdateOperationDetail(t *testing.T) {
 	err = testTruncateOperationInfo()
 	asst.Nil(err, "test UpdateOperationDetail() failed")
 }
+
+func TestDBRepo_SaveOperationStep(t *testing.T) {
+	asst := assert.New(t)
+
+	err := testDBORepo.SaveOperationStep(testOperationID, testHostIP1, testPortNum1, installStepInitOS, defaultFailedStatus, constant.EmptyString)
+	asst.Nil(err, "test SaveOperationStep() failed")
+	err = testDBORepo.SaveOperationStep(testOperationID, testHostIP1, testPortNum1, installStepInitOS, defaultSuccessStatus, constant.EmptyString)
+	asst.Nil(err, "test SaveOperationStep() failed")
+	operationSteps, err := testDBORepo.GetOperationSteps(testOperationID)
+	asst.Nil(err, "test SaveOperationStep() failed")
+	asst.Equal(constant.OneInt, len(operationSteps), "test SaveOperationStep() failed")
+	asst.Equal(defaultSuccessStatus, operationSteps[constant.ZeroInt].Status, "test SaveOperationStep() failed")
+	// truncate operation info
+	err = testTruncateOperationInfo()
+	asst.Nil(err, "test SaveOperationStep() failed")
+}
+
+func TestDBRepo_DeleteOperationStep(t *testing.T) {
+	asst := assert.New(t)
+
+	err := testDBORepo.SaveOperationStep(testOperationID, testHostIP1, testPortNum1, installStepInitOS, defaultSuccessStatus, constant.EmptyString)
+	asst.Nil(err, "test DeleteOperationStep() failed")
+	err = testDBORepo.SaveOperationStep(testOperationID, testHostIP1, testPortNum1, installStepInstallBinary, defaultSuccessStatus, constant.EmptyString)
+	asst.Nil(err, "test DeleteOperationStep() failed")
+	err = testDBORepo.DeleteOperationStep(testOperationID, testHostIP1, testPortNum1, installStepInstallBinary)
+	asst.Nil(err, "test DeleteOperationStep() failed")
+	operationSteps, err := testDBORepo.GetOperationSteps(testOperationID)
+	asst.Nil(err, "test DeleteOperationStep() failed")
+	asst.Equal(constant.OneInt, len(operationSteps), "test DeleteOperationStep() failed")
+	asst.Equal(installStepInitOS, operationSteps[constant.ZeroInt].Step, "test DeleteOperationStep() failed")
+	// truncate operation info
+	err = testTruncateOperationInfo()
+	asst.Nil(err, "test DeleteOperationStep() failed")
+}
+
+func TestDBRepo_FenceInstance(t *testing.T) {
+	asst := assert.New(t)
+
+	err := testDBORepo.FenceInstance(testOperationID, testHostIP1, testPortNum1, constant.EmptyString)
+	asst.Nil(err, "test FenceInstance() failed")
+	// fence the same instance again
+	err = testDBORepo.FenceInstance(testOperationID, testHostIP1, testPortNum1, constant.EmptyString)
+	asst.Nil(err, "test FenceInstance() failed")
+	fencedInstances, err := testDBORepo.GetFencedInstances()
+	asst.Nil(err, "test FenceInstance() failed")
+	asst.Equal(constant.OneInt, len(fencedInstances), "test FenceInstance() failed")
+	// truncate operation info
+	err = testTruncateOperationInfo()
+	asst.Nil(err, "test FenceInstance() failed")
+}
+
+func TestDBRepo_UnfenceInstance(t *testing.T) {
+	asst := assert.New(t)
+
+	err := testDBORepo.FenceInstance(testOperationID, testHostIP1, testPortNum1, constant.EmptyString)
+	asst.Nil(err, "test UnfenceInstance() failed")
+	err = testDBORepo.UnfenceInstance(testHostIP1, testPortNum1)
+	asst.Nil(err, "test UnfenceInstance() failed")
+	fencedInstances, err := testDBORepo.GetFencedInstances()
+	asst.Nil(err, "test UnfenceInstance() failed")
+	asst.Equal(constant.ZeroInt, len(fencedInstances), "test UnfenceInstance() failed")
+	// truncate operation info
+	err = testTruncateOperationInfo()
+	asst.Nil(err, "test UnfenceInstance() failed")
+}
+
+func TestDBRepo_GetParameterProfiles(t *testing.T) {
+	asst := assert.New(t)
+
+	parameterProfiles, err := testDBORepo.GetParameterProfiles()
+	asst.Nil(err, "test GetParameterProfiles() failed")
+	asst.True(len(parameterProfiles) > constant.ZeroInt, "test GetParameterProfiles() failed")
+}
+
+func TestDBRepo_GetParameterProfileByName(t *testing.T) {
+	asst := assert.New(t)
+
+	parameterProfile, err := testDBORepo.GetParameterProfileByName(testParameterProfileName)
+	asst.Nil(err, "test GetParameterProfileByName() failed")
+	parameters, err := parameterProfile.GetParameters()
+	asst.Nil(err, "test GetParameterProfileByName() failed")
+	asst.True(len(parameters) > constant.ZeroInt, "test GetParameterProfileByName() failed")
+	_, err = testDBORepo.GetParameterProfileByName("not-exists")
+	asst.NotNil(err, "test GetParameterProfileByName() failed")
+}
+
+func TestDBRepo_InitBackup(t *testing.T) {
+	asst := assert.New(t)
+
+	id, err := testDBORepo.InitBackup(testOperationID, testHostIP1, testPortNum1, FullBackupType, XtrabackupMethod, constant.ZeroInt, testBackupSetDir)
+	asst.Nil(err, "test InitBackup() failed")
+	backupInfo, err := testDBORepo.GetBackup(id)
+	asst.Nil(err, "test InitBackup() failed")
+	asst.Equal(defaultRunningStatus, backupInfo.Status, "test InitBackup() failed")
+	// the running backup set could not be the base of the incremental backup
+	lastBackup, err := testDBORepo.GetLastBackup(testHostIP1, testPortNum1, XtrabackupMethod)
+	asst.Nil(err, "test InitBackup() failed")
+	asst.Nil(lastBackup, "test InitBackup() failed")
+	// truncate backup info
+	err = testTruncateBackupInfo()
+	asst.Nil(err, "test InitBackup() failed")
+}
+
+func TestDBRepo_UpdateBackup(t *testing.T) {
+	asst := assert.New(t)
+
+	id, err := testDBORepo.InitBackup(testOperationID, testHostIP1, testPortNum1, FullBackupType, XtrabackupMethod, constant.ZeroInt, testBackupSetDir)
+	asst.Nil(err, "test UpdateBackup() failed")
+	backupInfo, err := testDBORepo.GetBackup(id)
+	asst.Nil(err, "test UpdateBackup() failed")
+	backupInfo.ToLSN = 19013468
+	backupInfo.Status = defaultSuccessStatus
+	err = testDBORepo.UpdateBackup(backupInfo)
+	asst.Nil(err, "test UpdateBackup() failed")
+	lastBackup, err := testDBORepo.GetLastBackup(testHostIP1, testPortNum1, XtrabackupMethod)
+	asst.Nil(err, "test UpdateBackup() failed")
+	asst.Equal(id, lastBackup.ID, "test UpdateBackup() failed")
+	asst.Equal(backupInfo.ToLSN, lastBackup.ToLSN, "test UpdateBackup() failed")
+	// truncate backup info
+	err = testTruncateBackupInfo()
+	asst.Nil(err, "test UpdateBackup() failed")
+}
+
+func TestDBRepo_DeleteBackup(t *testing.T) {
+	asst := assert.New(t)
+
+	baseID, err := testDBORepo.InitBackup(testOperationID, testHostIP1, testPortNum1, FullBackupType, XtrabackupMethod, constant.ZeroInt, testBackupSetDir)
+	asst.Nil(err, "test DeleteBackup() failed")
+	id, err := testDBORepo.InitBackup(testOperationID, testHostIP1, testPortNum1, IncrementalBackupType, XtrabackupMethod, baseID, testBackupSetDir)
+	asst.Nil(err, "test DeleteBackup() failed")
+	dependentCount, err := testDBORepo.GetDependentBackupCount(baseID)
+	asst.Nil(err, "test DeleteBackup() failed")
+	asst.Equal(constant.OneInt, dependentCount, "test DeleteBackup() failed")
+	err = testDBORepo.DeleteBackup(id)
+	asst.Nil(err, "test DeleteBackup() failed")
+	dependentCount, err = testDBORepo.GetDependentBackupCount(baseID)
+	asst.Nil(err, "test DeleteBackup() failed")
+	asst.Equal(constant.ZeroInt, dependentCount, "test DeleteBackup() failed")
+	// truncate backup info
+	err = testTruncateBackupInfo()
+	asst.Nil(err, "test DeleteBackup() failed")
+}
@@ -0,0 +1,401 @@
+package mysql
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/romberli/go-util/constant"
+	"github.com/romberli/go-util/linux"
+	"github.com/romberli/log"
+	"github.com/spf13/viper"
+
+	"github.com/romberli/db-operator/config"
+	"github.com/romberli/db-operator/pkg/message"
+	"github.com/romberli/db-operator/pkg/util/ssh"
+
+	msgMySQL "github.com/romberli/db-operator/pkg/message/mysql"
+)
+
+const (
+	restoreSuccessMessage = "restore mysql server completed."
+	restorePanicMessage   = "restore mysql server failed because of panic, please check the log for more details."
+
+	restoreDirName                    = "restore"
+	backupArchiveNameTemplate         = "dbo_backup_%d.tar.gz"
+	binlogSQLFileNameTemplate         = "dbo_restore_%d_%d.sql"
+	binlogFilePattern                 = "mysql-bin.[0-9]*"
+	archiveBackupCommandTemplate      = "/usr/bin/tar -czf %s -C %s ."
+	extractBackupCommandTemplate      = "/usr/bin/tar -xzf %s -C %s"
+	xtrabackupPrepareCommandTemplate  = "%s --prepare --target-dir=%s"
+	xtrabackupApplyLogOnlyOption      = " --apply-log-only"
+	xtrabackupIncrementalDirOption    = " --incremental-dir=%s"
+	xtrabackupMoveBackCommandTemplate = "%s --defaults-file=%s --defaults-group=mysqld%d --move-back --target-dir=%s"
+	moveCloneRedoLogCommandTemplate   = `/usr/bin/find %s -mindepth 1 -maxdepth 1 \( -name 'ib_logfile*' -o -name '#innodb_redo' \) -exec /usr/bin/mv {} %s \;`
+	moveCloneDataCommandTemplate      = `/usr/bin/find %s -mindepth 1 -maxdepth 1 -exec /usr/bin/mv {} %s \;`
+	mysqlbinlogCommandTemplate        = "%s/bin/mysqlbinlog --exclude-gtids='%s'"
+	mysqlbinlogStopDatetimeOption     = " --stop-datetime='%s'"
+	mysqlbinlogOutputTemplate         = " %s > %s"
+	applyBinlogSQLCommandTemplate     = "%s/bin/mysql --user=%s --password='%s' --socket=%s/run/mysql.sock < %s"
+	// the transactions from the stop gtid to the max transaction id of the same source uuid will be excluded
+	stopGTIDSetTemplate      = "%s,%s-%s"
+	maxGTIDTransactionID     = "9223372036854775806"
+	stopGTIDRegexpExpression = `^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}:[0-9]+$`
+)
+
+var stopGTIDRegexp = regexp.MustCompile(stopGTIDRegexpExpression)
+
+// CheckRestore checks if the backup set of the given id could be restored to the addr of the engine,
+// at most one of the stop gtid and the stop datetime could be specified, the binlogs will be replayed to the end if neither of them is specified
+func (e *Engine) CheckRestore(backupID int, stopGTID, stopDatetime string) error {
+	if len(e.Addrs) != constant.OneInt {
+		return errors.Errorf("mysql Engine.CheckRestore(): only one target addr could be specified. addrs: %v", e.Addrs)
+	}
+	if stopGTID != constant.EmptyString && stopDatetime != constant.EmptyString {
+		return errors.New("mysql Engine.CheckRestore(): stop gtid and stop datetime could not be specified at the same time")
+	}
+	if stopGTID != constant.EmptyString && !stopGTIDRegexp.MatchString(stopGTID) {
+		return errors.Errorf("mysql Engine.CheckRestore(): stop gtid must be formatted as uuid:transaction_id. stopGTID: %s", stopGTID)
+	}
+	if stopDatetime != constant.EmptyString {
+		_, err := time.ParseInLocation(constant.TimeLayoutSecond, stopDatetime, time.Local)
+		if err != nil {
+			return errors.Errorf("mysql Engine.CheckRestore(): stop datetime must be formatted as %s. stopDatetime: %s",
+				constant.TimeLayoutSecond, stopDatetime)
+		}
+	}
+
+	backups, err := e.getRestoreBackups(backupID)
+	if err != nil {
+		return err
+	}
+	lastBackup := backups[len(backups)-constant.OneInt]
+	// the gtid set is needed to skip the transactions which are already in the backup set when replaying the binlogs
+	if lastBackup.GTIDSet == constant.EmptyString {
+		return errors.Errorf("mysql Engine.CheckRestore(): gtid set of the backup set is empty, the binlogs could not be replayed. backupID: %d", backupID)
+	}
+	if e.Addrs[constant.ZeroInt] == lastBackup.GetAddr() {
+		return errors.Errorf("mysql Engine.CheckRestore(): backup set could not be restored to the instance where it was taken. addr: %s",
+			lastBackup.GetAddr())
+	}
+
+	return nil
+}
+
+// Restore installs a fresh instance of the addr, restores the backup set of the given id instead of initializing the data directory,
+// and then replays the binlogs of the instance where the backup set was taken up to the stop gtid or the stop datetime,
+// the restored instance will not join any cluster
+func (e *Engine) Restore(operationID, backupID int, stopGTID, stopDatetime string) error {
+	err := e.CheckRestore(backupID, stopGTID, stopDatetime)
+	if err != nil {
+		return err
+	}
+	backups, err := e.getRestoreBackups(backupID)
+	if err != nil {
+		return err
+	}
+	hostIP, portNum, err := splitAddr(e.Addrs[constant.ZeroInt])
+	if err != nil {
+		return err
+	}
+	// load the progress of the previous run
+	err = e.loadOperationProgress(operationID)
+	if err != nil {
+		return err
+	}
+	// init operation detail
+	operationDetailID, err := e.initOperationDetail(operationID, hostIP, portNum)
+	if err != nil {
+		return err
+	}
+	err = e.RestoreInstance(hostIP, portNum, backups, stopGTID, stopDatetime)
+	if err != nil {
+		e.updateOperationDetail(operationID, operationDetailID, hostIP, portNum, defaultFailedStatus, err.Error())
+		return err
+	}
+
+	e.updateOperationDetail(operationID, operationDetailID, hostIP, portNum, defaultSuccessStatus, restoreSuccessMessage)
+	log.Infof(message.NewMessage(msgMySQL.InfoMySQLEngineRestore, operationID, operationDetailID, hostIP, portNum, backupID, stopGTID, stopDatetime).Error())
+
+	return nil
+}
+
+// RestoreInstance installs the single instance with the backup sets and replays the binlogs,
+// if it fails and RollbackOnFailure is true, the completed steps will be undone in reverse order
+func (e *Engine) RestoreInstance(hostIP string, portNum int, backups []*BackupInfo, stopGTID, stopDatetime string) error {
+	return e.runWithRollback(hostIP, portNum, func() error {
+		return e.restoreInstance(hostIP, portNum, backups, stopGTID, stopDatetime)
+	})
+}
+
+// restoreInstance restores the single instance step by step, the os and the config file are prepared the same way as installing,
+// the steps which were already completed will be skipped
+func (e *Engine) restoreInstance(hostIP string, portNum int, backups []*BackupInfo, stopGTID, stopDatetime string) error {
+	// reset MySQL Sever Parameter
+	err := e.MySQLServer.InitWithHostInfo(hostIP, portNum, true)
+	if err != nil {
+		return err
+	}
+	// init os executor
+	err = e.InitOSExecutor()
+	if err != nil {
+		return err
+	}
+	err = e.ose.InitExecutor()
+	if err != nil {
+		return err
+	}
+	// init os
+	err = e.runStep(hostIP, portNum, installStepInitOS, e.ose.PrepareOS)
+	if err != nil {
+		return err
+	}
+	// install mysql binary
+	err = e.runStep(hostIP, portNum, installStepInstallBinary, e.ose.InstallBinary)
+	if err != nil {
+		return err
+	}
+	// restore the backup sets
+	err = e.runStep(hostIP, portNum, installStepRestore, func() error {
+		return e.restoreBackups(backups)
+	})
+	if err != nil {
+		return err
+	}
+	// start mysql multi instance
+	err = e.runStep(hostIP, portNum, installStepStartMySQLDMulti, e.startMultiInstance)
+	if err != nil {
+		return err
+	}
+	// replay the binlogs
+	return e.runStep(hostIP, portNum, installStepReplayBinlog, func() error {
+		return e.replayBinlogs(backups[len(backups)-constant.OneInt], stopGTID, stopDatetime)
+	})
+}
+
+// getRestoreBackups returns the backup sets which are needed to restore the backup set of the given id,
+// the full backup set is the first and the backup set of the given id is the last
+func (e *Engine) getRestoreBackups(backupID int) ([]*BackupInfo, error) {
+	var backups []*BackupInfo
+	for id := backupID; id != constant.ZeroInt; {
+		backup, err := e.dboRepo.GetBackup(id)
+		if err != nil {
+			return nil, err
+		}
+		if backup.Status != defaultSuccessStatus {
+			return nil, errors.Errorf("mysql Engine.getRestoreBackups(): backup set is not successful. backupID: %d, status: %d", backup.ID, backup.Status)
+		}
+		backups = append([]*BackupInfo{backup}, backups...)
+		id = backup.BaseBackupID
+	}
+
+	return backups, nil
+}
+
+// restoreBackups prepares the config file, transfers the backup sets to the host and moves the prepared data to the data directory
+func (e *Engine) restoreBackups(backups []*BackupInfo) error {
+	err := e.autoSizeIfEnabled()
+	if err != nil {
+		return err
+	}
+	// prepare mysql multi instance config file
+	err = e.prepareMultiInstanceConfigFile()
+	if err != nil {
+		return err
+	}
+	e.rollbackStack.Push("clean up mysql instance", e.cleanUpInstance)
+
+	restoreDir := filepath.Join(e.MySQLServer.BackupDir, strconv.Itoa(e.MySQLServer.PortNum), restoreDirName)
+	defer func() {
+		err = e.ose.Conn.RemoveAll(restoreDir)
+		if err != nil {
+			log.Errorf("mysql Engine.restoreBackups(): remove restore directory failed. restoreDir: %s, error:\n%+v", restoreDir, err)
+		}
+	}()
+
+	var dirs []string
+	for _, backup := range backups {
+		dir := filepath.Join(restoreDir, strconv.Itoa(backup.ID))
+		err = e.transferBackup(backup, dir)
+		if err != nil {
+			return err
+		}
+		dirs = append(dirs, dir)
+	}
+
+	logDataDir := filepath.Join(e.MySQLServer.LogDirBase, dataDirName)
+	err = e.ose.Conn.MkdirAll(logDataDir)
+	if err != nil {
+		return err
+	}
+	if backups[constant.ZeroInt].BackupMethod == CloneMethod {
+		// the clone directory is already consistent, the redo logs must be moved to the log directory
+		err = e.ose.Conn.ExecuteCommandWithoutOutput(fmt.Sprintf(moveCloneRedoLogCommandTemplate, dirs[constant.ZeroInt], logDataDir))
+		if err != nil {
+			return err
+		}
+		err = e.ose.Conn.ExecuteCommandWithoutOutput(fmt.Sprintf(moveCloneDataCommandTemplate, dirs[constant.ZeroInt],
+			filepath.Join(e.MySQLServer.DataDirBase, dataDirName)))
+	} else {
+		err = e.prepareXtrabackup(dirs)
+	}
+	if err != nil {
+		return err
+	}
+	err = e.ose.Conn.Chown(e.MySQLServer.DataDirBase, defaultMySQLUser, defaultMySQLGroup)
+	if err != nil {
+		return err
+	}
+
+	return e.ose.Conn.Chown(e.MySQLServer.LogDirBase, defaultMySQLUser, defaultMySQLGroup)
+}
+
+// transferBackup archives the backup set on the host where it was taken, and extracts it to the given directory of the host of the engine
+func (e *Engine) transferBackup(backup *BackupInfo, dir string) error {
+	sourceConn, err := newBackupSSHConn(backup.HostIP)
+	if err != nil {
+		return err
+	}
+
+	archivePath := filepath.Join(constant.DefaultTmpDir, fmt.Sprintf(backupArchiveNameTemplate, backup.ID))
+	err = ssh.NewConnWithContext(e.ctx, sourceConn).ExecuteCommandWithoutOutput(fmt.Sprintf(archiveBackupCommandTemplate, archivePath, backup.BackupDir))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = sourceConn.RemoveAll(archivePath)
+		if err != nil {
+			log.Errorf("mysql Engine.transferBackup(): remove archive on the source host failed. hostIP: %s, archivePath: %s, error:\n%+v",
+				backup.HostIP, archivePath, err)
+		}
+	}()
+
+	err = e.transferRemoteFile(sourceConn, archivePath, archivePath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = e.ose.Conn.RemoveAll(archivePath)
+		if err != nil {
+			log.Errorf("mysql Engine.transferBackup(): remove archive failed. hostIP: %s, archivePath: %s, error:\n%+v",
+				e.MySQLServer.HostIP, archivePath, err)
+		}
+	}()
+
+	err = e.ose.Conn.MkdirAll(dir)
+	if err != nil {
+		return err
+	}
+
+	return e.ose.Conn.ExecuteCommandWithoutOutput(fmt.Sprintf(extractBackupCommandTemplate, archivePath, dir))
+}
+
+// transferRemoteFile copies the file from the source host to the host of the engine through the local temporary directory
+func (e *Engine) transferRemoteFile(sourceConn *linux.SSHConn, fileSource, fileDest string) error {
+	fileLocal, err := os.CreateTemp(viper.GetString(config.MySQLInstallationTemporaryDirKey), filepath.Base(fileSource))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() {
+		err = os.Remove(fileLocal.Name())
+		if err != nil {
+			log.Errorf("mysql Engine.transferRemoteFile(): remove local file failed. error:\n%+v", err)
+		}
+	}()
+	err = fileLocal.Close()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	err = sourceConn.CopySingleFileFromRemote(fileSource, fileLocal.Name())
+	if err != nil {
+		return err
+	}
+
+	return e.ose.Conn.CopySingleFileToRemote(fileLocal.Name(), fileDest, constant.DefaultTmpDir)
+}
+
+// prepareXtrabackup applies the incremental backup sets to the full backup set in order,
+// and then moves the prepared full backup set to the directories of the config file
+func (e *Engine) prepareXtrabackup(dirs []string) error {
+	toolPath := viper.GetString(config.MySQLBackupToolPathKey)
+	baseDir := dirs[constant.ZeroInt]
+	for i, dir := range dirs {
+		cmd := fmt.Sprintf(xtrabackupPrepareCommandTemplate, toolPath, baseDir)
+		// the uncommitted transactions must not be rolled back until the last backup set is applied
+		if i < len(dirs)-constant.OneInt {
+			cmd += xtrabackupApplyLogOnlyOption
+		}
+		if i > constant.ZeroInt {
+			cmd += fmt.Sprintf(xtrabackupIncrementalDirOption, dir)
+		}
+		err := e.ose.Conn.ExecuteCommandWithoutOutput(cmd)
+		if err != nil {
+			return err
+		}
+	}
+
+	return e.ose.Conn.ExecuteCommandWithoutOutput(fmt.Sprintf(xtrabackupMoveBackCommandTemplate,
+		toolPath, defaultConfigFileName, e.MySQLServer.PortNum, baseDir))
+}
+
+// replayBinlogs extracts the transactions after the backup set from the binlogs of the instance where the backup set was taken,
+// and applies them to the instance of the engine
+func (e *Engine) replayBinlogs(backup *BackupInfo, stopGTID, stopDatetime string) error {
+	sourceConn, err := newBackupSSHConn(backup.HostIP)
+	if err != nil {
+		return err
+	}
+	sourceServer := e.MySQLServer.Clone()
+	err = sourceServer.InitWithHostInfo(backup.HostIP, backup.PortNum, true)
+	if err != nil {
+		return err
+	}
+
+	sqlFilePath := filepath.Join(constant.DefaultTmpDir, fmt.Sprintf(binlogSQLFileNameTemplate, e.MySQLServer.PortNum, backup.ID))
+	cmd := fmt.Sprintf(mysqlbinlogCommandTemplate, e.MySQLServer.BinaryDirBase, getExcludeGTIDSet(backup.GTIDSet, stopGTID))
+	if stopDatetime != constant.EmptyString {
+		cmd += fmt.Sprintf(mysqlbinlogStopDatetimeOption, stopDatetime)
+	}
+	cmd += fmt.Sprintf(mysqlbinlogOutputTemplate, filepath.Join(sourceServer.LogDirBase, binlogDirName, binlogFilePattern), sqlFilePath)
+	err = ssh.NewConnWithContext(e.ctx, sourceConn).ExecuteCommandWithoutOutput(cmd)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = sourceConn.RemoveAll(sqlFilePath)
+		if err != nil {
+			log.Errorf("mysql Engine.replayBinlogs(): remove sql file on the source host failed. hostIP: %s, sqlFilePath: %s, error:\n%+v",
+				backup.HostIP, sqlFilePath, err)
+		}
+	}()
+
+	err = e.transferRemoteFile(sourceConn, sqlFilePath, sqlFilePath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = e.ose.Conn.RemoveAll(sqlFilePath)
+		if err != nil {
+			log.Errorf("mysql Engine.replayBinlogs(): remove sql file failed. hostIP: %s, sqlFilePath: %s, error:\n%+v",
+				e.MySQLServer.HostIP, sqlFilePath, err)
+		}
+	}()
+
+	return e.ose.Conn.ExecuteCommandWithoutOutput(fmt.Sprintf(applyBinlogSQLCommandTemplate, e.MySQLServer.BinaryDirBase,
+		constant.DefaultRootUserName, e.MySQLServer.RootPass, e.MySQLServer.DataDirBase, sqlFilePath))
+}
+
+// getExcludeGTIDSet returns the gtid set which should not be replayed,
+// it contains the gtid set of the backup set and the transactions from the stop gtid of the same source uuid
+func getExcludeGTIDSet(backupGTIDSet, stopGTID string) string {
+	if stopGTID == constant.EmptyString {
+		return backupGTIDSet
+	}
+
+	return fmt.Sprintf(stopGTIDSetTemplate, backupGTIDSet, stopGTID, maxGTIDTransactionID)
+}
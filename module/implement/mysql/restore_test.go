@@ -0,0 +1,78 @@
+package mysql
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/romberli/go-util/common"
+	"github.com/romberli/go-util/constant"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testBackupGTIDSet = "3e11fa47-71ca-11e1-9e33-c80aa9429562:1-5"
+	testStopGTID      = "3e11fa47-71ca-11e1-9e33-c80aa9429562:8"
+	testStopDatetime  = "2024-01-01 12:00:00"
+)
+
+func TestRestore_All(t *testing.T) {
+	TestGetExcludeGTIDSet(t)
+	TestEngine_CheckRestore(t)
+	TestEngine_RestoreInstance(t)
+}
+
+func TestGetExcludeGTIDSet(t *testing.T) {
+	asst := assert.New(t)
+
+	asst.Equal(testBackupGTIDSet, getExcludeGTIDSet(testBackupGTIDSet, constant.EmptyString), "test getExcludeGTIDSet() failed")
+	asst.Equal(testBackupGTIDSet+",3e11fa47-71ca-11e1-9e33-c80aa9429562:8-9223372036854775806",
+		getExcludeGTIDSet(testBackupGTIDSet, testStopGTID), "test getExcludeGTIDSet() failed")
+}
+
+func TestEngine_CheckRestore(t *testing.T) {
+	asst := assert.New(t)
+
+	e := NewEngineWithDefault(testMySQLVersion, testMode, []string{fmt.Sprintf(addrTemplate, testHostIP2, testPortNum2)}, testMySQLServer, testPMMClient)
+	// stop gtid and stop datetime could not be specified at the same time
+	err := e.CheckRestore(testOperationID, testStopGTID, testStopDatetime)
+	asst.NotNil(err, "test CheckRestore() failed")
+	err = e.CheckRestore(testOperationID, "not-a-gtid", constant.EmptyString)
+	asst.NotNil(err, "test CheckRestore() failed")
+	err = e.CheckRestore(testOperationID, constant.EmptyString, "2024-01-01")
+	asst.NotNil(err, "test CheckRestore() failed")
+
+	id, err := testDBORepo.InitBackup(testOperationID, testHostIP1, testPortNum1, FullBackupType, XtrabackupMethod, constant.ZeroInt, testBackupSetDir)
+	asst.Nil(err, common.CombineMessageWithError("test CheckRestore() failed", err))
+	// the backup set is still running
+	err = e.CheckRestore(id, constant.EmptyString, testStopDatetime)
+	asst.NotNil(err, "test CheckRestore() failed")
+	backupInfo, err := testDBORepo.GetBackup(id)
+	asst.Nil(err, common.CombineMessageWithError("test CheckRestore() failed", err))
+	backupInfo.GTIDSet = testBackupGTIDSet
+	backupInfo.Status = defaultSuccessStatus
+	err = testDBORepo.UpdateBackup(backupInfo)
+	asst.Nil(err, common.CombineMessageWithError("test CheckRestore() failed", err))
+	err = e.CheckRestore(id, constant.EmptyString, testStopDatetime)
+	asst.Nil(err, common.CombineMessageWithError("test CheckRestore() failed", err))
+	// truncate backup info
+	err = testTruncateBackupInfo()
+	asst.Nil(err, common.CombineMessageWithError("test CheckRestore() failed", err))
+}
+
+func TestEngine_RestoreInstance(t *testing.T) {
+	asst := assert.New(t)
+
+	err := testEngine.MySQLServer.InitWithHostInfo(testHostIP1, testPortNum1, true)
+	asst.Nil(err, common.CombineMessageWithError("test RestoreInstance() failed", err))
+	err = testEngine.BackupInstance(testOperationID, testHostIP1, testPortNum1, FullBackupType, XtrabackupMethod)
+	asst.Nil(err, common.CombineMessageWithError("test RestoreInstance() failed", err))
+	backupInfo, err := testDBORepo.GetLastBackup(testHostIP1, testPortNum1, XtrabackupMethod)
+	asst.Nil(err, common.CombineMessageWithError("test RestoreInstance() failed", err))
+
+	e := NewEngineWithDefault(testMySQLVersion, testMode, []string{fmt.Sprintf(addrTemplate, testHostIP2, testPortNum2)}, testMySQLServer, testPMMClient)
+	err = e.RestoreInstance(testHostIP2, testPortNum2, []*BackupInfo{backupInfo}, constant.EmptyString, constant.EmptyString)
+	asst.Nil(err, common.CombineMessageWithError("test RestoreInstance() failed", err))
+	// truncate backup info
+	err = testTruncateBackupInfo()
+	asst.Nil(err, common.CombineMessageWithError("test RestoreInstance() failed", err))
+}
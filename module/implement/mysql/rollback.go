@@ -0,0 +1,108 @@
+package mysql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/romberli/go-util/constant"
+	"github.com/romberli/log"
+)
+
+type compensation struct {
+	name   string
+	step   int
+	action func() error
+}
+
+// RollbackStack records the compensating actions of the completed steps,
+// so that the partially applied changes could be undone in reverse order when a later step fails,
+// it also records the steps in running order, so that the steps which are affected by the rollback could be found
+type RollbackStack struct {
+	compensations []*compensation
+	steps         []int
+}
+
+// NewRollbackStack returns a new *RollbackStack
+func NewRollbackStack() *RollbackStack {
+	return newRollbackStack()
+}
+
+// newRollbackStack returns a new *RollbackStack
+func newRollbackStack() *RollbackStack {
+	return &RollbackStack{}
+}
+
+// BeginStep records that the step starts running, the compensating actions pushed afterwards belong to the step,
+// it does nothing if the stack is nil
+func (rs *RollbackStack) BeginStep(step int) {
+	if rs == nil {
+		return
+	}
+
+	rs.steps = append(rs.steps, step)
+}
+
+// Push records the compensating action of the running step, it does nothing if the stack is nil
+func (rs *RollbackStack) Push(name string, action func() error) {
+	if rs == nil {
+		return
+	}
+
+	step := constant.ZeroInt
+	if len(rs.steps) > constant.ZeroInt {
+		step = rs.steps[len(rs.steps)-constant.OneInt]
+	}
+	rs.compensations = append(rs.compensations, &compensation{
+		name:   name,
+		step:   step,
+		action: action,
+	})
+}
+
+// UndoneSteps returns the steps which will be affected by the rollback in running order,
+// they are the first step which has compensating actions and all the steps run after it,
+// because the later steps depend on the changes of the undone step.
+// the steps before are kept, they have no compensating action, so the rollback does not change anything they did
+func (rs *RollbackStack) UndoneSteps() []int {
+	if rs == nil || len(rs.compensations) == constant.ZeroInt {
+		return nil
+	}
+
+	firstStep := rs.compensations[constant.ZeroInt].step
+	for i, step := range rs.steps {
+		if step == firstStep {
+			return append([]int(nil), rs.steps[i:]...)
+		}
+	}
+
+	// the compensating action was not pushed by any step, all the steps are treated as undone
+	return append([]int(nil), rs.steps...)
+}
+
+// Rollback runs the compensating actions in reverse order and clears the stack,
+// it keeps running the rest of the actions even if some of them failed, and returns the combined error
+func (rs *RollbackStack) Rollback() error {
+	if rs == nil {
+		return nil
+	}
+
+	var errList []string
+	for i := len(rs.compensations) - constant.OneInt; i >= constant.ZeroInt; i-- {
+		c := rs.compensations[i]
+		log.Infof("mysql RollbackStack.Rollback(): running compensating action. name: %s", c.name)
+		err := c.action()
+		if err != nil {
+			log.Errorf("mysql RollbackStack.Rollback(): compensating action failed. name: %s, error:\n%+v", c.name, err)
+			errList = append(errList, fmt.Sprintf("%s: %s", c.name, err.Error()))
+		}
+	}
+	rs.compensations = nil
+
+	if len(errList) > constant.ZeroInt {
+		return errors.Errorf("mysql RollbackStack.Rollback(): some of the compensating actions failed. errors: %s",
+			strings.Join(errList, constant.SemicolonString))
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package mysql
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRollbackStack_All(t *testing.T) {
+	TestRollbackStack_Rollback(t)
+	TestRollbackStack_UndoneSteps(t)
+}
+
+func TestRollbackStack_Rollback(t *testing.T) {
+	asst := assert.New(t)
+
+	var steps []string
+	rs := NewRollbackStack()
+	rs.Push("step1", func() error {
+		steps = append(steps, "step1")
+		return nil
+	})
+	rs.Push("step2", func() error {
+		steps = append(steps, "step2")
+		return errors.New("step2 failed")
+	})
+	rs.Push("step3", func() error {
+		steps = append(steps, "step3")
+		return nil
+	})
+
+	err := rs.Rollback()
+	asst.NotNil(err, "test Rollback() failed")
+	asst.Equal([]string{"step3", "step2", "step1"}, steps, "test Rollback() failed")
+	// the stack should be cleared after rolling back
+	err = rs.Rollback()
+	asst.Nil(err, "test Rollback() failed")
+	// pushing to a nil stack should do nothing
+	var nilStack *RollbackStack
+	nilStack.Push("step1", func() error { return nil })
+	asst.Nil(nilStack.Rollback(), "test Rollback() failed")
+}
+
+func TestRollbackStack_UndoneSteps(t *testing.T) {
+	asst := assert.New(t)
+
+	noop := func() error { return nil }
+	// the os was prepared and the binary was installed before, so only the steps from initializing are undone
+	rs := NewRollbackStack()
+	rs.BeginStep(installStepInitOS)
+	rs.BeginStep(installStepInstallBinary)
+	rs.BeginStep(installStepInitialize)
+	rs.Push("clean up mysql instance", noop)
+	rs.BeginStep(installStepInitUser)
+	rs.BeginStep(installStepStartMySQLDMulti)
+	asst.Equal([]int{installStepInitialize, installStepInitUser, installStepStartMySQLDMulti}, rs.UndoneSteps(), "test UndoneSteps() failed")
+	// all the steps are undone if the first step has compensating actions
+	rs = NewRollbackStack()
+	rs.BeginStep(installStepInitOS)
+	rs.Push("remove directory", noop)
+	rs.BeginStep(installStepInstallBinary)
+	asst.Equal([]int{installStepInitOS, installStepInstallBinary}, rs.UndoneSteps(), "test UndoneSteps() failed")
+	// nothing is undone if there is no compensating action
+	rs = NewRollbackStack()
+	rs.BeginStep(installStepInitOS)
+	asst.Nil(rs.UndoneSteps(), "test UndoneSteps() failed")
+	var nilStack *RollbackStack
+	nilStack.BeginStep(installStepInitOS)
+	asst.Nil(nilStack.UndoneSteps(), "test UndoneSteps() failed")
+}
@@ -1,8 +1,22 @@
 package mysql
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pingcap/errors"
+	"github.com/romberli/go-util/common"
 	"github.com/romberli/go-util/constant"
+	"github.com/romberli/go-util/linux"
 	"github.com/romberli/log"
+
+	"github.com/romberli/db-operator/module/implement/mysql/mode"
+	"github.com/romberli/db-operator/module/implement/mysql/parameter"
+	"github.com/romberli/db-operator/pkg/message"
+
+	jsonmysql "github.com/romberli/db-operator/pkg/json/mysql"
+	msgMySQL "github.com/romberli/db-operator/pkg/message/mysql"
 )
 
 type Service struct {
@@ -28,24 +42,371 @@ func newService(repo *DBORepo, engine *Engine) *Service {
 	}
 }
 
-// Install installs the mysql to the target hosts
-func (s *Service) Install() error {
-	// init operation id
-	operationID, err := s.DBORepo.InitOperationHistory(defaultInstallOperation, s.Engine.Addrs)
+// Install installs the mysql to the target hosts asynchronously,
+// it returns the operation id as soon as the operation lock is acquired,
+// the caller could use the operation id to query the operation status later
+func (s *Service) Install() (int, error) {
+	// the instances will be registered to the cluster after they are installed
+	err := s.checkClusterName()
+	if err != nil {
+		return constant.ZeroInt, err
+	}
+	// the parameters must be resolved before saving the request, so that the resumed operation renders the same config file
+	err = s.resolveParameters()
+	if err != nil {
+		return constant.ZeroInt, err
+	}
+	if s.Engine.Mode == mode.GroupReplication {
+		// the group name must be saved with the request, so that the resumed operation uses the same group
+		err = s.Engine.initGroupReplicationParameter()
+		if err != nil {
+			return constant.ZeroInt, err
+		}
+	}
+	// save the request, so that the operation could be resumed if it fails
+	requestBody, err := json.Marshal(jsonmysql.NewInstallMySQL(constant.EmptyString, s.Engine.ClusterName, s.Engine.Mode, s.Engine.Addrs,
+		s.Engine.MySQLServer, s.Engine.PMMClient, s.Engine.RollbackOnFailure, s.Engine.ParameterProfile, s.Engine.ExtraParameters, s.Engine.AutoSizing))
+	if err != nil {
+		return constant.ZeroInt, errors.Trace(err)
+	}
+
+	return s.startOperation(defaultInstallOperation, string(requestBody), s.install, installSuccessMessage, installPanicMessage)
+}
+
+// checkClusterName checks if the cluster name of the engine is valid for a new cluster,
+// if the cluster name is empty, the addr of the source will be used as the cluster name
+func (s *Service) checkClusterName() error {
+	if s.Engine.ClusterName == constant.EmptyString {
+		if len(s.Engine.Addrs) == constant.ZeroInt {
+			return errors.New("mysql Service.checkClusterName(): addrs must not be empty")
+		}
+		// the first addr is the source after sorting, the same as the engine does when installing
+		addrs := make([]string, len(s.Engine.Addrs))
+		copy(addrs, s.Engine.Addrs)
+		err := linux.SortAddrs(addrs)
+		if err != nil {
+			return err
+		}
+		s.Engine.SetClusterName(addrs[constant.ZeroInt])
+	}
+
+	cluster, err := s.Engine.clusterRepo.findClusterByName(s.Engine.ClusterName)
 	if err != nil {
 		return err
 	}
+	if cluster != nil {
+		return errors.Errorf("mysql Service.checkClusterName(): cluster already exists. clusterName: %s", s.Engine.ClusterName)
+	}
+
+	return nil
+}
+
+// resolveParameters merges the parameters of the profile and the extra parameters of the engine into the parameters of the mysql server,
+// the extra parameters take precedence over the profile, and the merged parameters are validated by rendering the mysqld section
+func (s *Service) resolveParameters() error {
+	parameters := make(map[string]string)
+	for name, value := range s.Engine.MySQLServer.Parameters {
+		parameters[parameter.NormalizeVariableName(name)] = value
+	}
+	if s.Engine.ParameterProfile != constant.EmptyString {
+		parameterProfile, err := s.DBORepo.GetParameterProfileByName(s.Engine.ParameterProfile)
+		if err != nil {
+			return err
+		}
+		profileParameters, err := parameterProfile.GetParameters()
+		if err != nil {
+			return err
+		}
+		for name, value := range profileParameters {
+			parameters[parameter.NormalizeVariableName(name)] = value
+		}
+	}
+	for name, value := range s.Engine.ExtraParameters {
+		parameters[parameter.NormalizeVariableName(name)] = value
+	}
+	if len(parameters) == constant.ZeroInt {
+		return nil
+	}
+
+	s.Engine.MySQLServer.SetParameters(parameters)
+	_, err := s.Engine.MySQLServer.GetMySQLDConfig(s.Engine.mysqlVersion, s.Engine.Mode)
+
+	return err
+}
+
+// Resume resumes the failed install operation asynchronously,
+// the steps which were completed in the previous run will be skipped,
+// the engine must be initialized with the request of the operation
+func (s *Service) Resume(operationID int) error {
 	// get lock
-	err = s.DBORepo.GetLock(operationID, s.Engine.Addrs)
+	err := s.DBORepo.GetLock(operationID, s.Engine.Addrs)
+	if err != nil {
+		return err
+	}
+	err = s.DBORepo.ResumeOperationHistory(operationID)
+	if err != nil {
+		releaseErr := s.DBORepo.ReleaseLock(operationID)
+		if releaseErr != nil {
+			log.Errorf(constant.LogWithStackString, releaseErr)
+		}
+		return err
+	}
+	// run the operation in the background
+	go s.runOperation(operationID, s.install, installSuccessMessage, installPanicMessage)
+
+	return nil
+}
+
+// install installs the mysql with the engine
+func (s *Service) install(operationID int) error {
+	err := s.Engine.Install(operationID)
+	if err != nil {
+		log.Errorf(constant.LogWithStackString, message.NewMessage(msgMySQL.ErrMySQLServiceInstallMySQL, err,
+			s.Engine.MySQLServer.Version, s.Engine.Mode, common.ConvertSliceToString(s.Engine.Addrs, constant.CommaString)))
+	}
+
+	return err
+}
+
+// Remove removes the mysql instances of the target hosts asynchronously,
+// the data and log directories will be archived instead of being deleted if archive is true,
+// and the mysql binary will also be removed if removeBinary is true and no other instance on the host is still using it
+func (s *Service) Remove(archive, removeBinary bool) (int, error) {
+	operationType := defaultRemoveInstanceOperation
+	if removeBinary {
+		operationType = defaultRemoveBinaryOperation
+	}
+
+	return s.startOperation(operationType, constant.EmptyString, func(operationID int) error {
+		err := s.Engine.Remove(operationID, archive, removeBinary)
+		if err != nil {
+			log.Errorf(constant.LogWithStackString, message.NewMessage(msgMySQL.ErrMySQLServiceRemoveMySQL, err,
+				archive, removeBinary, common.ConvertSliceToString(s.Engine.Addrs, constant.CommaString)))
+		}
+
+		return err
+	}, removeSuccessMessage, removePanicMessage)
+}
+
+// AddReplica installs new instances of the target hosts and provisions them from the existing source
+// with the clone plugin asynchronously
+func (s *Service) AddReplica(sourceAddr string) (int, error) {
+	return s.startOperation(defaultAddReplicaOperation, constant.EmptyString, func(operationID int) error {
+		err := s.Engine.AddReplica(operationID, sourceAddr)
+		if err != nil {
+			log.Errorf(constant.LogWithStackString, message.NewMessage(msgMySQL.ErrMySQLServiceAddReplica, err,
+				s.Engine.MySQLServer.Version, s.Engine.Mode, sourceAddr, common.ConvertSliceToString(s.Engine.Addrs, constant.CommaString)))
+		}
+
+		return err
+	}, addReplicaSuccessMessage, addReplicaPanicMessage)
+}
+
+// Switchover promotes the target replica to be the new source of the cluster of the target hosts asynchronously,
+// the old source and the other replicas will replicate from the new source after the switchover
+func (s *Service) Switchover(sourceAddr, targetAddr string) (int, error) {
+	return s.startOperation(defaultSwitchoverOperation, constant.EmptyString, func(operationID int) error {
+		err := s.Engine.Switchover(operationID, sourceAddr, targetAddr)
+		if err != nil {
+			log.Errorf(constant.LogWithStackString, message.NewMessage(msgMySQL.ErrMySQLServiceSwitchover, err,
+				s.Engine.Mode, sourceAddr, targetAddr, common.ConvertSliceToString(s.Engine.Addrs, constant.CommaString)))
+		}
+
+		return err
+	}, switchoverSuccessMessage, switchoverPanicMessage)
+}
+
+// Failover promotes the most advanced surviving replica to be the new source of the cluster of the target hosts asynchronously
+// when the source is dead, the old source will be fenced after the failover
+func (s *Service) Failover(sourceAddr string) (int, error) {
+	return s.startOperation(defaultFailoverOperation, constant.EmptyString, func(operationID int) error {
+		err := s.Engine.Failover(operationID, sourceAddr)
+		if err != nil {
+			log.Errorf(constant.LogWithStackString, message.NewMessage(msgMySQL.ErrMySQLServiceFailover, err,
+				s.Engine.Mode, sourceAddr, common.ConvertSliceToString(s.Engine.Addrs, constant.CommaString)))
+		}
+
+		return err
+	}, failoverSuccessMessage, failoverPanicMessage)
+}
+
+// Upgrade upgrades the mysql instances of the target hosts to the version of the engine in place asynchronously
+func (s *Service) Upgrade() (int, error) {
+	return s.startOperation(defaultUpgradeOperation, constant.EmptyString, func(operationID int) error {
+		err := s.Engine.Upgrade(operationID)
+		if err != nil {
+			log.Errorf(constant.LogWithStackString, message.NewMessage(msgMySQL.ErrMySQLServiceUpgradeMySQL, err,
+				s.Engine.MySQLServer.Version, common.ConvertSliceToString(s.Engine.Addrs, constant.CommaString)))
+		}
+
+		return err
+	}, upgradeSuccessMessage, upgradePanicMessage)
+}
+
+// Start starts the mysql instances of the target hosts with mysqld_multi asynchronously
+func (s *Service) Start() (int, error) {
+	return s.startOperation(defaultStartOperation, constant.EmptyString, func(operationID int) error {
+		err := s.Engine.Start(operationID)
+		if err != nil {
+			log.Errorf(constant.LogWithStackString, message.NewMessage(msgMySQL.ErrMySQLServiceStartInstance, err,
+				common.ConvertSliceToString(s.Engine.Addrs, constant.CommaString)))
+		}
+
+		return err
+	}, startSuccessMessage, startPanicMessage)
+}
+
+// Stop stops the mysql instances of the target hosts with mysqld_multi asynchronously,
+// innodb_fast_shutdown will be set to 0 before shutting down if slowShutdown is true
+func (s *Service) Stop(slowShutdown bool) (int, error) {
+	return s.startOperation(defaultStopOperation, constant.EmptyString, func(operationID int) error {
+		err := s.Engine.Stop(operationID, slowShutdown)
+		if err != nil {
+			log.Errorf(constant.LogWithStackString, message.NewMessage(msgMySQL.ErrMySQLServiceStopInstance, err,
+				slowShutdown, common.ConvertSliceToString(s.Engine.Addrs, constant.CommaString)))
+		}
+
+		return err
+	}, stopSuccessMessage, stopPanicMessage)
+}
+
+// Restart restarts the mysql instances of the target hosts one by one asynchronously,
+// innodb_fast_shutdown will be set to 0 before shutting down if slowShutdown is true
+func (s *Service) Restart(slowShutdown bool) (int, error) {
+	return s.startOperation(defaultRestartOperation, constant.EmptyString, func(operationID int) error {
+		err := s.Engine.Restart(operationID, slowShutdown)
+		if err != nil {
+			log.Errorf(constant.LogWithStackString, message.NewMessage(msgMySQL.ErrMySQLServiceRestartInstance, err,
+				slowShutdown, common.ConvertSliceToString(s.Engine.Addrs, constant.CommaString)))
+		}
+
+		return err
+	}, restartSuccessMessage, restartPanicMessage)
+}
+
+// SetParameters changes the variables of the mysql instances of the target hosts asynchronously,
+// it returns the operation id and the names of the static variables which will only take effect after the instance is restarted
+func (s *Service) SetParameters(variables map[string]string) (int, []string, error) {
+	restartRequired, err := s.Engine.CheckParameters(variables)
 	if err != nil {
+		return constant.ZeroInt, nil, err
+	}
+
+	operationID, err := s.startOperation(defaultSetParameterOperation, constant.EmptyString, func(operationID int) error {
+		err := s.Engine.SetParameters(operationID, variables)
+		if err != nil {
+			log.Errorf(constant.LogWithStackString, message.NewMessage(msgMySQL.ErrMySQLServiceSetParameters, err,
+				variables, common.ConvertSliceToString(s.Engine.Addrs, constant.CommaString)))
+		}
+
 		return err
+	}, setParametersSuccessMessage, setParametersPanicMessage)
+	if err != nil {
+		return operationID, nil, err
 	}
+
+	return operationID, restartRequired, nil
+}
+
+// Backup takes the physical backups of the mysql instances of the target hosts asynchronously
+func (s *Service) Backup(backupType, backupMethod int) (int, error) {
+	err := s.Engine.CheckBackup(backupType, backupMethod)
+	if err != nil {
+		return constant.ZeroInt, err
+	}
+
+	return s.startOperation(defaultBackupOperation, constant.EmptyString, func(operationID int) error {
+		err := s.Engine.Backup(operationID, backupType, backupMethod)
+		if err != nil {
+			log.Errorf(constant.LogWithStackString, message.NewMessage(msgMySQL.ErrMySQLServiceBackup, err,
+				backupType, backupMethod, common.ConvertSliceToString(s.Engine.Addrs, constant.CommaString)))
+		}
+
+		return err
+	}, backupSuccessMessage, backupPanicMessage)
+}
+
+// Restore installs a fresh instance of the target host from the backup set and replays the binlogs asynchronously,
+// the binlogs are replayed up to the stop gtid or the stop datetime
+func (s *Service) Restore(backupID int, stopGTID, stopDatetime string) (int, error) {
+	err := s.Engine.CheckRestore(backupID, stopGTID, stopDatetime)
+	if err != nil {
+		return constant.ZeroInt, err
+	}
+
+	return s.startOperation(defaultRestoreOperation, constant.EmptyString, func(operationID int) error {
+		err := s.Engine.Restore(operationID, backupID, stopGTID, stopDatetime)
+		if err != nil {
+			log.Errorf(constant.LogWithStackString, message.NewMessage(msgMySQL.ErrMySQLServiceRestore, err,
+				backupID, stopGTID, stopDatetime, common.ConvertSliceToString(s.Engine.Addrs, constant.CommaString)))
+		}
+
+		return err
+	}, restoreSuccessMessage, restorePanicMessage)
+}
+
+// startOperation initializes the operation history with the request body and gets the operation lock of the addrs,
+// then it runs the operation in the background and returns the operation id immediately
+func (s *Service) startOperation(operationType int, requestBody string, operate func(operationID int) error, successMessage, panicMessage string) (int, error) {
+	// init operation id
+	operationID, err := s.DBORepo.InitOperationHistory(operationType, s.Engine.Addrs, requestBody)
+	if err != nil {
+		return constant.ZeroInt, err
+	}
+	// get lock
+	err = s.DBORepo.GetLock(operationID, s.Engine.Addrs)
+	if err != nil {
+		s.updateOperationHistory(operationID, defaultFailedStatus, err.Error())
+		return operationID, err
+	}
+	// run the operation in the background
+	go s.runOperation(operationID, operate, successMessage, panicMessage)
+
+	return operationID, nil
+}
+
+// runOperation runs the operation, it renews the operation lock while running,
+// and releases the operation lock and records the result when finished,
+// the operation could be cancelled by the context which is registered with the operation id
+func (s *Service) runOperation(operationID int, operate func(operationID int) error, successMessage, panicMessage string) {
+	ctx, cancel := context.WithCancel(context.Background())
+	runningOperations.register(operationID, cancel)
+	s.Engine.SetContext(ctx)
+
+	heartbeatStopChan := s.keepLockAlive(operationID, cancel)
 	defer func() {
-		err = s.DBORepo.ReleaseLock(operationID)
+		close(heartbeatStopChan)
+		runningOperations.unregister(operationID)
+		cancel()
+
+		if r := recover(); r != nil {
+			log.Errorf("mysql Service.runOperation(): panic recovered. operationID: %d, panic: %v", operationID, r)
+			s.updateOperationHistory(operationID, defaultFailedStatus, panicMessage)
+		}
+
+		err := s.DBORepo.ReleaseLock(operationID)
 		if err != nil {
 			log.Errorf(constant.LogWithStackString, err)
 		}
 	}()
-	// install mysql
-	return s.Engine.Install(operationID)
+
+	err := operate(operationID)
+	if err != nil {
+		if ctx.Err() != nil {
+			s.updateOperationHistory(operationID, defaultCancelledStatus, fmt.Sprintf(operationCancelledMessageTemplate, err.Error()))
+			return
+		}
+		s.updateOperationHistory(operationID, defaultFailedStatus, err.Error())
+		return
+	}
+
+	s.updateOperationHistory(operationID, defaultSuccessStatus, successMessage)
+}
+
+// updateOperationHistory updates the operation history, it only logs the error if failed
+func (s *Service) updateOperationHistory(operationID, status int, msg string) {
+	err := s.DBORepo.UpdateOperationHistory(operationID, status, msg)
+	if err != nil {
+		log.Errorf(constant.LogWithStackString, message.NewMessage(msgMySQL.ErrMySQLServiceUpdateOperationHistory, err, operationID, status))
+	}
 }
@@ -2,10 +2,16 @@ package mysql
 
 import (
 	"testing"
+	"time"
 
+	"github.com/romberli/go-util/constant"
 	"github.com/stretchr/testify/assert"
 )
 
+const (
+	testWaitForOperationInterval = 10 * time.Second
+)
+
 var (
 	testService *Service
 )
@@ -27,6 +33,20 @@ func testInitService() *Service {
 	return NewServiceWithDefault(testEngine)
 }
 
+func testWaitForOperation(operationID int) (int, error) {
+	for {
+		operationInfo, err := testService.GetOperationHistory(operationID)
+		if err != nil {
+			return constant.ZeroInt, err
+		}
+		if operationInfo.Status != defaultRunningStatus {
+			return operationInfo.Status, nil
+		}
+
+		time.Sleep(testWaitForOperationInterval)
+	}
+}
+
 func TestService_Install(t *testing.T) {
 	asst := assert.New(t)
 
@@ -34,9 +54,37 @@ func TestService_Install(t *testing.T) {
 	err := testClearMySQL(testAddrs...)
 	asst.Nil(err, "test InstallSingleInstance() failed")
 	// install
-	err = testService.Install()
+	operationID, err := testService.Install()
+	asst.Nil(err, "test Install() failed")
+	// wait for the installation to finish
+	status, err := testWaitForOperation(operationID)
 	asst.Nil(err, "test Install() failed")
+	asst.Equal(defaultSuccessStatus, status, "test Install() failed")
 	// clear previous mysql server
 	// err = testClearMySQL(testAddrs...)
 	asst.Nil(err, "test InstallSingleInstance() failed")
 }
+
+func TestService_CheckClusterName(t *testing.T) {
+	asst := assert.New(t)
+
+	clusterName := testService.Engine.ClusterName
+	defer testService.Engine.SetClusterName(clusterName)
+	// the addr of the source is used as the cluster name if it is empty
+	testService.Engine.SetClusterName(constant.EmptyString)
+	err := testService.checkClusterName()
+	asst.Nil(err, "test CheckClusterName() failed")
+	asst.Equal(testAddr1, testService.Engine.ClusterName, "test CheckClusterName() failed")
+}
+
+func TestService_Remove(t *testing.T) {
+	asst := assert.New(t)
+
+	// remove
+	operationID, err := testService.Remove(true, false)
+	asst.Nil(err, "test Remove() failed")
+	// wait for the removal to finish
+	status, err := testWaitForOperation(operationID)
+	asst.Nil(err, "test Remove() failed")
+	asst.Equal(defaultSuccessStatus, status, "test Remove() failed")
+}
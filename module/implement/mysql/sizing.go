@@ -0,0 +1,134 @@
+package mysql
+
+import (
+	"fmt"
+
+	"github.com/romberli/go-util/constant"
+	"github.com/romberli/log"
+
+	"github.com/romberli/db-operator/pkg/message"
+
+	msgMySQL "github.com/romberli/db-operator/pkg/message/mysql"
+)
+
+const (
+	// the hosts whose memory is not larger than this use a smaller ratio of the memory for the buffer pools
+	sizingSmallMemory               = 4 << 30
+	sizingSmallMemoryPercent        = 50
+	sizingMemoryPercent             = 75
+	sizingBufferPoolChunkSize       = 128 << 20
+	sizingSSDIOCapacity             = 8000
+	sizingHDDIOCapacity             = 400
+	sizingMinSSDIOCapacity          = 1000
+	sizingMinHDDIOCapacity          = 100
+	sizingMinIOThreads              = 4
+	sizingMaxIOThreads              = 64
+	sizingMinReplicaParallelWorkers = 4
+	sizingMaxReplicaParallelWorkers = 32
+
+	bufferPoolSizeTemplate = "%dM"
+)
+
+// autoSizeIfEnabled sizes the mysql server if auto sizing is enabled,
+// the resources of the host must be shared with the other instances before generating the config file
+func (e *Engine) autoSizeIfEnabled() error {
+	if !e.AutoSizing {
+		return nil
+	}
+
+	return e.autoSize()
+}
+
+// autoSize collects the hardware facts of the host and sets the options of the mysql server which are derived from them,
+// the resources of the host are shared evenly by the instances defined in the config file,
+// the instances of the addrs on the same host and the new instance
+func (e *Engine) autoSize() error {
+	portNums, err := e.getHostPortNums(e.MySQLServer.HostIP)
+	if err != nil {
+		return err
+	}
+	hostFacts, err := e.ose.GetHostFacts(portNums)
+	if err != nil {
+		return err
+	}
+
+	innodbBufferPoolSize, innodbIOCapacity, innodbIOThreads, replicaParallelWorkers := getResourceSizing(hostFacts)
+	e.MySQLServer.SetResourceSizing(innodbBufferPoolSize, innodbIOCapacity, innodbIOThreads, replicaParallelWorkers)
+
+	log.Infof(message.NewMessage(msgMySQL.InfoMySQLEngineAutoSizing, e.MySQLServer.HostIP, e.MySQLServer.PortNum,
+		hostFacts.TotalMemory, hostFacts.CPUCount, hostFacts.Rotational, hostFacts.InstanceCount,
+		innodbBufferPoolSize, innodbIOCapacity, innodbIOThreads, replicaParallelWorkers).Error())
+
+	return nil
+}
+
+// getHostPortNums returns the port numbers of the addrs on the given host,
+// the instances which are installed by the same request may not be in the config file yet when sizing
+func (e *Engine) getHostPortNums(hostIP string) ([]int, error) {
+	hostAddrsList, err := groupAddrsByHost(e.Addrs)
+	if err != nil {
+		return nil, err
+	}
+
+	var portNums []int
+	for _, hostAddrs := range hostAddrsList {
+		for _, addr := range hostAddrs {
+			addrHostIP, portNum, err := splitAddr(addr)
+			if err != nil {
+				return nil, err
+			}
+			if addrHostIP != hostIP {
+				break
+			}
+			portNums = append(portNums, portNum)
+		}
+	}
+
+	return portNums, nil
+}
+
+// getResourceSizing returns the buffer pool size, io capacity, io threads and replica parallel workers of each instance on the host
+func getResourceSizing(hostFacts *HostFacts) (string, int, int, int) {
+	instanceCount := hostFacts.InstanceCount + constant.OneInt
+
+	// buffer pool, it is rounded down to the multiple of the chunk size
+	memoryPercent := int64(sizingMemoryPercent)
+	if hostFacts.TotalMemory <= sizingSmallMemory {
+		memoryPercent = sizingSmallMemoryPercent
+	}
+	bufferPoolSize := hostFacts.TotalMemory * memoryPercent / 100 / int64(instanceCount)
+	bufferPoolSize = bufferPoolSize / sizingBufferPoolChunkSize * sizingBufferPoolChunkSize
+	if bufferPoolSize < sizingBufferPoolChunkSize {
+		bufferPoolSize = sizingBufferPoolChunkSize
+	}
+
+	// io capacity
+	ioCapacity := sizingSSDIOCapacity / instanceCount
+	minIOCapacity := sizingMinSSDIOCapacity
+	if hostFacts.Rotational {
+		ioCapacity = sizingHDDIOCapacity / instanceCount
+		minIOCapacity = sizingMinHDDIOCapacity
+	}
+	if ioCapacity < minIOCapacity {
+		ioCapacity = minIOCapacity
+	}
+
+	// threads
+	cpuCount := hostFacts.CPUCount / instanceCount
+	ioThreads := getBoundedValue(cpuCount, sizingMinIOThreads, sizingMaxIOThreads)
+	replicaParallelWorkers := getBoundedValue(cpuCount, sizingMinReplicaParallelWorkers, sizingMaxReplicaParallelWorkers)
+
+	return fmt.Sprintf(bufferPoolSizeTemplate, bufferPoolSize>>20), ioCapacity, ioThreads, replicaParallelWorkers
+}
+
+// getBoundedValue returns the value which is limited to [minValue, maxValue]
+func getBoundedValue(value, minValue, maxValue int) int {
+	if value < minValue {
+		return minValue
+	}
+	if value > maxValue {
+		return maxValue
+	}
+
+	return value
+}
@@ -0,0 +1,45 @@
+package mysql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSizing_All(t *testing.T) {
+	TestGetResourceSizing(t)
+	TestEngine_AutoSize(t)
+}
+
+func TestGetResourceSizing(t *testing.T) {
+	asst := assert.New(t)
+
+	// 64G memory, 32 cpus, ssd and 1 other instance
+	innodbBufferPoolSize, innodbIOCapacity, innodbIOThreads, replicaParallelWorkers := getResourceSizing(NewHostFacts(64<<30, 32, false, 1))
+	asst.Equal("24576M", innodbBufferPoolSize, "test getResourceSizing() failed")
+	asst.Equal(4000, innodbIOCapacity, "test getResourceSizing() failed")
+	asst.Equal(16, innodbIOThreads, "test getResourceSizing() failed")
+	asst.Equal(16, replicaParallelWorkers, "test getResourceSizing() failed")
+	// 2G memory, 2 cpus, hdd and no other instance
+	innodbBufferPoolSize, innodbIOCapacity, innodbIOThreads, replicaParallelWorkers = getResourceSizing(NewHostFacts(2<<30, 2, true, 0))
+	asst.Equal("1024M", innodbBufferPoolSize, "test getResourceSizing() failed")
+	asst.Equal(400, innodbIOCapacity, "test getResourceSizing() failed")
+	asst.Equal(4, innodbIOThreads, "test getResourceSizing() failed")
+	asst.Equal(4, replicaParallelWorkers, "test getResourceSizing() failed")
+	// 1G memory and 15 other instances
+	innodbBufferPoolSize, innodbIOCapacity, _, _ = getResourceSizing(NewHostFacts(1<<30, 4, true, 15))
+	asst.Equal("128M", innodbBufferPoolSize, "test getResourceSizing() failed")
+	asst.Equal(100, innodbIOCapacity, "test getResourceSizing() failed")
+}
+
+func TestEngine_AutoSize(t *testing.T) {
+	asst := assert.New(t)
+
+	err := testEngine.MySQLServer.InitWithHostInfo(testHostIP1, testPortNum1, true)
+	asst.Nil(err, "test autoSize() failed")
+	err = testEngine.InitOSExecutor()
+	asst.Nil(err, "test autoSize() failed")
+	err = testEngine.autoSize()
+	asst.Nil(err, "test autoSize() failed")
+	asst.NotEqual(0, testEngine.MySQLServer.InnodbIOCapacity, "test autoSize() failed")
+}
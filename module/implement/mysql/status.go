@@ -0,0 +1,214 @@
+package mysql
+
+import (
+	"fmt"
+	"net"
+	"sync"
+
+	"github.com/romberli/go-util/constant"
+	"github.com/romberli/go-util/middleware/mysql"
+	"github.com/romberli/log"
+
+	"github.com/romberli/db-operator/module/implement/mysql/mode"
+)
+
+const (
+	getInstanceStatusSQL                   = "select @@version, @@read_only, @@super_read_only, @@global.gtid_executed ;"
+	getGroupReplicationMemberRolesSQL      = "select member_host, member_port, member_state, member_role from performance_schema.replication_group_members ;"
+	GroupReplicationMemberRoleField        = "member_role"
+	LastIOErrorField                       = "Last_IO_Error"
+	LastSQLErrorField                      = "Last_SQL_Error"
+	instanceStatusVersionColumnIndex       = 0
+	instanceStatusReadOnlyColumnIndex      = 1
+	instanceStatusSuperReadOnlyColumnIndex = 2
+	instanceStatusGTIDExecutedColumnIndex  = 3
+	readOnlyOnValue                        = 1
+)
+
+// GetClusterStatus connects to all the instances of the addrs concurrently and returns their live status,
+// the instance which could not be connected is reported as down instead of failing the whole inspection,
+// the group membership is also reported if the mode is group replication
+func (e *Engine) GetClusterStatus(clusterName string) *ClusterStatus {
+	var (
+		wg        sync.WaitGroup
+		instances = make([]*InstanceStatus, len(e.Addrs))
+	)
+	for i, addr := range e.Addrs {
+		wg.Add(constant.OneInt)
+		go func(i int, addr string) {
+			defer wg.Done()
+
+			instances[i] = e.getInstanceStatus(addr)
+		}(i, addr)
+	}
+	wg.Wait()
+
+	var groupMembers []*GroupMember
+	if e.Mode == mode.GroupReplication {
+		for _, instance := range instances {
+			if !instance.IsUp {
+				continue
+			}
+			// the membership reported by any of the running members is the view of the group
+			members, err := e.getGroupMembers(instance.Addr)
+			if err != nil {
+				log.Warnf("mysql Engine.GetClusterStatus(): get group members failed. addr: %s, error:\n%+v", instance.Addr, err)
+				continue
+			}
+			groupMembers = members
+			break
+		}
+	}
+
+	return NewClusterStatus(clusterName, int(e.Mode), instances, groupMembers)
+}
+
+// getInstanceStatus returns the live status of the instance of the addr, the error will be recorded in the status
+func (e *Engine) getInstanceStatus(addr string) *InstanceStatus {
+	status := NewInstanceStatusWithDefault(addr)
+
+	conn, err := mysql.NewConn(addr, constant.EmptyString, constant.DefaultRootUserName, e.MySQLServer.RootPass)
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+	defer func() {
+		err = conn.Close()
+		if err != nil {
+			log.Errorf("mysql Engine.getInstanceStatus(): close mysql connection failed. error:\n%+v", err)
+		}
+	}()
+	status.IsUp = true
+
+	err = e.fillInstanceStatus(conn, status)
+	if err != nil {
+		status.Error = err.Error()
+	}
+
+	return status
+}
+
+// fillInstanceStatus fills the status with the variables, the replication status and the semi-sync status of the instance
+func (e *Engine) fillInstanceStatus(conn *mysql.Conn, status *InstanceStatus) error {
+	// variables
+	result, err := conn.Execute(getInstanceStatusSQL)
+	if err != nil {
+		return err
+	}
+	status.Version, err = result.GetString(constant.ZeroInt, instanceStatusVersionColumnIndex)
+	if err != nil {
+		return err
+	}
+	readOnly, err := result.GetInt(constant.ZeroInt, instanceStatusReadOnlyColumnIndex)
+	if err != nil {
+		return err
+	}
+	status.ReadOnly = readOnly == readOnlyOnValue
+	superReadOnly, err := result.GetInt(constant.ZeroInt, instanceStatusSuperReadOnlyColumnIndex)
+	if err != nil {
+		return err
+	}
+	status.SuperReadOnly = superReadOnly == readOnlyOnValue
+	status.GTIDExecuted, err = result.GetString(constant.ZeroInt, instanceStatusGTIDExecutedColumnIndex)
+	if err != nil {
+		return err
+	}
+
+	// replication
+	rs, err := getReplicationSyntaxByVersionStr(status.Version)
+	if err != nil {
+		return err
+	}
+	result, err = conn.Execute(rs.showReplicaStatusSQL)
+	if err != nil {
+		return err
+	}
+	if result.RowNumber() > constant.ZeroInt {
+		status.IsReplica = true
+		status.IOThreadRunning, err = result.GetStringByName(constant.ZeroInt, rs.ioThreadRunningField)
+		if err != nil {
+			return err
+		}
+		status.SQLThreadRunning, err = result.GetStringByName(constant.ZeroInt, rs.sqlThreadRunningField)
+		if err != nil {
+			return err
+		}
+		status.SecondsBehindSource, err = result.GetStringByName(constant.ZeroInt, rs.secondsBehindSourceField)
+		if err != nil {
+			return err
+		}
+		status.LastIOError, err = result.GetStringByName(constant.ZeroInt, LastIOErrorField)
+		if err != nil {
+			return err
+		}
+		status.LastSQLError, err = result.GetStringByName(constant.ZeroInt, LastSQLErrorField)
+		if err != nil {
+			return err
+		}
+	}
+
+	// semi-sync, the status will be empty if the semi-sync plugin is not loaded
+	status.SemiSyncSourceStatus, err = getGlobalStatus(conn, rs.semiSyncSourceStatusVariable)
+	if err != nil {
+		return err
+	}
+	status.SemiSyncReplicaStatus, err = getGlobalStatus(conn, rs.semiSyncReplicaStatusVariable)
+
+	return err
+}
+
+// getGroupMembers returns the members of the group replication viewed by the instance of the addr
+func (e *Engine) getGroupMembers(addr string) ([]*GroupMember, error) {
+	conn, err := mysql.NewConn(addr, constant.EmptyString, constant.DefaultRootUserName, e.MySQLServer.RootPass)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = conn.Close()
+		if err != nil {
+			log.Errorf("mysql Engine.getGroupMembers(): close mysql connection failed. error:\n%+v", err)
+		}
+	}()
+
+	result, err := conn.Execute(getGroupReplicationMemberRolesSQL)
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]*GroupMember, result.RowNumber())
+	for i := constant.ZeroInt; i < result.RowNumber(); i++ {
+		hostIP, err := result.GetStringByName(i, GroupReplicationMemberHostField)
+		if err != nil {
+			return nil, err
+		}
+		portNum, err := result.GetStringByName(i, GroupReplicationMemberPortField)
+		if err != nil {
+			return nil, err
+		}
+		state, err := result.GetStringByName(i, GroupReplicationMemberStateField)
+		if err != nil {
+			return nil, err
+		}
+		role, err := result.GetStringByName(i, GroupReplicationMemberRoleField)
+		if err != nil {
+			return nil, err
+		}
+
+		members[i] = NewGroupMember(net.JoinHostPort(hostIP, portNum), state, role)
+	}
+
+	return members, nil
+}
+
+// getGlobalStatus returns the value of the global status variable, it returns an empty string if the variable does not exist
+func getGlobalStatus(conn *mysql.Conn, variable string) (string, error) {
+	result, err := conn.Execute(fmt.Sprintf(getGlobalStatusSQLTemplate, variable))
+	if err != nil {
+		return constant.EmptyString, err
+	}
+	if result.RowNumber() == constant.ZeroInt {
+		return constant.EmptyString, nil
+	}
+
+	return result.GetStringByName(constant.ZeroInt, GlobalStatusValueField)
+}
@@ -0,0 +1,28 @@
+package mysql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatus_All(t *testing.T) {
+	TestEngine_GetClusterStatus(t)
+}
+
+func TestEngine_GetClusterStatus(t *testing.T) {
+	asst := assert.New(t)
+
+	err := testInitInstance(testAddrs)
+	asst.Nil(err, "test GetClusterStatus() failed")
+	err = testEngine.ConfigureReplica(testAddr2, testHostIP1, testPortNum1)
+	asst.Nil(err, "test GetClusterStatus() failed")
+
+	clusterStatus := testEngine.GetClusterStatus(testClusterName)
+	asst.Equal(len(testAddrs), len(clusterStatus.Instances), "test GetClusterStatus() failed")
+	for _, instance := range clusterStatus.Instances {
+		asst.True(instance.IsUp, "test GetClusterStatus() failed")
+		asst.Empty(instance.Error, "test GetClusterStatus() failed")
+		asst.Equal(instance.Addr == testAddr2, instance.IsReplica, "test GetClusterStatus() failed")
+	}
+}
@@ -0,0 +1,332 @@
+package mysql
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/pingcap/errors"
+	"github.com/romberli/go-util/common"
+	"github.com/romberli/go-util/constant"
+	"github.com/romberli/go-util/middleware/mysql"
+	"github.com/romberli/log"
+
+	"github.com/romberli/db-operator/module/implement/mysql/mode"
+	"github.com/romberli/db-operator/pkg/message"
+
+	msgMySQL "github.com/romberli/db-operator/pkg/message/mysql"
+)
+
+const (
+	switchoverSuccessMessage = "switchover completed."
+	switchoverPanicMessage   = "switchover failed because of panic, please check the log for more details."
+
+	demoteSourceSuccessMessage   = "demote source completed."
+	catchUpSuccessMessage        = "target caught up with the source."
+	promoteTargetSuccessMessage  = "promote target completed."
+	repointReplicaSuccessMessage = "repoint replica completed."
+
+	defaultWaitForGTIDTimeout = 60
+
+	getGTIDExecutedSQL                 = "select @@global.gtid_executed ;"
+	waitForExecutedGTIDSetSQLTemplate  = "select wait_for_executed_gtid_set('%s', %d) ;"
+	getReplicationSourceSQL            = "select host, port from performance_schema.replication_connection_configuration where channel_name = '' ;"
+	ReplicationSourceHostField         = "host"
+	ReplicationSourcePortField         = "port"
+	waitForExecutedGTIDSetSuccessValue = 0
+)
+
+// Switchover promotes the target replica to be the new source in a planned way,
+// the old source is set read only and the target waits for applying all the transactions of the old source before being promoted,
+// then the old source and the other replicas of the addrs are repointed to the new source
+func (e *Engine) Switchover(operationID int, sourceAddr, targetAddr string) error {
+	if e.Mode != mode.AsyncReplication && e.Mode != mode.SemiSyncReplication {
+		return errors.Errorf("mysql Engine.Switchover(): only async replication and semi-sync replication modes are supported. mode: %d", e.Mode)
+	}
+	if sourceAddr == targetAddr {
+		return errors.Errorf("mysql Engine.Switchover(): the target must not be the source. source: %s, target: %s", sourceAddr, targetAddr)
+	}
+	if !common.ElementInSlice(e.Addrs, sourceAddr) || !common.ElementInSlice(e.Addrs, targetAddr) {
+		return errors.Errorf("mysql Engine.Switchover(): both the source and the target must be in the addrs. source: %s, target: %s, addrs: %v",
+			sourceAddr, targetAddr, e.Addrs)
+	}
+	// check if the target is replicating from the source
+	err := e.checkReplicationSource(targetAddr, sourceAddr)
+	if err != nil {
+		return err
+	}
+
+	// demote the old source
+	err = e.runOperationDetail(operationID, sourceAddr, demoteSourceSuccessMessage, func() error {
+		return e.executeReplicationSQLs(sourceAddr, func(rs *replicationSyntax) []string {
+			return []string{rs.getSetVariableSQL(superReadOnlyVariable, variableOnValue)}
+		})
+	})
+	if err != nil {
+		return err
+	}
+	// wait for the target to apply all the transactions of the old source
+	err = e.runOperationDetail(operationID, targetAddr, catchUpSuccessMessage, func() error {
+		return e.waitForCatchUp(targetAddr, sourceAddr)
+	})
+	if err != nil {
+		// the target is not promoted, so the old source could continue serving the writes
+		undoErr := e.executeReplicationSQLs(sourceAddr, func(rs *replicationSyntax) []string {
+			return []string{
+				rs.getSetVariableSQL(superReadOnlyVariable, variableOffValue),
+				rs.getSetVariableSQL(readOnlyVariable, variableOffValue),
+			}
+		})
+		if undoErr != nil {
+			log.Errorf("mysql Engine.Switchover(): undo demoting the source failed. source: %s, error:\n%+v", sourceAddr, undoErr)
+		}
+		return err
+	}
+	// promote the target
+	err = e.runOperationDetail(operationID, targetAddr, promoteTargetSuccessMessage, func() error {
+		return e.promoteSource(targetAddr)
+	})
+	if err != nil {
+		return err
+	}
+	// repoint the old source and the other replicas to the new source
+	for _, addr := range e.Addrs {
+		if addr == targetAddr {
+			continue
+		}
+
+		err = e.runOperationDetail(operationID, addr, repointReplicaSuccessMessage, func() error {
+			return e.repointReplica(addr, targetAddr)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	e.setClusterSource(targetAddr)
+	log.Infof(message.NewMessage(msgMySQL.InfoMySQLEngineSwitchover, operationID, sourceAddr, targetAddr).Error())
+
+	return nil
+}
+
+// runOperationDetail records the given operation step of the addr as an operation detail
+func (e *Engine) runOperationDetail(operationID int, addr, successMessage string, stepFunc func() error) error {
+	hostIP, portNum, err := splitAddr(addr)
+	if err != nil {
+		return err
+	}
+	operationDetailID, err := e.dboRepo.InitOperationDetail(operationID, hostIP, portNum)
+	if err != nil {
+		return err
+	}
+
+	err = stepFunc()
+	if err != nil {
+		e.updateOperationDetail(operationID, operationDetailID, hostIP, portNum, defaultFailedStatus, err.Error())
+		return err
+	}
+	e.updateOperationDetail(operationID, operationDetailID, hostIP, portNum, defaultSuccessStatus, successMessage)
+
+	return nil
+}
+
+// executeSQLs executes the sqls on the mysql server of the addr one by one
+func (e *Engine) executeSQLs(addr string, sqls ...string) error {
+	conn, err := mysql.NewConn(addr, constant.EmptyString, constant.DefaultRootUserName, e.MySQLServer.RootPass)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = conn.Close()
+		if err != nil {
+			log.Errorf("mysql Engine.executeSQLs(): close mysql connection failed. error:\n%+v", err)
+		}
+	}()
+
+	for _, sql := range sqls {
+		_, err = conn.Execute(sql)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// executeReplicationSQLs executes the sqls on the mysql server of the addr one by one,
+// the sqls are generated with the replication syntax of the version of the running mysql server
+func (e *Engine) executeReplicationSQLs(addr string, getSQLs func(rs *replicationSyntax) []string) error {
+	conn, err := mysql.NewConn(addr, constant.EmptyString, constant.DefaultRootUserName, e.MySQLServer.RootPass)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = conn.Close()
+		if err != nil {
+			log.Errorf("mysql Engine.executeReplicationSQLs(): close mysql connection failed. error:\n%+v", err)
+		}
+	}()
+
+	rs, err := getConnReplicationSyntax(conn)
+	if err != nil {
+		return err
+	}
+	for _, sql := range getSQLs(rs) {
+		_, err = conn.Execute(sql)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkReplicationSource checks if the replica of the addr is replicating from the source of the sourceAddr
+func (e *Engine) checkReplicationSource(addr, sourceAddr string) error {
+	conn, err := mysql.NewConn(addr, constant.EmptyString, constant.DefaultRootUserName, e.MySQLServer.RootPass)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = conn.Close()
+		if err != nil {
+			log.Errorf("mysql Engine.checkReplicationSource(): close mysql connection failed. error:\n%+v", err)
+		}
+	}()
+
+	result, err := conn.Execute(getReplicationSourceSQL)
+	if err != nil {
+		return err
+	}
+	if result.RowNumber() == constant.ZeroInt {
+		return errors.Errorf("mysql Engine.checkReplicationSource(): replication is not configured. addr: %s", addr)
+	}
+	hostIP, err := result.GetStringByName(constant.ZeroInt, ReplicationSourceHostField)
+	if err != nil {
+		return err
+	}
+	portNum, err := result.GetStringByName(constant.ZeroInt, ReplicationSourcePortField)
+	if err != nil {
+		return err
+	}
+	actualSourceAddr := net.JoinHostPort(hostIP, portNum)
+	if actualSourceAddr != sourceAddr {
+		return errors.Errorf("mysql Engine.checkReplicationSource(): the replica is not replicating from the source. addr: %s, expectedSource: %s, actualSource: %s",
+			addr, sourceAddr, actualSourceAddr)
+	}
+
+	return e.waitForReplicaRunning(conn, addr)
+}
+
+// waitForCatchUp waits for the replica of the addr to apply all the transactions executed on the source of the sourceAddr
+func (e *Engine) waitForCatchUp(addr, sourceAddr string) error {
+	gtidSet, err := e.getGTIDExecuted(sourceAddr)
+	if err != nil {
+		return err
+	}
+
+	conn, err := mysql.NewConn(addr, constant.EmptyString, constant.DefaultRootUserName, e.MySQLServer.RootPass)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = conn.Close()
+		if err != nil {
+			log.Errorf("mysql Engine.waitForCatchUp(): close mysql connection failed. error:\n%+v", err)
+		}
+	}()
+
+	result, err := conn.Execute(fmt.Sprintf(waitForExecutedGTIDSetSQLTemplate, gtidSet, defaultWaitForGTIDTimeout))
+	if err != nil {
+		return err
+	}
+	ret, err := result.GetInt(constant.ZeroInt, constant.ZeroInt)
+	if err != nil {
+		return err
+	}
+	if ret != waitForExecutedGTIDSetSuccessValue {
+		return errors.Errorf("mysql Engine.waitForCatchUp(): the replica did not apply all the transactions of the source in time. addr: %s, source: %s, gtidSet: %s, timeout: %d",
+			addr, sourceAddr, gtidSet, defaultWaitForGTIDTimeout)
+	}
+
+	return nil
+}
+
+// getGTIDExecuted gets the gtid_executed of the mysql server of the addr
+func (e *Engine) getGTIDExecuted(addr string) (string, error) {
+	conn, err := mysql.NewConn(addr, constant.EmptyString, constant.DefaultRootUserName, e.MySQLServer.RootPass)
+	if err != nil {
+		return constant.EmptyString, err
+	}
+	defer func() {
+		err = conn.Close()
+		if err != nil {
+			log.Errorf("mysql Engine.getGTIDExecuted(): close mysql connection failed. error:\n%+v", err)
+		}
+	}()
+
+	result, err := conn.Execute(getGTIDExecutedSQL)
+	if err != nil {
+		return constant.EmptyString, err
+	}
+
+	return result.GetString(constant.ZeroInt, constant.ZeroInt)
+}
+
+// promoteSource stops the replication of the replica of the addr and makes it writable,
+// the semi-sync source will be enabled if the mode is semi-sync replication
+func (e *Engine) promoteSource(addr string) error {
+	return e.executeReplicationSQLs(addr, func(rs *replicationSyntax) []string {
+		sqls := []string{rs.stopReplicaSQL, rs.resetReplicaAllSQL}
+		if e.Mode == mode.SemiSyncReplication {
+			sqls = append(sqls, rs.getSetSemiSyncSQLs(true, false)...)
+		}
+
+		return append(sqls,
+			rs.getSetVariableSQL(superReadOnlyVariable, variableOffValue),
+			rs.getSetVariableSQL(readOnlyVariable, variableOffValue),
+		)
+	})
+}
+
+// repointReplica makes the mysql server of the addr replicate from the source of the sourceAddr with gtid auto position,
+// the mysql server will be set read only, and the semi-sync replica will be enabled if the mode is semi-sync replication
+func (e *Engine) repointReplica(addr, sourceAddr string) error {
+	sourceHostIP, sourcePortNum, err := splitAddr(sourceAddr)
+	if err != nil {
+		return err
+	}
+
+	err = e.executeReplicationSQLs(addr, func(rs *replicationSyntax) []string {
+		sqls := []string{rs.getSetVariableSQL(superReadOnlyVariable, variableOnValue), rs.stopReplicaSQL}
+		if e.Mode == mode.SemiSyncReplication {
+			sqls = append(sqls, rs.getSetSemiSyncSQLs(false, true)...)
+		}
+
+		return append(sqls,
+			rs.getChangeSourceSQL(sourceHostIP, sourcePortNum, e.MySQLServer.ReplicationUser, e.MySQLServer.ReplicationPass),
+			rs.startReplicaSQL,
+		)
+	})
+	if err != nil {
+		return err
+	}
+
+	conn, err := mysql.NewConn(addr, constant.EmptyString, constant.DefaultRootUserName, e.MySQLServer.RootPass)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = conn.Close()
+		if err != nil {
+			log.Errorf("mysql Engine.repointReplica(): close mysql connection failed. error:\n%+v", err)
+		}
+	}()
+
+	err = e.waitForReplicaRunning(conn, addr)
+	if err != nil || e.Mode != mode.SemiSyncReplication {
+		return err
+	}
+
+	// check if semi-sync replication is really working
+	return e.CheckSemiSyncReplication(addr, sourceHostIP, sourcePortNum)
+}
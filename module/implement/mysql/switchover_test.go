@@ -0,0 +1,30 @@
+package mysql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSwitchover_All(t *testing.T) {
+	TestEngine_Switchover(t)
+}
+
+func TestEngine_Switchover(t *testing.T) {
+	asst := assert.New(t)
+
+	err := testInitInstance(testAddrs)
+	asst.Nil(err, "test Switchover() failed")
+	err = testEngine.ConfigureReplica(testAddr2, testHostIP1, testPortNum1)
+	asst.Nil(err, "test Switchover() failed")
+	// switch over to the replica
+	err = testEngine.Switchover(1, testAddr1, testAddr2)
+	asst.Nil(err, "test Switchover() failed")
+	err = testEngine.checkReplicationSource(testAddr1, testAddr2)
+	asst.Nil(err, "test Switchover() failed")
+	// switch back
+	err = testEngine.Switchover(1, testAddr2, testAddr1)
+	asst.Nil(err, "test Switchover() failed")
+	err = testEngine.checkReplicationSource(testAddr2, testAddr1)
+	asst.Nil(err, "test Switchover() failed")
+}
@@ -1,16 +1,23 @@
 package mysql
 
 import (
-	"github.com/romberli/go-util/constant"
+	"encoding/json"
+	"fmt"
 	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/romberli/go-util/constant"
 )
 
 type OperationInfo struct {
 	ID             int       `json:"id" middleware:"id"`
 	OperationType  int       `json:"operation_type" middleware:"operation_type"`
 	Addrs          string    `json:"addrs" middleware:"addrs"`
+	Owner          string    `json:"owner" middleware:"owner"`
 	Status         int       `json:"status" middleware:"status"`
+	CancelFlag     int       `json:"cancel_flag" middleware:"cancel_flag"`
 	Message        string    `json:"message" middleware:"message"`
+	RequestBody    string    `json:"-" middleware:"request_body"`
 	DelFlag        int       `json:"del_flag" middleware:"del_flag"`
 	CreateTime     time.Time `json:"create_time" middleware:"create_time"`
 	LastUpdateTime time.Time `json:"last_update_time" middleware:"last_update_time"`
@@ -22,14 +29,23 @@ func NewOperationInfoWithDefault() *OperationInfo {
 		ID:             constant.ZeroInt,
 		OperationType:  constant.ZeroInt,
 		Addrs:          constant.EmptyString,
+		Owner:          constant.EmptyString,
 		Status:         constant.ZeroInt,
+		CancelFlag:     constant.ZeroInt,
 		Message:        constant.EmptyString,
+		RequestBody:    constant.EmptyString,
 		DelFlag:        constant.ZeroInt,
 		CreateTime:     time.Time{},
 		LastUpdateTime: time.Time{},
 	}
 }
 
+// IsResumable returns if the operation could be resumed, only the failed or cancelled install operation could be resumed
+func (oi *OperationInfo) IsResumable() bool {
+	return oi.OperationType == defaultInstallOperation && (oi.Status == defaultFailedStatus || oi.Status == defaultCancelledStatus) &&
+		oi.RequestBody != constant.EmptyString
+}
+
 type OperationDetail struct {
 	ID             int       `json:"id" middleware:"id"`
 	OperationID    int       `json:"operation_id" middleware:"operation_id"`
@@ -56,3 +72,399 @@ func NewOperationDetailWithDefault() *OperationDetail {
 		LastUpdateTime: time.Time{},
 	}
 }
+
+type OperationStep struct {
+	ID             int       `json:"id" middleware:"id"`
+	OperationID    int       `json:"operation_id" middleware:"operation_id"`
+	HostIP         string    `json:"host_ip" middleware:"host_ip"`
+	PortNum        int       `json:"port_num" middleware:"port_num"`
+	Step           int       `json:"step" middleware:"step"`
+	Status         int       `json:"status" middleware:"status"`
+	Message        string    `json:"message" middleware:"message"`
+	DelFlag        int       `json:"del_flag" middleware:"del_flag"`
+	CreateTime     time.Time `json:"create_time" middleware:"create_time"`
+	LastUpdateTime time.Time `json:"last_update_time" middleware:"last_update_time"`
+}
+
+// NewOperationStepWithDefault returns a new *OperationStep with default value
+func NewOperationStepWithDefault() *OperationStep {
+	return &OperationStep{
+		ID:             constant.ZeroInt,
+		OperationID:    constant.ZeroInt,
+		HostIP:         constant.EmptyString,
+		PortNum:        constant.ZeroInt,
+		Step:           constant.ZeroInt,
+		Status:         constant.ZeroInt,
+		Message:        constant.EmptyString,
+		DelFlag:        constant.ZeroInt,
+		CreateTime:     time.Time{},
+		LastUpdateTime: time.Time{},
+	}
+}
+
+type OperationLock struct {
+	ID          int    `json:"id" middleware:"id"`
+	OperationID int    `json:"operation_id" middleware:"operation_id"`
+	Addr        string `json:"addr" middleware:"addr"`
+	Owner       string `json:"owner" middleware:"owner"`
+	// Expired is 1 if the lock has not been renewed within the lease timeout, the expired lock will be purged
+	Expired        int       `json:"expired" middleware:"expired"`
+	DelFlag        int       `json:"del_flag" middleware:"del_flag"`
+	CreateTime     time.Time `json:"create_time" middleware:"create_time"`
+	LastUpdateTime time.Time `json:"last_update_time" middleware:"last_update_time"`
+}
+
+// NewOperationLockWithDefault returns a new *OperationLock with default value
+func NewOperationLockWithDefault() *OperationLock {
+	return &OperationLock{
+		ID:             constant.ZeroInt,
+		OperationID:    constant.ZeroInt,
+		Addr:           constant.EmptyString,
+		Owner:          constant.EmptyString,
+		Expired:        constant.ZeroInt,
+		DelFlag:        constant.ZeroInt,
+		CreateTime:     time.Time{},
+		LastUpdateTime: time.Time{},
+	}
+}
+
+type FencedInstance struct {
+	ID             int       `json:"id" middleware:"id"`
+	OperationID    int       `json:"operation_id" middleware:"operation_id"`
+	HostIP         string    `json:"host_ip" middleware:"host_ip"`
+	PortNum        int       `json:"port_num" middleware:"port_num"`
+	Reason         string    `json:"reason" middleware:"reason"`
+	DelFlag        int       `json:"del_flag" middleware:"del_flag"`
+	CreateTime     time.Time `json:"create_time" middleware:"create_time"`
+	LastUpdateTime time.Time `json:"last_update_time" middleware:"last_update_time"`
+}
+
+// NewFencedInstanceWithDefault returns a new *FencedInstance with default value
+func NewFencedInstanceWithDefault() *FencedInstance {
+	return &FencedInstance{
+		ID:             constant.ZeroInt,
+		OperationID:    constant.ZeroInt,
+		HostIP:         constant.EmptyString,
+		PortNum:        constant.ZeroInt,
+		Reason:         constant.EmptyString,
+		DelFlag:        constant.ZeroInt,
+		CreateTime:     time.Time{},
+		LastUpdateTime: time.Time{},
+	}
+}
+
+type ParameterProfile struct {
+	ID             int       `json:"id" middleware:"id"`
+	ProfileName    string    `json:"profile_name" middleware:"profile_name"`
+	Description    string    `json:"description" middleware:"description"`
+	Parameters     string    `json:"parameters" middleware:"parameters"`
+	DelFlag        int       `json:"del_flag" middleware:"del_flag"`
+	CreateTime     time.Time `json:"create_time" middleware:"create_time"`
+	LastUpdateTime time.Time `json:"last_update_time" middleware:"last_update_time"`
+}
+
+// NewParameterProfileWithDefault returns a new *ParameterProfile with default value
+func NewParameterProfileWithDefault() *ParameterProfile {
+	return &ParameterProfile{
+		ID:             constant.ZeroInt,
+		ProfileName:    constant.EmptyString,
+		Description:    constant.EmptyString,
+		Parameters:     constant.EmptyString,
+		DelFlag:        constant.ZeroInt,
+		CreateTime:     time.Time{},
+		LastUpdateTime: time.Time{},
+	}
+}
+
+// GetParameters returns the parameters of the profile, the key is the option name
+func (pp *ParameterProfile) GetParameters() (map[string]string, error) {
+	parameters := make(map[string]string)
+	err := json.Unmarshal([]byte(pp.Parameters), &parameters)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return parameters, nil
+}
+
+type BackupInfo struct {
+	ID             int       `json:"id" middleware:"id"`
+	OperationID    int       `json:"operation_id" middleware:"operation_id"`
+	HostIP         string    `json:"host_ip" middleware:"host_ip"`
+	PortNum        int       `json:"port_num" middleware:"port_num"`
+	BackupType     int       `json:"backup_type" middleware:"backup_type"`
+	BackupMethod   int       `json:"backup_method" middleware:"backup_method"`
+	BaseBackupID   int       `json:"base_backup_id" middleware:"base_backup_id"`
+	BackupDir      string    `json:"backup_dir" middleware:"backup_dir"`
+	FromLSN        int64     `json:"from_lsn" middleware:"from_lsn"`
+	ToLSN          int64     `json:"to_lsn" middleware:"to_lsn"`
+	GTIDSet        string    `json:"gtid_set" middleware:"gtid_set"`
+	BackupSize     int64     `json:"backup_size" middleware:"backup_size"`
+	Checksum       string    `json:"checksum" middleware:"checksum"`
+	Status         int       `json:"status" middleware:"status"`
+	Message        string    `json:"message" middleware:"message"`
+	StartTime      time.Time `json:"start_time" middleware:"start_time"`
+	EndTime        time.Time `json:"end_time" middleware:"end_time"`
+	DelFlag        int       `json:"del_flag" middleware:"del_flag"`
+	CreateTime     time.Time `json:"create_time" middleware:"create_time"`
+	LastUpdateTime time.Time `json:"last_update_time" middleware:"last_update_time"`
+}
+
+// NewBackupInfoWithDefault returns a new *BackupInfo with default value
+func NewBackupInfoWithDefault() *BackupInfo {
+	return &BackupInfo{
+		ID:             constant.ZeroInt,
+		OperationID:    constant.ZeroInt,
+		HostIP:         constant.EmptyString,
+		PortNum:        constant.ZeroInt,
+		BackupType:     constant.ZeroInt,
+		BackupMethod:   constant.ZeroInt,
+		BaseBackupID:   constant.ZeroInt,
+		BackupDir:      constant.EmptyString,
+		FromLSN:        constant.ZeroInt,
+		ToLSN:          constant.ZeroInt,
+		GTIDSet:        constant.EmptyString,
+		BackupSize:     constant.ZeroInt,
+		Checksum:       constant.EmptyString,
+		Status:         constant.ZeroInt,
+		Message:        constant.EmptyString,
+		StartTime:      time.Time{},
+		EndTime:        time.Time{},
+		DelFlag:        constant.ZeroInt,
+		CreateTime:     time.Time{},
+		LastUpdateTime: time.Time{},
+	}
+}
+
+// GetAddr returns the address of the instance of the backup set
+func (bi *BackupInfo) GetAddr() string {
+	return fmt.Sprintf(addrTemplate, bi.HostIP, bi.PortNum)
+}
+
+type ClusterInfo struct {
+	ID             int       `json:"id" middleware:"id"`
+	ClusterName    string    `json:"cluster_name" middleware:"cluster_name"`
+	Mode           int       `json:"mode" middleware:"mode"`
+	Version        string    `json:"version" middleware:"version"`
+	DelFlag        int       `json:"del_flag" middleware:"del_flag"`
+	CreateTime     time.Time `json:"create_time" middleware:"create_time"`
+	LastUpdateTime time.Time `json:"last_update_time" middleware:"last_update_time"`
+}
+
+// NewClusterInfo returns a new *ClusterInfo
+func NewClusterInfo(clusterName string, mode int, version string) *ClusterInfo {
+	clusterInfo := NewClusterInfoWithDefault()
+	clusterInfo.ClusterName = clusterName
+	clusterInfo.Mode = mode
+	clusterInfo.Version = version
+
+	return clusterInfo
+}
+
+// NewClusterInfoWithDefault returns a new *ClusterInfo with default value
+func NewClusterInfoWithDefault() *ClusterInfo {
+	return &ClusterInfo{
+		ID:             constant.ZeroInt,
+		ClusterName:    constant.EmptyString,
+		Mode:           constant.ZeroInt,
+		Version:        constant.EmptyString,
+		DelFlag:        constant.ZeroInt,
+		CreateTime:     time.Time{},
+		LastUpdateTime: time.Time{},
+	}
+}
+
+type InstanceInfo struct {
+	ID             int    `json:"id" middleware:"id"`
+	ClusterID      int    `json:"cluster_id" middleware:"cluster_id"`
+	HostIP         string `json:"host_ip" middleware:"host_ip"`
+	PortNum        int    `json:"port_num" middleware:"port_num"`
+	Role           int    `json:"role" middleware:"role"`
+	ServerID       int    `json:"server_id" middleware:"server_id"`
+	DataDirBase    string `json:"data_dir_base" middleware:"data_dir_base"`
+	LogDirBase     string `json:"log_dir_base" middleware:"log_dir_base"`
+	PMMServiceName string `json:"pmm_service_name" middleware:"pmm_service_name"`
+	// MySQLServerParam is the json of the effective mysql server parameter which the config file of the instance was rendered from,
+	// it contains the passwords, so it is not exposed
+	MySQLServerParam string    `json:"-" middleware:"mysql_server_param"`
+	DelFlag          int       `json:"del_flag" middleware:"del_flag"`
+	CreateTime       time.Time `json:"create_time" middleware:"create_time"`
+	LastUpdateTime   time.Time `json:"last_update_time" middleware:"last_update_time"`
+}
+
+// NewInstanceInfo returns a new *InstanceInfo
+func NewInstanceInfo(hostIP string, portNum, role, serverID int, dataDirBase, logDirBase, pmmServiceName, mysqlServerParam string) *InstanceInfo {
+	instanceInfo := NewInstanceInfoWithDefault()
+	instanceInfo.HostIP = hostIP
+	instanceInfo.PortNum = portNum
+	instanceInfo.Role = role
+	instanceInfo.ServerID = serverID
+	instanceInfo.DataDirBase = dataDirBase
+	instanceInfo.LogDirBase = logDirBase
+	instanceInfo.PMMServiceName = pmmServiceName
+	instanceInfo.MySQLServerParam = mysqlServerParam
+
+	return instanceInfo
+}
+
+// NewInstanceInfoWithDefault returns a new *InstanceInfo with default value
+func NewInstanceInfoWithDefault() *InstanceInfo {
+	return &InstanceInfo{
+		ID:               constant.ZeroInt,
+		ClusterID:        constant.ZeroInt,
+		HostIP:           constant.EmptyString,
+		PortNum:          constant.ZeroInt,
+		Role:             constant.ZeroInt,
+		ServerID:         constant.ZeroInt,
+		DataDirBase:      constant.EmptyString,
+		LogDirBase:       constant.EmptyString,
+		PMMServiceName:   constant.EmptyString,
+		MySQLServerParam: constant.EmptyString,
+		DelFlag:          constant.ZeroInt,
+		CreateTime:       time.Time{},
+		LastUpdateTime:   time.Time{},
+	}
+}
+
+// GetAddr returns the addr of the instance
+func (ii *InstanceInfo) GetAddr() string {
+	return fmt.Sprintf(addrTemplate, ii.HostIP, ii.PortNum)
+}
+
+type ClusterDetail struct {
+	*ClusterInfo
+	Instances []*InstanceInfo `json:"instances"`
+}
+
+// NewClusterDetail returns a new *ClusterDetail
+func NewClusterDetail(clusterInfo *ClusterInfo, instances []*InstanceInfo) *ClusterDetail {
+	return &ClusterDetail{
+		ClusterInfo: clusterInfo,
+		Instances:   instances,
+	}
+}
+
+type InstanceStatus struct {
+	Addr                  string `json:"addr"`
+	IsUp                  bool   `json:"is_up"`
+	Error                 string `json:"error"`
+	Version               string `json:"version"`
+	ReadOnly              bool   `json:"read_only"`
+	SuperReadOnly         bool   `json:"super_read_only"`
+	IsReplica             bool   `json:"is_replica"`
+	IOThreadRunning       string `json:"io_thread_running"`
+	SQLThreadRunning      string `json:"sql_thread_running"`
+	SecondsBehindSource   string `json:"seconds_behind_source"`
+	LastIOError           string `json:"last_io_error"`
+	LastSQLError          string `json:"last_sql_error"`
+	GTIDExecuted          string `json:"gtid_executed"`
+	SemiSyncSourceStatus  string `json:"semi_sync_source_status"`
+	SemiSyncReplicaStatus string `json:"semi_sync_replica_status"`
+}
+
+// NewInstanceStatusWithDefault returns a new *InstanceStatus with default value
+func NewInstanceStatusWithDefault(addr string) *InstanceStatus {
+	return &InstanceStatus{
+		Addr:                  addr,
+		IsUp:                  false,
+		Error:                 constant.EmptyString,
+		Version:               constant.EmptyString,
+		ReadOnly:              false,
+		SuperReadOnly:         false,
+		IsReplica:             false,
+		IOThreadRunning:       constant.EmptyString,
+		SQLThreadRunning:      constant.EmptyString,
+		SecondsBehindSource:   constant.EmptyString,
+		LastIOError:           constant.EmptyString,
+		LastSQLError:          constant.EmptyString,
+		GTIDExecuted:          constant.EmptyString,
+		SemiSyncSourceStatus:  constant.EmptyString,
+		SemiSyncReplicaStatus: constant.EmptyString,
+	}
+}
+
+type GroupMember struct {
+	Addr  string `json:"addr"`
+	State string `json:"state"`
+	Role  string `json:"role"`
+}
+
+// NewGroupMember returns a new *GroupMember
+func NewGroupMember(addr, state, role string) *GroupMember {
+	return &GroupMember{
+		Addr:  addr,
+		State: state,
+		Role:  role,
+	}
+}
+
+type ClusterStatus struct {
+	ClusterName  string            `json:"cluster_name"`
+	Mode         int               `json:"mode"`
+	Instances    []*InstanceStatus `json:"instances"`
+	GroupMembers []*GroupMember    `json:"group_members,omitempty"`
+}
+
+// NewClusterStatus returns a new *ClusterStatus
+func NewClusterStatus(clusterName string, mode int, instances []*InstanceStatus, groupMembers []*GroupMember) *ClusterStatus {
+	return &ClusterStatus{
+		ClusterName:  clusterName,
+		Mode:         mode,
+		Instances:    instances,
+		GroupMembers: groupMembers,
+	}
+}
+
+type ConfigDrift struct {
+	Name              string `json:"name"`
+	Template          string `json:"template"`
+	File              string `json:"file"`
+	Runtime           string `json:"runtime"`
+	TemplateFileDrift bool   `json:"template_file_drift"`
+	FileRuntimeDrift  bool   `json:"file_runtime_drift"`
+}
+
+// NewConfigDrift returns a new *ConfigDrift
+func NewConfigDrift(name, template, file, runtime string, templateFileDrift, fileRuntimeDrift bool) *ConfigDrift {
+	return &ConfigDrift{
+		Name:              name,
+		Template:          template,
+		File:              file,
+		Runtime:           runtime,
+		TemplateFileDrift: templateFileDrift,
+		FileRuntimeDrift:  fileRuntimeDrift,
+	}
+}
+
+type InstanceDrift struct {
+	Addr         string         `json:"addr"`
+	RuntimeError string         `json:"runtime_error"`
+	Drifts       []*ConfigDrift `json:"drifts"`
+}
+
+// NewInstanceDrift returns a new *InstanceDrift
+func NewInstanceDrift(addr, runtimeError string, drifts []*ConfigDrift) *InstanceDrift {
+	return &InstanceDrift{
+		Addr:         addr,
+		RuntimeError: runtimeError,
+		Drifts:       drifts,
+	}
+}
+
+type HostFacts struct {
+	TotalMemory   int64 `json:"total_memory"`
+	CPUCount      int   `json:"cpu_count"`
+	Rotational    bool  `json:"rotational"`
+	InstanceCount int   `json:"instance_count"`
+}
+
+// NewHostFacts returns a new *HostFacts, the total memory is in bytes,
+// and the instance count is the number of the other instances defined in the config file of the host
+func NewHostFacts(totalMemory int64, cpuCount int, rotational bool, instanceCount int) *HostFacts {
+	return &HostFacts{
+		TotalMemory:   totalMemory,
+		CPUCount:      cpuCount,
+		Rotational:    rotational,
+		InstanceCount: instanceCount,
+	}
+}
@@ -0,0 +1,290 @@
+package mysql
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/romberli/go-util/constant"
+	"github.com/romberli/go-util/middleware/mysql"
+	"github.com/romberli/log"
+
+	"github.com/romberli/db-operator/module/implement/mysql/parameter"
+	"github.com/romberli/db-operator/pkg/message"
+
+	msgMySQL "github.com/romberli/db-operator/pkg/message/mysql"
+)
+
+const (
+	setParametersSuccessMessage = "set parameters completed."
+	setParametersPanicMessage   = "set parameters failed because of panic, please check the log for more details."
+
+	quotedValueTemplate = "'%s'"
+)
+
+var (
+	parameterValueRegexp     = regexp.MustCompile(`^[0-9A-Za-z_.\-]+$`)
+	parameterSizeValueRegexp = regexp.MustCompile(`^([0-9]+)([KkMmGg])$`)
+	parameterSizeUnits       = map[string]int64{"k": 1 << 10, "m": 1 << 20, "g": 1 << 30}
+)
+
+// CheckParameters checks if the variables are allowed to be changed in the version and the mode of the engine and if the values are valid,
+// it returns the sorted names of the static variables, which will only take effect after the instance is restarted
+func (e *Engine) CheckParameters(variables map[string]string) ([]string, error) {
+	if len(variables) == constant.ZeroInt {
+		return nil, errors.New("mysql Engine.CheckParameters(): variables should not be empty")
+	}
+	catalog, err := parameter.GetModeVariableCatalog(e.mysqlVersion, e.Mode)
+	if err != nil {
+		return nil, err
+	}
+
+	restartRequired := []string{}
+	for _, name := range getSortedVariableNames(variables) {
+		isDynamic, exists := catalog[name]
+		if !exists {
+			return nil, errors.Errorf("mysql Engine.CheckParameters(): variable is not allowed to be changed. version: %s, mode: %s, variable: %s",
+				e.mysqlVersion.String(), e.Mode.String(), name)
+		}
+		if !parameterValueRegexp.MatchString(variables[name]) {
+			return nil, errors.Errorf("mysql Engine.CheckParameters(): value of the variable is not valid. variable: %s, value: %s",
+				name, variables[name])
+		}
+		if !isDynamic {
+			restartRequired = append(restartRequired, name)
+		}
+	}
+
+	return restartRequired, nil
+}
+
+// SetParameters changes the variables of the mysql instances of the addrs, the dynamic variables are changed with set persist,
+// the static variables are written into the instance section of the config file and take effect after the instance is restarted,
+// all the instances are checked before changing any of them, so that the variables will not be changed on part of the instances
+func (e *Engine) SetParameters(operationID int, variables map[string]string) error {
+	_, err := e.CheckParameters(variables)
+	if err != nil {
+		return err
+	}
+	for _, addr := range e.Addrs {
+		hostIP, portNum, err := splitAddr(addr)
+		if err != nil {
+			return err
+		}
+		err = e.checkInstanceParameters(hostIP, portNum, variables)
+		if err != nil {
+			return err
+		}
+	}
+
+	return e.runInstanceOperation(operationID, setParametersSuccessMessage, func(hostIP string, portNum int) error {
+		return e.SetInstanceParameters(hostIP, portNum, variables)
+	}, func(operationDetailID int, hostIP string, portNum int) {
+		log.Infof(message.NewMessage(msgMySQL.InfoMySQLEngineSetParameters, operationID, operationDetailID, hostIP, portNum, variables).Error())
+	})
+}
+
+// checkInstanceParameters checks if the variables could be changed on the single instance,
+// the dynamic variables must exist in the running instance, e.g. the plugin of the variable must be loaded,
+// and the instance section must exist in the config file if there are static variables
+func (e *Engine) checkInstanceParameters(hostIP string, portNum int, variables map[string]string) error {
+	dynamicVariables, staticVariables, err := e.splitVariables(variables)
+	if err != nil {
+		return err
+	}
+
+	err = e.initInstanceExecutor(hostIP, portNum)
+	if err != nil {
+		return err
+	}
+	if len(dynamicVariables) > constant.ZeroInt {
+		globalVariables, err := e.getGlobalVariables(fmt.Sprintf(addrTemplate, hostIP, portNum))
+		if err != nil {
+			return err
+		}
+		for _, name := range getSortedVariableNames(dynamicVariables) {
+			_, exists := globalVariables[name]
+			if !exists {
+				return errors.Errorf("mysql Engine.checkInstanceParameters(): variable does not exist in the instance. hostIP: %s, portNum: %d, variable: %s",
+					hostIP, portNum, name)
+			}
+		}
+	}
+	if len(staticVariables) > constant.ZeroInt {
+		content, err := e.ose.Conn.Cat(defaultConfigFileName)
+		if err != nil {
+			return err
+		}
+		section := fmt.Sprintf(mysqldMultiInstanceSectionTemplate, portNum)
+		_, found := getConfigSectionValues(content, section)
+		if !found {
+			return errors.Errorf("mysql Engine.checkInstanceParameters(): instance section not found in the config file. hostIP: %s, section: %s",
+				hostIP, section)
+		}
+	}
+
+	return nil
+}
+
+// SetInstanceParameters changes the variables of the single instance, the variables should be checked before calling this function
+func (e *Engine) SetInstanceParameters(hostIP string, portNum int, variables map[string]string) error {
+	dynamicVariables, staticVariables, err := e.splitVariables(variables)
+	if err != nil {
+		return err
+	}
+
+	err = e.initInstanceExecutor(hostIP, portNum)
+	if err != nil {
+		return err
+	}
+	if len(dynamicVariables) > constant.ZeroInt {
+		err = e.setPersistVariables(dynamicVariables)
+		if err != nil {
+			return err
+		}
+	}
+	if len(staticVariables) > constant.ZeroInt {
+		return e.updateMultiInstanceConfigVariables(staticVariables)
+	}
+
+	return nil
+}
+
+// splitVariables splits the variables into the dynamic variables and the static variables by the catalog of the version and the mode
+func (e *Engine) splitVariables(variables map[string]string) (map[string]string, map[string]string, error) {
+	catalog, err := parameter.GetModeVariableCatalog(e.mysqlVersion, e.Mode)
+	if err != nil {
+		return nil, nil, err
+	}
+	dynamicVariables := make(map[string]string)
+	staticVariables := make(map[string]string)
+	for name, value := range variables {
+		if catalog[name] {
+			dynamicVariables[name] = value
+			continue
+		}
+		staticVariables[name] = value
+	}
+
+	return dynamicVariables, staticVariables, nil
+}
+
+// setPersistVariables changes the dynamic variables with set persist, so that they survive the restart of the instance
+func (e *Engine) setPersistVariables(variables map[string]string) error {
+	conn, err := mysql.NewConn(fmt.Sprintf(addrTemplate, e.MySQLServer.HostIP, e.MySQLServer.PortNum),
+		constant.EmptyString, constant.DefaultRootUserName, e.MySQLServer.RootPass)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = conn.Close()
+		if err != nil {
+			log.Errorf("mysql Engine.setPersistVariables(): close mysql connection failed. error:\n%+v", err)
+		}
+	}()
+
+	for _, name := range getSortedVariableNames(variables) {
+		_, err = conn.Execute(fmt.Sprintf(setPersistVariableSQLTemplate, name, getSQLValue(variables[name])))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// updateMultiInstanceConfigVariables writes the static variables into the instance section of the config file,
+// the config file will be backed up first
+func (e *Engine) updateMultiInstanceConfigVariables(variables map[string]string) error {
+	content, err := e.ose.Conn.Cat(defaultConfigFileName)
+	if err != nil {
+		return err
+	}
+	section := fmt.Sprintf(mysqldMultiInstanceSectionTemplate, e.MySQLServer.PortNum)
+	for _, name := range getSortedVariableNames(variables) {
+		var found bool
+		content, found = setConfigSectionValue(content, section, name, variables[name])
+		if !found {
+			return errors.Errorf("mysql Engine.updateMultiInstanceConfigVariables(): instance section not found in the config file. hostIP: %s, section: %s",
+				e.MySQLServer.HostIP, section)
+		}
+	}
+	// backup the config file
+	err = e.ose.Conn.Copy(defaultConfigFileName, fmt.Sprintf(defaultConfigFileBackupNameTemplate, time.Now().Format(constant.TimeLayoutSecondDash)))
+	if err != nil {
+		return err
+	}
+
+	return e.transferConfigContent([]byte(content), fmt.Sprintf(configFileNameTemplate, e.MySQLServer.PortNum), defaultConfigFileName)
+}
+
+// setConfigSectionValue sets the value of the given key in the given section of the config content,
+// the key will be appended to the end of the section if it does not exist,
+// it returns the new content and whether the section was found
+func setConfigSectionValue(content, section, key, value string) (string, bool) {
+	newContent, found := replaceConfigSectionValue(content, section, key, value)
+	if found {
+		return newContent, true
+	}
+
+	lines := strings.Split(content, constant.CRLFString)
+	insertIndex := constant.DefaultRandomInt
+	var inSection bool
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, configSectionPrefix) {
+			// a new section starts
+			inSection = trimmed == section
+			if inSection {
+				insertIndex = i
+			}
+			continue
+		}
+		if inSection && trimmed != constant.EmptyString {
+			insertIndex = i
+		}
+	}
+	if insertIndex == constant.DefaultRandomInt {
+		return content, false
+	}
+
+	newLines := make([]string, constant.ZeroInt, len(lines)+constant.OneInt)
+	newLines = append(newLines, lines[:insertIndex+constant.OneInt]...)
+	newLines = append(newLines, key+constant.EqualString+value)
+	newLines = append(newLines, lines[insertIndex+constant.OneInt:]...)
+
+	return strings.Join(newLines, constant.CRLFString), true
+}
+
+// getSQLValue returns the value which could be used in the set statement,
+// the size value such as 4G is converted to bytes, and the non-numeric value is quoted
+func getSQLValue(value string) string {
+	matches := parameterSizeValueRegexp.FindStringSubmatch(value)
+	if matches != nil {
+		size, err := strconv.ParseInt(matches[constant.OneInt], 10, 64)
+		if err == nil {
+			return strconv.FormatInt(size*parameterSizeUnits[strings.ToLower(matches[constant.TwoInt])], 10)
+		}
+	}
+	_, err := strconv.ParseFloat(value, 64)
+	if err == nil {
+		return value
+	}
+
+	return fmt.Sprintf(quotedValueTemplate, value)
+}
+
+// getSortedVariableNames returns the sorted names of the variables
+func getSortedVariableNames(variables map[string]string) []string {
+	names := make([]string, constant.ZeroInt, len(variables))
+	for name := range variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
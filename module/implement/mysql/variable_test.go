@@ -0,0 +1,73 @@
+package mysql
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVariable_All(t *testing.T) {
+	TestEngine_CheckParameters(t)
+	TestSetConfigSectionValue(t)
+	TestGetSQLValue(t)
+	TestEngine_SetInstanceParameters(t)
+}
+
+func TestEngine_CheckParameters(t *testing.T) {
+	asst := assert.New(t)
+
+	restartRequired, err := testEngine.CheckParameters(map[string]string{
+		"max_connections":              "3000",
+		"innodb_buffer_pool_size":      "4G",
+		"innodb_buffer_pool_instances": "8",
+	})
+	asst.Nil(err, "test CheckParameters() failed")
+	asst.Equal([]string{"innodb_buffer_pool_instances"}, restartRequired, "test CheckParameters() failed")
+	_, err = testEngine.CheckParameters(map[string]string{"datadir": "/tmp"})
+	asst.NotNil(err, "test CheckParameters() failed")
+	_, err = testEngine.CheckParameters(map[string]string{"max_connections": "1; drop table t"})
+	asst.NotNil(err, "test CheckParameters() failed")
+	_, err = testEngine.CheckParameters(map[string]string{})
+	asst.NotNil(err, "test CheckParameters() failed")
+}
+
+func TestSetConfigSectionValue(t *testing.T) {
+	asst := assert.New(t)
+
+	content := "[mysqld3306]\nmax_connections=2000\n\n[mysqld3307]\nmax_connections=2000\n"
+	section := fmt.Sprintf(mysqldMultiInstanceSectionTemplate, testPortNum1)
+	newContent, found := setConfigSectionValue(content, section, "max_connections", "3000")
+	asst.True(found, "test setConfigSectionValue() failed")
+	asst.Equal("[mysqld3306]\nmax_connections=3000\n\n[mysqld3307]\nmax_connections=2000\n", newContent, "test setConfigSectionValue() failed")
+	newContent, found = setConfigSectionValue(content, section, "innodb_buffer_pool_instances", "8")
+	asst.True(found, "test setConfigSectionValue() failed")
+	asst.Equal("[mysqld3306]\nmax_connections=2000\ninnodb_buffer_pool_instances=8\n\n[mysqld3307]\nmax_connections=2000\n", newContent, "test setConfigSectionValue() failed")
+	_, found = setConfigSectionValue(content, "[mysqld3308]", "innodb_buffer_pool_instances", "8")
+	asst.False(found, "test setConfigSectionValue() failed")
+}
+
+func TestGetSQLValue(t *testing.T) {
+	asst := assert.New(t)
+
+	asst.Equal("3000", getSQLValue("3000"), "test getSQLValue() failed")
+	asst.Equal("4294967296", getSQLValue("4G"), "test getSQLValue() failed")
+	asst.Equal("134217728", getSQLValue("128m"), "test getSQLValue() failed")
+	asst.Equal("0.5", getSQLValue("0.5"), "test getSQLValue() failed")
+	asst.Equal("'before_on_primary_failover'", getSQLValue("before_on_primary_failover"), "test getSQLValue() failed")
+}
+
+func TestEngine_SetInstanceParameters(t *testing.T) {
+	asst := assert.New(t)
+
+	err := testInitInstance([]string{testAddr1})
+	asst.Nil(err, "test SetInstanceParameters() failed")
+	err = testEngine.SetInstanceParameters(testHostIP1, testPortNum1, map[string]string{
+		"max_connections":              "3000",
+		"innodb_buffer_pool_instances": "8",
+	})
+	asst.Nil(err, "test SetInstanceParameters() failed")
+	content, err := testEngine.ose.Conn.Cat(defaultConfigFileName)
+	asst.Nil(err, "test SetInstanceParameters() failed")
+	asst.Contains(content, "innodb_buffer_pool_instances=8", "test SetInstanceParameters() failed")
+}
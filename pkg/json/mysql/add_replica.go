@@ -0,0 +1,65 @@
+package mysql
+
+import (
+	"encoding/json"
+
+	"github.com/romberli/go-util/constant"
+
+	"github.com/romberli/db-operator/module/implement/mysql/mode"
+	"github.com/romberli/db-operator/module/implement/mysql/parameter"
+)
+
+type AddReplica struct {
+	Token             string                 `json:"token"`
+	Mode              mode.Mode              `json:"mode"`
+	SourceAddr        string                 `json:"source_addr"`
+	Addrs             []string               `json:"addrs"`
+	MySQLServerParam  *parameter.MySQLServer `json:"mysql_server_param"`
+	PMMClientParam    *parameter.PMMClient   `json:"pmm_client_param"`
+	RollbackOnFailure bool                   `json:"rollback_on_failure"`
+}
+
+// NewAddReplica returns a new *AddReplica
+func NewAddReplica(token string, mode mode.Mode, sourceAddr string, addrs []string,
+	mysqlServerParam *parameter.MySQLServer, pmmClientParam *parameter.PMMClient, rollbackOnFailure bool) *AddReplica {
+	return newAddReplica(token, mode, sourceAddr, addrs, mysqlServerParam, pmmClientParam, rollbackOnFailure)
+}
+
+// NewAddReplicaWithDefault returns a new *AddReplica with default parameters
+func NewAddReplicaWithDefault() *AddReplica {
+	return newAddReplica(
+		constant.EmptyString,
+		mode.AsyncReplication,
+		constant.EmptyString,
+		[]string{},
+		parameter.NewMySQLServerWithDefault(),
+		parameter.NewPMMClientWithDefault(),
+		defaultRollbackOnFailure,
+	)
+}
+
+// newAddReplica returns a new *AddReplica
+func newAddReplica(token string, mode mode.Mode, sourceAddr string, addrs []string,
+	mysqlServerParam *parameter.MySQLServer, pmmClientParam *parameter.PMMClient, rollbackOnFailure bool) *AddReplica {
+	return &AddReplica{
+		Token:             token,
+		Mode:              mode,
+		SourceAddr:        sourceAddr,
+		Addrs:             addrs,
+		MySQLServerParam:  mysqlServerParam,
+		PMMClientParam:    pmmClientParam,
+		RollbackOnFailure: rollbackOnFailure,
+	}
+}
+
+// Unmarshal unmarshals json data to *AddReplica
+func (ar *AddReplica) Unmarshal(data []byte) error {
+	err := json.Unmarshal(data, ar)
+	if err != nil {
+		return err
+	}
+
+	ar.MySQLServerParam.SetVersion(ar.MySQLServerParam.Version)
+
+	return nil
+}
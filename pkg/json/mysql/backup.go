@@ -0,0 +1,61 @@
+package mysql
+
+import (
+	"encoding/json"
+
+	"github.com/romberli/go-util/constant"
+
+	"github.com/romberli/db-operator/module/implement/mysql/parameter"
+)
+
+const (
+	defaultBackupType   = 1
+	defaultBackupMethod = 1
+)
+
+type Backup struct {
+	Token            string                 `json:"token"`
+	Addr             string                 `json:"addr"`
+	BackupType       int                    `json:"backup_type"`
+	BackupMethod     int                    `json:"backup_method"`
+	MySQLServerParam *parameter.MySQLServer `json:"mysql_server_param"`
+}
+
+// NewBackup returns a new *Backup
+func NewBackup(token string, addr string, backupType, backupMethod int, mysqlServerParam *parameter.MySQLServer) *Backup {
+	return newBackup(token, addr, backupType, backupMethod, mysqlServerParam)
+}
+
+// NewBackupWithDefault returns a new *Backup with default parameters, it takes a full backup with xtrabackup by default
+func NewBackupWithDefault() *Backup {
+	return newBackup(
+		constant.EmptyString,
+		constant.EmptyString,
+		defaultBackupType,
+		defaultBackupMethod,
+		parameter.NewMySQLServerWithDefault(),
+	)
+}
+
+// newBackup returns a new *Backup
+func newBackup(token string, addr string, backupType, backupMethod int, mysqlServerParam *parameter.MySQLServer) *Backup {
+	return &Backup{
+		Token:            token,
+		Addr:             addr,
+		BackupType:       backupType,
+		BackupMethod:     backupMethod,
+		MySQLServerParam: mysqlServerParam,
+	}
+}
+
+// Unmarshal unmarshals json data to *Backup
+func (b *Backup) Unmarshal(data []byte) error {
+	err := json.Unmarshal(data, b)
+	if err != nil {
+		return err
+	}
+
+	b.MySQLServerParam.SetVersion(b.MySQLServerParam.Version)
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package mysql
+
+import (
+	"encoding/json"
+
+	"github.com/romberli/go-util/constant"
+
+	"github.com/romberli/db-operator/module/implement/mysql/mode"
+	"github.com/romberli/db-operator/module/implement/mysql/parameter"
+)
+
+type Failover struct {
+	Token            string                 `json:"token"`
+	Mode             mode.Mode              `json:"mode"`
+	SourceAddr       string                 `json:"source_addr"`
+	Addrs            []string               `json:"addrs"`
+	MySQLServerParam *parameter.MySQLServer `json:"mysql_server_param"`
+}
+
+// NewFailover returns a new *Failover
+func NewFailover(token string, mode mode.Mode, sourceAddr string, addrs []string, mysqlServerParam *parameter.MySQLServer) *Failover {
+	return newFailover(token, mode, sourceAddr, addrs, mysqlServerParam)
+}
+
+// NewFailoverWithDefault returns a new *Failover with default parameters
+func NewFailoverWithDefault() *Failover {
+	return newFailover(
+		constant.EmptyString,
+		mode.AsyncReplication,
+		constant.EmptyString,
+		[]string{},
+		parameter.NewMySQLServerWithDefault(),
+	)
+}
+
+// newFailover returns a new *Failover
+func newFailover(token string, mode mode.Mode, sourceAddr string, addrs []string, mysqlServerParam *parameter.MySQLServer) *Failover {
+	return &Failover{
+		Token:            token,
+		Mode:             mode,
+		SourceAddr:       sourceAddr,
+		Addrs:            addrs,
+		MySQLServerParam: mysqlServerParam,
+	}
+}
+
+// Unmarshal unmarshals json data to *Failover
+func (f *Failover) Unmarshal(data []byte) error {
+	err := json.Unmarshal(data, f)
+	if err != nil {
+		return err
+	}
+
+	f.MySQLServerParam.SetVersion(f.MySQLServerParam.Version)
+
+	return nil
+}
@@ -9,40 +9,61 @@ import (
 	"github.com/romberli/db-operator/module/implement/mysql/parameter"
 )
 
+const (
+	defaultRollbackOnFailure = true
+)
+
 type InstallMySQL struct {
-	Token            string                 `json:"token"`
-	Mode             mode.Mode              `json:"mode"`
-	Addrs            []string               `json:"addrs"`
-	MySQLServerParam *parameter.MySQLServer `json:"mysql_server_param"`
-	PMMClientParam   *parameter.PMMClient   `json:"pmm_client_param"`
+	Token             string                 `json:"token"`
+	ClusterName       string                 `json:"cluster_name"`
+	Mode              mode.Mode              `json:"mode"`
+	Addrs             []string               `json:"addrs"`
+	MySQLServerParam  *parameter.MySQLServer `json:"mysql_server_param"`
+	PMMClientParam    *parameter.PMMClient   `json:"pmm_client_param"`
+	RollbackOnFailure bool                   `json:"rollback_on_failure"`
+	ParameterProfile  string                 `json:"parameter_profile"`
+	ExtraParameters   map[string]string      `json:"extra_parameters"`
+	AutoSizing        bool                   `json:"auto_sizing"`
 }
 
 // NewInstallMySQL returns a new *InstallMySQL
-func NewInstallMySQL(token string, mode mode.Mode, addrs []string,
-	mysqlServerParam *parameter.MySQLServer, pmmClientParam *parameter.PMMClient) *InstallMySQL {
-	return newInstallMySQL(token, mode, addrs, mysqlServerParam, pmmClientParam)
+func NewInstallMySQL(token, clusterName string, mode mode.Mode, addrs []string,
+	mysqlServerParam *parameter.MySQLServer, pmmClientParam *parameter.PMMClient, rollbackOnFailure bool,
+	parameterProfile string, extraParameters map[string]string, autoSizing bool) *InstallMySQL {
+	return newInstallMySQL(token, clusterName, mode, addrs, mysqlServerParam, pmmClientParam, rollbackOnFailure, parameterProfile, extraParameters, autoSizing)
 }
 
 // NewInstallMySQLWithDefault returns a new *InstallMySQL with default parameters
 func NewInstallMySQLWithDefault() *InstallMySQL {
 	return newInstallMySQL(
+		constant.EmptyString,
 		constant.EmptyString,
 		mode.Standalone,
 		[]string{},
 		parameter.NewMySQLServerWithDefault(),
 		parameter.NewPMMClientWithDefault(),
+		defaultRollbackOnFailure,
+		constant.EmptyString,
+		map[string]string{},
+		false,
 	)
 }
 
 // newInstallMySQL returns a new *InstallMySQL
-func newInstallMySQL(token string, mode mode.Mode, addrs []string,
-	mysqlServerParam *parameter.MySQLServer, pmmClientParam *parameter.PMMClient) *InstallMySQL {
+func newInstallMySQL(token, clusterName string, mode mode.Mode, addrs []string,
+	mysqlServerParam *parameter.MySQLServer, pmmClientParam *parameter.PMMClient, rollbackOnFailure bool,
+	parameterProfile string, extraParameters map[string]string, autoSizing bool) *InstallMySQL {
 	return &InstallMySQL{
-		Token:            token,
-		Mode:             mode,
-		Addrs:            addrs,
-		MySQLServerParam: mysqlServerParam,
-		PMMClientParam:   pmmClientParam,
+		Token:             token,
+		ClusterName:       clusterName,
+		Mode:              mode,
+		Addrs:             addrs,
+		MySQLServerParam:  mysqlServerParam,
+		PMMClientParam:    pmmClientParam,
+		RollbackOnFailure: rollbackOnFailure,
+		ParameterProfile:  parameterProfile,
+		ExtraParameters:   extraParameters,
+		AutoSizing:        autoSizing,
 	}
 }
 
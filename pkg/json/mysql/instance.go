@@ -0,0 +1,53 @@
+package mysql
+
+import (
+	"encoding/json"
+
+	"github.com/romberli/go-util/constant"
+
+	"github.com/romberli/db-operator/module/implement/mysql/parameter"
+)
+
+type InstanceLifecycle struct {
+	Token            string                 `json:"token"`
+	Addrs            []string               `json:"addrs"`
+	SlowShutdown     bool                   `json:"slow_shutdown"`
+	MySQLServerParam *parameter.MySQLServer `json:"mysql_server_param"`
+}
+
+// NewInstanceLifecycle returns a new *InstanceLifecycle
+func NewInstanceLifecycle(token string, addrs []string, slowShutdown bool, mysqlServerParam *parameter.MySQLServer) *InstanceLifecycle {
+	return newInstanceLifecycle(token, addrs, slowShutdown, mysqlServerParam)
+}
+
+// NewInstanceLifecycleWithDefault returns a new *InstanceLifecycle with default parameters
+func NewInstanceLifecycleWithDefault() *InstanceLifecycle {
+	return newInstanceLifecycle(
+		constant.EmptyString,
+		[]string{},
+		false,
+		parameter.NewMySQLServerWithDefault(),
+	)
+}
+
+// newInstanceLifecycle returns a new *InstanceLifecycle
+func newInstanceLifecycle(token string, addrs []string, slowShutdown bool, mysqlServerParam *parameter.MySQLServer) *InstanceLifecycle {
+	return &InstanceLifecycle{
+		Token:            token,
+		Addrs:            addrs,
+		SlowShutdown:     slowShutdown,
+		MySQLServerParam: mysqlServerParam,
+	}
+}
+
+// Unmarshal unmarshals json data to *InstanceLifecycle
+func (il *InstanceLifecycle) Unmarshal(data []byte) error {
+	err := json.Unmarshal(data, il)
+	if err != nil {
+		return err
+	}
+
+	il.MySQLServerParam.SetVersion(il.MySQLServerParam.Version)
+
+	return nil
+}
@@ -0,0 +1,35 @@
+package mysql
+
+import (
+	"encoding/json"
+
+	"github.com/romberli/go-util/constant"
+)
+
+type ReleaseLock struct {
+	Token string `json:"token"`
+	Addr  string `json:"addr"`
+}
+
+// NewReleaseLock returns a new *ReleaseLock
+func NewReleaseLock(token, addr string) *ReleaseLock {
+	return newReleaseLock(token, addr)
+}
+
+// NewReleaseLockWithDefault returns a new *ReleaseLock with default parameters
+func NewReleaseLockWithDefault() *ReleaseLock {
+	return newReleaseLock(constant.EmptyString, constant.EmptyString)
+}
+
+// newReleaseLock returns a new *ReleaseLock
+func newReleaseLock(token, addr string) *ReleaseLock {
+	return &ReleaseLock{
+		Token: token,
+		Addr:  addr,
+	}
+}
+
+// Unmarshal unmarshals json data to *ReleaseLock
+func (rl *ReleaseLock) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, rl)
+}
@@ -0,0 +1,57 @@
+package mysql
+
+import (
+	"encoding/json"
+
+	"github.com/romberli/go-util/constant"
+
+	"github.com/romberli/db-operator/module/implement/mysql/mode"
+	"github.com/romberli/db-operator/module/implement/mysql/parameter"
+)
+
+type SetParameters struct {
+	Token            string                 `json:"token"`
+	Mode             mode.Mode              `json:"mode"`
+	Addrs            []string               `json:"addrs"`
+	Variables        map[string]string      `json:"variables"`
+	MySQLServerParam *parameter.MySQLServer `json:"mysql_server_param"`
+}
+
+// NewSetParameters returns a new *SetParameters
+func NewSetParameters(token string, mode mode.Mode, addrs []string, variables map[string]string, mysqlServerParam *parameter.MySQLServer) *SetParameters {
+	return newSetParameters(token, mode, addrs, variables, mysqlServerParam)
+}
+
+// NewSetParametersWithDefault returns a new *SetParameters with default parameters
+func NewSetParametersWithDefault() *SetParameters {
+	return newSetParameters(
+		constant.EmptyString,
+		mode.Standalone,
+		[]string{},
+		map[string]string{},
+		parameter.NewMySQLServerWithDefault(),
+	)
+}
+
+// newSetParameters returns a new *SetParameters
+func newSetParameters(token string, mode mode.Mode, addrs []string, variables map[string]string, mysqlServerParam *parameter.MySQLServer) *SetParameters {
+	return &SetParameters{
+		Token:            token,
+		Mode:             mode,
+		Addrs:            addrs,
+		Variables:        variables,
+		MySQLServerParam: mysqlServerParam,
+	}
+}
+
+// Unmarshal unmarshals json data to *SetParameters
+func (sp *SetParameters) Unmarshal(data []byte) error {
+	err := json.Unmarshal(data, sp)
+	if err != nil {
+		return err
+	}
+
+	sp.MySQLServerParam.SetVersion(sp.MySQLServerParam.Version)
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package mysql
+
+import (
+	"encoding/json"
+
+	"github.com/romberli/go-util/constant"
+
+	"github.com/romberli/db-operator/module/implement/mysql/parameter"
+)
+
+type RemoveMySQL struct {
+	Token            string                 `json:"token"`
+	Addrs            []string               `json:"addrs"`
+	Archive          bool                   `json:"archive"`
+	RemoveBinary     bool                   `json:"remove_binary"`
+	MySQLServerParam *parameter.MySQLServer `json:"mysql_server_param"`
+	PMMClientParam   *parameter.PMMClient   `json:"pmm_client_param"`
+}
+
+// NewRemoveMySQL returns a new *RemoveMySQL
+func NewRemoveMySQL(token string, addrs []string, archive, removeBinary bool,
+	mysqlServerParam *parameter.MySQLServer, pmmClientParam *parameter.PMMClient) *RemoveMySQL {
+	return newRemoveMySQL(token, addrs, archive, removeBinary, mysqlServerParam, pmmClientParam)
+}
+
+// NewRemoveMySQLWithDefault returns a new *RemoveMySQL with default parameters
+func NewRemoveMySQLWithDefault() *RemoveMySQL {
+	return newRemoveMySQL(
+		constant.EmptyString,
+		[]string{},
+		false,
+		false,
+		parameter.NewMySQLServerWithDefault(),
+		parameter.NewPMMClientWithDefault(),
+	)
+}
+
+// newRemoveMySQL returns a new *RemoveMySQL
+func newRemoveMySQL(token string, addrs []string, archive, removeBinary bool,
+	mysqlServerParam *parameter.MySQLServer, pmmClientParam *parameter.PMMClient) *RemoveMySQL {
+	return &RemoveMySQL{
+		Token:            token,
+		Addrs:            addrs,
+		Archive:          archive,
+		RemoveBinary:     removeBinary,
+		MySQLServerParam: mysqlServerParam,
+		PMMClientParam:   pmmClientParam,
+	}
+}
+
+// Unmarshal unmarshals json data to *RemoveMySQL
+func (rm *RemoveMySQL) Unmarshal(data []byte) error {
+	err := json.Unmarshal(data, rm)
+	if err != nil {
+		return err
+	}
+
+	rm.MySQLServerParam.SetVersion(rm.MySQLServerParam.Version)
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package mysql
+
+import (
+	"encoding/json"
+
+	"github.com/romberli/go-util/constant"
+
+	"github.com/romberli/db-operator/module/implement/mysql/parameter"
+)
+
+type Restore struct {
+	Token            string                 `json:"token"`
+	Addr             string                 `json:"addr"`
+	BackupID         int                    `json:"backup_id"`
+	StopGTID         string                 `json:"stop_gtid"`
+	StopDatetime     string                 `json:"stop_datetime"`
+	MySQLServerParam *parameter.MySQLServer `json:"mysql_server_param"`
+}
+
+// NewRestore returns a new *Restore
+func NewRestore(token string, addr string, backupID int, stopGTID, stopDatetime string, mysqlServerParam *parameter.MySQLServer) *Restore {
+	return newRestore(token, addr, backupID, stopGTID, stopDatetime, mysqlServerParam)
+}
+
+// NewRestoreWithDefault returns a new *Restore with default parameters
+func NewRestoreWithDefault() *Restore {
+	return newRestore(
+		constant.EmptyString,
+		constant.EmptyString,
+		constant.ZeroInt,
+		constant.EmptyString,
+		constant.EmptyString,
+		parameter.NewMySQLServerWithDefault(),
+	)
+}
+
+// newRestore returns a new *Restore
+func newRestore(token string, addr string, backupID int, stopGTID, stopDatetime string, mysqlServerParam *parameter.MySQLServer) *Restore {
+	return &Restore{
+		Token:            token,
+		Addr:             addr,
+		BackupID:         backupID,
+		StopGTID:         stopGTID,
+		StopDatetime:     stopDatetime,
+		MySQLServerParam: mysqlServerParam,
+	}
+}
+
+// Unmarshal unmarshals json data to *Restore
+func (r *Restore) Unmarshal(data []byte) error {
+	err := json.Unmarshal(data, r)
+	if err != nil {
+		return err
+	}
+
+	r.MySQLServerParam.SetVersion(r.MySQLServerParam.Version)
+
+	return nil
+}
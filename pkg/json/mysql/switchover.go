@@ -0,0 +1,60 @@
+package mysql
+
+import (
+	"encoding/json"
+
+	"github.com/romberli/go-util/constant"
+
+	"github.com/romberli/db-operator/module/implement/mysql/mode"
+	"github.com/romberli/db-operator/module/implement/mysql/parameter"
+)
+
+type Switchover struct {
+	Token            string                 `json:"token"`
+	Mode             mode.Mode              `json:"mode"`
+	SourceAddr       string                 `json:"source_addr"`
+	TargetAddr       string                 `json:"target_addr"`
+	Addrs            []string               `json:"addrs"`
+	MySQLServerParam *parameter.MySQLServer `json:"mysql_server_param"`
+}
+
+// NewSwitchover returns a new *Switchover
+func NewSwitchover(token string, mode mode.Mode, sourceAddr, targetAddr string, addrs []string, mysqlServerParam *parameter.MySQLServer) *Switchover {
+	return newSwitchover(token, mode, sourceAddr, targetAddr, addrs, mysqlServerParam)
+}
+
+// NewSwitchoverWithDefault returns a new *Switchover with default parameters
+func NewSwitchoverWithDefault() *Switchover {
+	return newSwitchover(
+		constant.EmptyString,
+		mode.AsyncReplication,
+		constant.EmptyString,
+		constant.EmptyString,
+		[]string{},
+		parameter.NewMySQLServerWithDefault(),
+	)
+}
+
+// newSwitchover returns a new *Switchover
+func newSwitchover(token string, mode mode.Mode, sourceAddr, targetAddr string, addrs []string, mysqlServerParam *parameter.MySQLServer) *Switchover {
+	return &Switchover{
+		Token:            token,
+		Mode:             mode,
+		SourceAddr:       sourceAddr,
+		TargetAddr:       targetAddr,
+		Addrs:            addrs,
+		MySQLServerParam: mysqlServerParam,
+	}
+}
+
+// Unmarshal unmarshals json data to *Switchover
+func (s *Switchover) Unmarshal(data []byte) error {
+	err := json.Unmarshal(data, s)
+	if err != nil {
+		return err
+	}
+
+	s.MySQLServerParam.SetVersion(s.MySQLServerParam.Version)
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package mysql
+
+import (
+	"encoding/json"
+
+	"github.com/romberli/go-util/constant"
+
+	"github.com/romberli/db-operator/module/implement/mysql/parameter"
+)
+
+type UpgradeMySQL struct {
+	Token            string                 `json:"token"`
+	Addrs            []string               `json:"addrs"`
+	MySQLServerParam *parameter.MySQLServer `json:"mysql_server_param"`
+}
+
+// NewUpgradeMySQL returns a new *UpgradeMySQL
+func NewUpgradeMySQL(token string, addrs []string, mysqlServerParam *parameter.MySQLServer) *UpgradeMySQL {
+	return newUpgradeMySQL(token, addrs, mysqlServerParam)
+}
+
+// NewUpgradeMySQLWithDefault returns a new *UpgradeMySQL with default parameters
+func NewUpgradeMySQLWithDefault() *UpgradeMySQL {
+	return newUpgradeMySQL(
+		constant.EmptyString,
+		[]string{},
+		parameter.NewMySQLServerWithDefault(),
+	)
+}
+
+// newUpgradeMySQL returns a new *UpgradeMySQL
+func newUpgradeMySQL(token string, addrs []string, mysqlServerParam *parameter.MySQLServer) *UpgradeMySQL {
+	return &UpgradeMySQL{
+		Token:            token,
+		Addrs:            addrs,
+		MySQLServerParam: mysqlServerParam,
+	}
+}
+
+// Unmarshal unmarshals json data to *UpgradeMySQL
+func (um *UpgradeMySQL) Unmarshal(data []byte) error {
+	err := json.Unmarshal(data, um)
+	if err != nil {
+		return err
+	}
+
+	um.MySQLServerParam.SetVersion(um.MySQLServerParam.Version)
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package job
+
+import (
+	"github.com/romberli/db-operator/pkg/message"
+	"github.com/romberli/go-util/config"
+)
+
+func init() {
+	initJobDebugMessage()
+	initJobInfoMessage()
+	initJobErrorMessage()
+}
+
+const (
+	// info
+	InfoJobSchedulerStart  = 204001
+	InfoJobSchedulerStop   = 204002
+	InfoJobRun             = 204003
+	InfoJobGetJobs         = 204004
+	InfoJobGetJobHistories = 204005
+
+	// error
+	ErrJobSchedulerGetJobs     = 404001
+	ErrJobSchedulerParseCron   = 404002
+	ErrJobSchedulerScheduleJob = 404003
+	ErrJobSchedulerStopTimeout = 404004
+	ErrJobRun                  = 404005
+	ErrJobNotValidJobType      = 404006
+	ErrJobRepositoryClaimJob   = 404007
+	ErrJobGetJobs              = 404008
+	ErrJobGetJobHistories      = 404009
+	ErrJobNotValidJobID        = 404010
+	ErrJobConfigDriftCheck     = 404011
+	ErrJobUpdateJobHistory     = 404012
+)
+
+func initJobDebugMessage() {
+
+}
+
+func initJobInfoMessage() {
+	message.Messages[InfoJobSchedulerStart] = config.NewErrMessage(message.DefaultMessageHeader, InfoJobSchedulerStart,
+		"job: scheduler started. owner: %s")
+	message.Messages[InfoJobSchedulerStop] = config.NewErrMessage(message.DefaultMessageHeader, InfoJobSchedulerStop,
+		"job: scheduler stopped. owner: %s")
+	message.Messages[InfoJobRun] = config.NewErrMessage(message.DefaultMessageHeader, InfoJobRun,
+		"job: run job completed. job_id: %d, job_name: %s, job_history_id: %d, scheduled_time: %s")
+	message.Messages[InfoJobGetJobs] = config.NewErrMessage(message.DefaultMessageHeader, InfoJobGetJobs,
+		"job: get jobs completed")
+	message.Messages[InfoJobGetJobHistories] = config.NewErrMessage(message.DefaultMessageHeader, InfoJobGetJobHistories,
+		"job: get job histories completed. job_id: %d")
+}
+
+func initJobErrorMessage() {
+	message.Messages[ErrJobSchedulerGetJobs] = config.NewErrMessage(message.DefaultMessageHeader, ErrJobSchedulerGetJobs,
+		"job: scheduler get enabled jobs failed")
+	message.Messages[ErrJobSchedulerParseCron] = config.NewErrMessage(message.DefaultMessageHeader, ErrJobSchedulerParseCron,
+		"job: scheduler parse cron expression failed. job_id: %d, job_name: %s, cron_expr: %s")
+	message.Messages[ErrJobSchedulerScheduleJob] = config.NewErrMessage(message.DefaultMessageHeader, ErrJobSchedulerScheduleJob,
+		"job: scheduler schedule job failed. job_id: %d, job_name: %s")
+	message.Messages[ErrJobSchedulerStopTimeout] = config.NewErrMessage(message.DefaultMessageHeader, ErrJobSchedulerStopTimeout,
+		"job: scheduler stop timed out, some jobs are still running. owner: %s, timeout: %s")
+	message.Messages[ErrJobRun] = config.NewErrMessage(message.DefaultMessageHeader, ErrJobRun,
+		"job: run job failed. job_id: %d, job_name: %s, job_history_id: %d, scheduled_time: %s")
+	message.Messages[ErrJobNotValidJobType] = config.NewErrMessage(message.DefaultMessageHeader, ErrJobNotValidJobType,
+		"job: job type must be one of [1, 2, 3, 4], %d is not valid")
+	message.Messages[ErrJobRepositoryClaimJob] = config.NewErrMessage(message.DefaultMessageHeader, ErrJobRepositoryClaimJob,
+		"job: claim job failed. job_id: %d, scheduled_time: %s")
+	message.Messages[ErrJobGetJobs] = config.NewErrMessage(message.DefaultMessageHeader, ErrJobGetJobs,
+		"job: get jobs failed")
+	message.Messages[ErrJobGetJobHistories] = config.NewErrMessage(message.DefaultMessageHeader, ErrJobGetJobHistories,
+		"job: get job histories failed. job_id: %d")
+	message.Messages[ErrJobNotValidJobID] = config.NewErrMessage(message.DefaultMessageHeader, ErrJobNotValidJobID,
+		"job: job id must be a positive integer, %s is not valid")
+	message.Messages[ErrJobConfigDriftCheck] = config.NewErrMessage(message.DefaultMessageHeader, ErrJobConfigDriftCheck,
+		"job: config drift found. addr: %s, drift_num: %d, runtime_error: %s")
+	message.Messages[ErrJobUpdateJobHistory] = config.NewErrMessage(message.DefaultMessageHeader, ErrJobUpdateJobHistory,
+		"job: update job history failed. job_history_id: %d")
+}
@@ -24,6 +24,10 @@ const (
 	ErrMySQLNotValidConfigMySQLParameterInnodbIOCapacity     = 402004
 	ErrMySQLNotValidConfigMySQLUser                          = 402005
 	ErrMySQLNotValidConfigMySQLOperationTimeout              = 402006
+	ErrMySQLNotValidConfigMySQLInstallConcurrency            = 402007
+	ErrMySQLNotValidConfigMySQLBackupToolPath                = 402008
+	ErrMySQLNotValidConfigMySQLBackupRetentionDays           = 402009
+	ErrMySQLNotValidConfigMySQLLockLeaseTimeout              = 402010
 )
 
 func initMySQLConfigDebugMessage() {
@@ -47,4 +51,12 @@ func initMySQLConfigErrorMessage() {
 		"mysql.Config: %s should not be empty")
 	message.Messages[ErrMySQLNotValidConfigMySQLOperationTimeout] = config.NewErrMessage(message.DefaultMessageHeader, ErrMySQLNotValidConfigMySQLOperationTimeout,
 		"mysql.Config: operation timeout should be in the range [%d, %d], %d is not valid")
+	message.Messages[ErrMySQLNotValidConfigMySQLInstallConcurrency] = config.NewErrMessage(message.DefaultMessageHeader, ErrMySQLNotValidConfigMySQLInstallConcurrency,
+		"mysql.Config: install concurrency should be in the range [%d, %d], %d is not valid")
+	message.Messages[ErrMySQLNotValidConfigMySQLBackupToolPath] = config.NewErrMessage(message.DefaultMessageHeader, ErrMySQLNotValidConfigMySQLBackupToolPath,
+		"mysql.Config: backup tool path should not be empty")
+	message.Messages[ErrMySQLNotValidConfigMySQLBackupRetentionDays] = config.NewErrMessage(message.DefaultMessageHeader, ErrMySQLNotValidConfigMySQLBackupRetentionDays,
+		"mysql.Config: backup retention days should be in the range [%d, %d], %d is not valid")
+	message.Messages[ErrMySQLNotValidConfigMySQLLockLeaseTimeout] = config.NewErrMessage(message.DefaultMessageHeader, ErrMySQLNotValidConfigMySQLLockLeaseTimeout,
+		"mysql.Config: lock lease timeout should be in the range [%d, %d], %d is not valid")
 }
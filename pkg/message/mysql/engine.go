@@ -16,10 +16,36 @@ const (
 	// debug
 
 	// info
-	InfoMySQLEngineInitInstance = 202201
+	InfoMySQLEngineInitInstance               = 202201
+	InfoMySQLEngineRemoveInstance             = 202202
+	InfoMySQLEngineUpgradeInstance            = 202203
+	InfoMySQLEngineRollbackInstance           = 202204
+	InfoMySQLEngineSkipStep                   = 202205
+	InfoMySQLEngineAddReplica                 = 202206
+	InfoMySQLEngineSwitchover                 = 202207
+	InfoMySQLEngineFailover                   = 202208
+	InfoMySQLEngineRegisterCluster            = 202209
+	InfoMySQLEngineStartInstance              = 202210
+	InfoMySQLEngineStopInstance               = 202211
+	InfoMySQLEngineRestartInstance            = 202212
+	InfoMySQLEngineSetParameters              = 202213
+	InfoMySQLEngineAutoSizing                 = 202214
+	InfoMySQLEngineBackup                     = 202215
+	InfoMySQLEnginePurgeBackup                = 202216
+	InfoMySQLEngineRestore                    = 202217
+	InfoMySQLEnginePurgeBinaryLogs            = 202218
+	InfoMySQLEngineSkipGroupReplicationMember = 202219
 
 	// error
 	ErrMySQLEngineUpdateOperationDetail = 402201
+	ErrMySQLEngineRollbackInstance      = 402202
+	ErrMySQLEngineSaveOperationStep     = 402203
+	ErrMySQLEngineResetOperationSteps   = 402204
+	ErrMySQLEngineUnfenceInstance       = 402205
+	ErrMySQLEngineUpdateCluster         = 402206
+	ErrMySQLEngineUpdateBackup          = 402207
+	ErrMySQLEnginePurgeBackup           = 402208
+	ErrMySQLEngineOperationCancelled    = 402209
 )
 
 func initDefaultEngineDebugMessage() {
@@ -29,9 +55,62 @@ func initDefaultEngineDebugMessage() {
 func initDefaultEngineInfoMessage() {
 	message.Messages[InfoMySQLEngineInitInstance] = config.NewErrMessage(message.DefaultMessageHeader, InfoMySQLEngineInitInstance,
 		"mysql Engine: init instance completed. operationID: %d, operationDetailID: %d, hostIP: %s, portNum: %d")
+	message.Messages[InfoMySQLEngineRemoveInstance] = config.NewErrMessage(message.DefaultMessageHeader, InfoMySQLEngineRemoveInstance,
+		"mysql Engine: remove instance completed. operationID: %d, operationDetailID: %d, hostIP: %s, portNum: %d")
+	message.Messages[InfoMySQLEngineUpgradeInstance] = config.NewErrMessage(message.DefaultMessageHeader, InfoMySQLEngineUpgradeInstance,
+		"mysql Engine: upgrade instance completed. operationID: %d, operationDetailID: %d, hostIP: %s, portNum: %d, version: %s")
+	message.Messages[InfoMySQLEngineRollbackInstance] = config.NewErrMessage(message.DefaultMessageHeader, InfoMySQLEngineRollbackInstance,
+		"mysql Engine: rollback instance completed. hostIP: %s, portNum: %d")
+	message.Messages[InfoMySQLEngineSkipStep] = config.NewErrMessage(message.DefaultMessageHeader, InfoMySQLEngineSkipStep,
+		"mysql Engine: step was completed in the previous run, skip it. operationID: %d, hostIP: %s, portNum: %d, step: %d")
+	message.Messages[InfoMySQLEngineAddReplica] = config.NewErrMessage(message.DefaultMessageHeader, InfoMySQLEngineAddReplica,
+		"mysql Engine: add replica completed. operationID: %d, operationDetailID: %d, hostIP: %s, portNum: %d, source: %s")
+	message.Messages[InfoMySQLEngineSwitchover] = config.NewErrMessage(message.DefaultMessageHeader, InfoMySQLEngineSwitchover,
+		"mysql Engine: switchover completed. operationID: %d, source: %s, target: %s")
+	message.Messages[InfoMySQLEngineFailover] = config.NewErrMessage(message.DefaultMessageHeader, InfoMySQLEngineFailover,
+		"mysql Engine: failover completed, the old source is fenced. operationID: %d, source: %s, newSource: %s")
+	message.Messages[InfoMySQLEngineRegisterCluster] = config.NewErrMessage(message.DefaultMessageHeader, InfoMySQLEngineRegisterCluster,
+		"mysql Engine: register cluster completed. clusterID: %d, clusterName: %s, instanceNum: %d")
+	message.Messages[InfoMySQLEngineStartInstance] = config.NewErrMessage(message.DefaultMessageHeader, InfoMySQLEngineStartInstance,
+		"mysql Engine: start instance completed. operationID: %d, operationDetailID: %d, hostIP: %s, portNum: %d")
+	message.Messages[InfoMySQLEngineStopInstance] = config.NewErrMessage(message.DefaultMessageHeader, InfoMySQLEngineStopInstance,
+		"mysql Engine: stop instance completed. operationID: %d, operationDetailID: %d, hostIP: %s, portNum: %d, slowShutdown: %t")
+	message.Messages[InfoMySQLEngineRestartInstance] = config.NewErrMessage(message.DefaultMessageHeader, InfoMySQLEngineRestartInstance,
+		"mysql Engine: restart instance completed. operationID: %d, operationDetailID: %d, hostIP: %s, portNum: %d, slowShutdown: %t")
+	message.Messages[InfoMySQLEngineSetParameters] = config.NewErrMessage(message.DefaultMessageHeader, InfoMySQLEngineSetParameters,
+		"mysql Engine: set parameters completed. operationID: %d, operationDetailID: %d, hostIP: %s, portNum: %d, variables: %v")
+	message.Messages[InfoMySQLEngineAutoSizing] = config.NewErrMessage(message.DefaultMessageHeader, InfoMySQLEngineAutoSizing,
+		"mysql Engine: auto sizing completed. hostIP: %s, portNum: %d, totalMemory: %d, cpuCount: %d, rotational: %t, otherInstances: %d, "+
+			"innodbBufferPoolSize: %s, innodbIOCapacity: %d, innodbIOThreads: %d, replicaParallelWorkers: %d")
+	message.Messages[InfoMySQLEngineBackup] = config.NewErrMessage(message.DefaultMessageHeader, InfoMySQLEngineBackup,
+		"mysql Engine: backup completed. operationID: %d, operationDetailID: %d, hostIP: %s, portNum: %d, backupType: %d, backupMethod: %d")
+	message.Messages[InfoMySQLEnginePurgeBackup] = config.NewErrMessage(message.DefaultMessageHeader, InfoMySQLEnginePurgeBackup,
+		"mysql Engine: purge expired backup completed. backupID: %d, addr: %s, backupDir: %s")
+	message.Messages[InfoMySQLEngineRestore] = config.NewErrMessage(message.DefaultMessageHeader, InfoMySQLEngineRestore,
+		"mysql Engine: restore completed. operationID: %d, operationDetailID: %d, hostIP: %s, portNum: %d, backupID: %d, stopGTID: %s, stopDatetime: %s")
+	message.Messages[InfoMySQLEnginePurgeBinaryLogs] = config.NewErrMessage(message.DefaultMessageHeader, InfoMySQLEnginePurgeBinaryLogs,
+		"mysql Engine: purge binary logs completed. addr: %s, before: %s")
+	message.Messages[InfoMySQLEngineSkipGroupReplicationMember] = config.NewErrMessage(message.DefaultMessageHeader, InfoMySQLEngineSkipGroupReplicationMember,
+		"mysql Engine: group replication member is already online, skip it. operationID: %d, addr: %s")
 }
 
 func initDefaultEngineErrorMessage() {
 	message.Messages[ErrMySQLEngineUpdateOperationDetail] = config.NewErrMessage(message.DefaultMessageHeader, ErrMySQLEngineUpdateOperationDetail,
 		"mysql Engine: update operation detail failed. operationID: %d, operationDetailID: %d, hostIP: %s, portNum: %d, status: %d")
+	message.Messages[ErrMySQLEngineRollbackInstance] = config.NewErrMessage(message.DefaultMessageHeader, ErrMySQLEngineRollbackInstance,
+		"mysql Engine: rollback instance failed. hostIP: %s, portNum: %d")
+	message.Messages[ErrMySQLEngineSaveOperationStep] = config.NewErrMessage(message.DefaultMessageHeader, ErrMySQLEngineSaveOperationStep,
+		"mysql Engine: save operation step failed. operationID: %d, hostIP: %s, portNum: %d, step: %d, status: %d")
+	message.Messages[ErrMySQLEngineResetOperationSteps] = config.NewErrMessage(message.DefaultMessageHeader, ErrMySQLEngineResetOperationSteps,
+		"mysql Engine: reset operation steps failed. operationID: %d, hostIP: %s, portNum: %d, step: %d")
+	message.Messages[ErrMySQLEngineUnfenceInstance] = config.NewErrMessage(message.DefaultMessageHeader, ErrMySQLEngineUnfenceInstance,
+		"mysql Engine: unfence instance failed. operationID: %d, hostIP: %s, portNum: %d")
+	message.Messages[ErrMySQLEngineUpdateCluster] = config.NewErrMessage(message.DefaultMessageHeader, ErrMySQLEngineUpdateCluster,
+		"mysql Engine: update the cluster of the instance failed. addr: %s")
+	message.Messages[ErrMySQLEngineUpdateBackup] = config.NewErrMessage(message.DefaultMessageHeader, ErrMySQLEngineUpdateBackup,
+		"mysql Engine: update the result of the backup failed. backupID: %d, status: %d")
+	message.Messages[ErrMySQLEnginePurgeBackup] = config.NewErrMessage(message.DefaultMessageHeader, ErrMySQLEnginePurgeBackup,
+		"mysql Engine: purge expired backup failed. backupID: %d, addr: %s, backupDir: %s")
+	message.Messages[ErrMySQLEngineOperationCancelled] = config.NewErrMessage(message.DefaultMessageHeader, ErrMySQLEngineOperationCancelled,
+		"mysql Engine: operation was cancelled. operationID: %d, hostIP: %s, portNum: %d")
 }
@@ -18,8 +18,19 @@ const (
 	// info
 
 	// error
-	ErrMySQLRepositoryGetLock     = 402301
-	ErrMySQLRepositoryReleaseLock = 402302
+	ErrMySQLRepositoryGetLock               = 402301
+	ErrMySQLRepositoryReleaseLock           = 402302
+	ErrMySQLRepositoryFenceInstance         = 402303
+	ErrMySQLRepositoryUnfenceInstance       = 402304
+	ErrMySQLRepositorySaveCluster           = 402305
+	ErrMySQLRepositorySetSource             = 402306
+	ErrMySQLRepositoryDeleteInstance        = 402307
+	ErrMySQLRepositoryUpdateBackup          = 402308
+	ErrMySQLRepositoryDeleteBackup          = 402309
+	ErrMySQLRepositoryPurgeOperation        = 402310
+	ErrMySQLRepositoryRenewLock             = 402311
+	ErrMySQLRepositoryFailOrphanedOperation = 402312
+	ErrMySQLRepositoryCancelOperation       = 402313
 )
 
 func initMySQLRepositoryDebugMessage() {
@@ -35,4 +46,26 @@ func initMySQLRepositoryErrorMessage() {
 		"mysql.Repository: get lock failed. operation_id: %d, addrs: %s")
 	message.Messages[ErrMySQLRepositoryReleaseLock] = config.NewErrMessage(message.DefaultMessageHeader, ErrMySQLRepositoryReleaseLock,
 		"mysql.Repository: release lock failed. operation_id: %d")
+	message.Messages[ErrMySQLRepositoryFenceInstance] = config.NewErrMessage(message.DefaultMessageHeader, ErrMySQLRepositoryFenceInstance,
+		"mysql.Repository: fence instance failed. operation_id: %d, host_ip: %s, port_num: %d")
+	message.Messages[ErrMySQLRepositoryUnfenceInstance] = config.NewErrMessage(message.DefaultMessageHeader, ErrMySQLRepositoryUnfenceInstance,
+		"mysql.Repository: unfence instance failed. host_ip: %s, port_num: %d")
+	message.Messages[ErrMySQLRepositorySaveCluster] = config.NewErrMessage(message.DefaultMessageHeader, ErrMySQLRepositorySaveCluster,
+		"mysql.Repository: save cluster failed. cluster_name: %s")
+	message.Messages[ErrMySQLRepositorySetSource] = config.NewErrMessage(message.DefaultMessageHeader, ErrMySQLRepositorySetSource,
+		"mysql.Repository: set source failed. host_ip: %s, port_num: %d")
+	message.Messages[ErrMySQLRepositoryDeleteInstance] = config.NewErrMessage(message.DefaultMessageHeader, ErrMySQLRepositoryDeleteInstance,
+		"mysql.Repository: delete instance failed. host_ip: %s, port_num: %d")
+	message.Messages[ErrMySQLRepositoryUpdateBackup] = config.NewErrMessage(message.DefaultMessageHeader, ErrMySQLRepositoryUpdateBackup,
+		"mysql.Repository: update backup failed. id: %d")
+	message.Messages[ErrMySQLRepositoryDeleteBackup] = config.NewErrMessage(message.DefaultMessageHeader, ErrMySQLRepositoryDeleteBackup,
+		"mysql.Repository: delete backup failed. id: %d")
+	message.Messages[ErrMySQLRepositoryPurgeOperation] = config.NewErrMessage(message.DefaultMessageHeader, ErrMySQLRepositoryPurgeOperation,
+		"mysql.Repository: purge operation history failed. min_time: %s")
+	message.Messages[ErrMySQLRepositoryRenewLock] = config.NewErrMessage(message.DefaultMessageHeader, ErrMySQLRepositoryRenewLock,
+		"mysql.Repository: renew lock failed. operation_id: %d, owner: %s")
+	message.Messages[ErrMySQLRepositoryFailOrphanedOperation] = config.NewErrMessage(message.DefaultMessageHeader, ErrMySQLRepositoryFailOrphanedOperation,
+		"mysql.Repository: fail orphaned operation failed. operation_id: %d")
+	message.Messages[ErrMySQLRepositoryCancelOperation] = config.NewErrMessage(message.DefaultMessageHeader, ErrMySQLRepositoryCancelOperation,
+		"mysql.Repository: request operation cancellation failed. operation_id: %d")
 }
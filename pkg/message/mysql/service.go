@@ -16,11 +16,62 @@ const (
 	// debug
 
 	// info
-	InfoMySQLServiceInstallMySQL = 202101
+	InfoMySQLServiceInstallMySQL             = 202101
+	InfoMySQLServiceGetOperationHistory      = 202102
+	InfoMySQLServiceGetOperationDetails      = 202103
+	InfoMySQLServiceRemoveMySQL              = 202104
+	InfoMySQLServiceUpgradeMySQL             = 202105
+	InfoMySQLServiceResumeOperation          = 202106
+	InfoMySQLServiceAddReplica               = 202107
+	InfoMySQLServiceSwitchover               = 202108
+	InfoMySQLServiceFailover                 = 202109
+	InfoMySQLServiceGetClusters              = 202110
+	InfoMySQLServiceGetCluster               = 202111
+	InfoMySQLServiceGetClusterStatus         = 202112
+	InfoMySQLServiceStartInstance            = 202113
+	InfoMySQLServiceStopInstance             = 202114
+	InfoMySQLServiceRestartInstance          = 202115
+	InfoMySQLServiceSetParameters            = 202116
+	InfoMySQLServiceGetConfigDrift           = 202117
+	InfoMySQLServiceGetParameterProfiles     = 202118
+	InfoMySQLServiceBackup                   = 202119
+	InfoMySQLServiceGetBackups               = 202120
+	InfoMySQLServiceRestore                  = 202121
+	InfoMySQLServiceGetLocks                 = 202122
+	InfoMySQLServiceReleaseLock              = 202123
+	InfoMySQLServiceRecoverOrphanedOperation = 202124
+	InfoMySQLServiceCancelOperation          = 202125
 
 	// error
-	ErrMySQLServiceInstallMySQL           = 402101
-	ErrMySQLServiceUpdateOperationHistory = 402102
+	ErrMySQLServiceInstallMySQL              = 402101
+	ErrMySQLServiceUpdateOperationHistory    = 402102
+	ErrMySQLServiceNotValidOperationID       = 402103
+	ErrMySQLServiceGetOperationHistory       = 402104
+	ErrMySQLServiceGetOperationDetails       = 402105
+	ErrMySQLServiceRemoveMySQL               = 402106
+	ErrMySQLServiceUpgradeMySQL              = 402107
+	ErrMySQLServiceResumeOperation           = 402108
+	ErrMySQLServiceNotResumableOperation     = 402109
+	ErrMySQLServiceAddReplica                = 402110
+	ErrMySQLServiceSwitchover                = 402111
+	ErrMySQLServiceFailover                  = 402112
+	ErrMySQLServiceGetClusters               = 402113
+	ErrMySQLServiceGetCluster                = 402114
+	ErrMySQLServiceGetClusterStatus          = 402115
+	ErrMySQLServiceStartInstance             = 402116
+	ErrMySQLServiceStopInstance              = 402117
+	ErrMySQLServiceRestartInstance           = 402118
+	ErrMySQLServiceSetParameters             = 402119
+	ErrMySQLServiceGetConfigDrift            = 402120
+	ErrMySQLServiceGetParameterProfiles      = 402121
+	ErrMySQLServiceBackup                    = 402122
+	ErrMySQLServiceGetBackups                = 402123
+	ErrMySQLServiceRestore                   = 402124
+	ErrMySQLServiceGetLocks                  = 402125
+	ErrMySQLServiceReleaseLock               = 402126
+	ErrMySQLServiceRecoverOrphanedOperations = 402127
+	ErrMySQLServiceCancelOperation           = 402128
+	ErrMySQLServiceNotCancellableOperation   = 402129
 )
 
 func initMySQLServiceDebugMessage() {
@@ -29,7 +80,55 @@ func initMySQLServiceDebugMessage() {
 
 func initMySQLServiceInfoMessage() {
 	message.Messages[InfoMySQLServiceInstallMySQL] = config.NewErrMessage(message.DefaultMessageHeader, InfoMySQLServiceInstallMySQL,
-		"mysql.Service: install mysql completed. version: %s, mode: %d, addrs: %s")
+		"mysql.Service: install mysql started. operationID: %d, version: %s, mode: %d, addrs: %s")
+	message.Messages[InfoMySQLServiceGetOperationHistory] = config.NewErrMessage(message.DefaultMessageHeader, InfoMySQLServiceGetOperationHistory,
+		"mysql.Service: get operation history completed. operationID: %d")
+	message.Messages[InfoMySQLServiceGetOperationDetails] = config.NewErrMessage(message.DefaultMessageHeader, InfoMySQLServiceGetOperationDetails,
+		"mysql.Service: get operation details completed. operationID: %d")
+	message.Messages[InfoMySQLServiceRemoveMySQL] = config.NewErrMessage(message.DefaultMessageHeader, InfoMySQLServiceRemoveMySQL,
+		"mysql.Service: remove mysql started. operationID: %d, archive: %t, removeBinary: %t, addrs: %s")
+	message.Messages[InfoMySQLServiceUpgradeMySQL] = config.NewErrMessage(message.DefaultMessageHeader, InfoMySQLServiceUpgradeMySQL,
+		"mysql.Service: upgrade mysql started. operationID: %d, version: %s, addrs: %s")
+	message.Messages[InfoMySQLServiceResumeOperation] = config.NewErrMessage(message.DefaultMessageHeader, InfoMySQLServiceResumeOperation,
+		"mysql.Service: resume operation started. operationID: %d")
+	message.Messages[InfoMySQLServiceAddReplica] = config.NewErrMessage(message.DefaultMessageHeader, InfoMySQLServiceAddReplica,
+		"mysql.Service: add replica started. operationID: %d, version: %s, mode: %d, source: %s, addrs: %s")
+	message.Messages[InfoMySQLServiceSwitchover] = config.NewErrMessage(message.DefaultMessageHeader, InfoMySQLServiceSwitchover,
+		"mysql.Service: switchover started. operationID: %d, mode: %d, source: %s, target: %s, addrs: %s")
+	message.Messages[InfoMySQLServiceFailover] = config.NewErrMessage(message.DefaultMessageHeader, InfoMySQLServiceFailover,
+		"mysql.Service: failover started. operationID: %d, mode: %d, source: %s, addrs: %s")
+	message.Messages[InfoMySQLServiceGetClusters] = config.NewErrMessage(message.DefaultMessageHeader, InfoMySQLServiceGetClusters,
+		"mysql.Service: get clusters completed.")
+	message.Messages[InfoMySQLServiceGetCluster] = config.NewErrMessage(message.DefaultMessageHeader, InfoMySQLServiceGetCluster,
+		"mysql.Service: get cluster completed. clusterName: %s")
+	message.Messages[InfoMySQLServiceGetClusterStatus] = config.NewErrMessage(message.DefaultMessageHeader, InfoMySQLServiceGetClusterStatus,
+		"mysql.Service: get cluster status completed. clusterName: %s")
+	message.Messages[InfoMySQLServiceStartInstance] = config.NewErrMessage(message.DefaultMessageHeader, InfoMySQLServiceStartInstance,
+		"mysql.Service: start instance started. operationID: %d, addrs: %s")
+	message.Messages[InfoMySQLServiceStopInstance] = config.NewErrMessage(message.DefaultMessageHeader, InfoMySQLServiceStopInstance,
+		"mysql.Service: stop instance started. operationID: %d, slowShutdown: %t, addrs: %s")
+	message.Messages[InfoMySQLServiceRestartInstance] = config.NewErrMessage(message.DefaultMessageHeader, InfoMySQLServiceRestartInstance,
+		"mysql.Service: restart instance started. operationID: %d, slowShutdown: %t, addrs: %s")
+	message.Messages[InfoMySQLServiceSetParameters] = config.NewErrMessage(message.DefaultMessageHeader, InfoMySQLServiceSetParameters,
+		"mysql.Service: set parameters started. operationID: %d, variables: %s, restartRequired: %s, addrs: %s")
+	message.Messages[InfoMySQLServiceGetConfigDrift] = config.NewErrMessage(message.DefaultMessageHeader, InfoMySQLServiceGetConfigDrift,
+		"mysql.Service: get config drift completed. addr: %s")
+	message.Messages[InfoMySQLServiceGetParameterProfiles] = config.NewErrMessage(message.DefaultMessageHeader, InfoMySQLServiceGetParameterProfiles,
+		"mysql.Service: get parameter profiles completed.")
+	message.Messages[InfoMySQLServiceBackup] = config.NewErrMessage(message.DefaultMessageHeader, InfoMySQLServiceBackup,
+		"mysql.Service: backup started. operationID: %d, backupType: %d, backupMethod: %d, addrs: %s")
+	message.Messages[InfoMySQLServiceGetBackups] = config.NewErrMessage(message.DefaultMessageHeader, InfoMySQLServiceGetBackups,
+		"mysql.Service: get backups completed. addr: %s")
+	message.Messages[InfoMySQLServiceRestore] = config.NewErrMessage(message.DefaultMessageHeader, InfoMySQLServiceRestore,
+		"mysql.Service: restore started. operationID: %d, backupID: %d, stopGTID: %s, stopDatetime: %s, addr: %s")
+	message.Messages[InfoMySQLServiceGetLocks] = config.NewErrMessage(message.DefaultMessageHeader, InfoMySQLServiceGetLocks,
+		"mysql.Service: get locks completed.")
+	message.Messages[InfoMySQLServiceReleaseLock] = config.NewErrMessage(message.DefaultMessageHeader, InfoMySQLServiceReleaseLock,
+		"mysql.Service: release lock completed. addr: %s, operationID: %d, owner: %s")
+	message.Messages[InfoMySQLServiceRecoverOrphanedOperation] = config.NewErrMessage(message.DefaultMessageHeader, InfoMySQLServiceRecoverOrphanedOperation,
+		"mysql.Service: orphaned operation marked as failed. operationID: %d, owner: %s, message: %s")
+	message.Messages[InfoMySQLServiceCancelOperation] = config.NewErrMessage(message.DefaultMessageHeader, InfoMySQLServiceCancelOperation,
+		"mysql.Service: cancel operation requested, it will stop at the next step boundary. operationID: %d")
 }
 
 func initMySQLServiceErrorMessage() {
@@ -37,4 +136,58 @@ func initMySQLServiceErrorMessage() {
 		"mysql.Service: install mysql failed. version: %s, mode: %d, addrs: %s")
 	message.Messages[ErrMySQLServiceUpdateOperationHistory] = config.NewErrMessage(message.DefaultMessageHeader, ErrMySQLServiceUpdateOperationHistory,
 		"mysql.Service: update operation history failed. operationID: %d, status: %d")
+	message.Messages[ErrMySQLServiceNotValidOperationID] = config.NewErrMessage(message.DefaultMessageHeader, ErrMySQLServiceNotValidOperationID,
+		"mysql.Service: operation id must be a positive integer, %s is not valid")
+	message.Messages[ErrMySQLServiceGetOperationHistory] = config.NewErrMessage(message.DefaultMessageHeader, ErrMySQLServiceGetOperationHistory,
+		"mysql.Service: get operation history failed. operationID: %d")
+	message.Messages[ErrMySQLServiceGetOperationDetails] = config.NewErrMessage(message.DefaultMessageHeader, ErrMySQLServiceGetOperationDetails,
+		"mysql.Service: get operation details failed. operationID: %d")
+	message.Messages[ErrMySQLServiceRemoveMySQL] = config.NewErrMessage(message.DefaultMessageHeader, ErrMySQLServiceRemoveMySQL,
+		"mysql.Service: remove mysql failed. archive: %t, removeBinary: %t, addrs: %s")
+	message.Messages[ErrMySQLServiceUpgradeMySQL] = config.NewErrMessage(message.DefaultMessageHeader, ErrMySQLServiceUpgradeMySQL,
+		"mysql.Service: upgrade mysql failed. version: %s, addrs: %s")
+	message.Messages[ErrMySQLServiceResumeOperation] = config.NewErrMessage(message.DefaultMessageHeader, ErrMySQLServiceResumeOperation,
+		"mysql.Service: resume operation failed. operationID: %d")
+	message.Messages[ErrMySQLServiceNotResumableOperation] = config.NewErrMessage(message.DefaultMessageHeader, ErrMySQLServiceNotResumableOperation,
+		"mysql.Service: operation is not resumable, only the failed or cancelled install operation could be resumed. operationID: %d, type: %d, status: %d")
+	message.Messages[ErrMySQLServiceAddReplica] = config.NewErrMessage(message.DefaultMessageHeader, ErrMySQLServiceAddReplica,
+		"mysql.Service: add replica failed. version: %s, mode: %d, source: %s, addrs: %s")
+	message.Messages[ErrMySQLServiceSwitchover] = config.NewErrMessage(message.DefaultMessageHeader, ErrMySQLServiceSwitchover,
+		"mysql.Service: switchover failed. mode: %d, source: %s, target: %s, addrs: %s")
+	message.Messages[ErrMySQLServiceFailover] = config.NewErrMessage(message.DefaultMessageHeader, ErrMySQLServiceFailover,
+		"mysql.Service: failover failed. mode: %d, source: %s, addrs: %s")
+	message.Messages[ErrMySQLServiceGetClusters] = config.NewErrMessage(message.DefaultMessageHeader, ErrMySQLServiceGetClusters,
+		"mysql.Service: get clusters failed.")
+	message.Messages[ErrMySQLServiceGetCluster] = config.NewErrMessage(message.DefaultMessageHeader, ErrMySQLServiceGetCluster,
+		"mysql.Service: get cluster failed. clusterName: %s")
+	message.Messages[ErrMySQLServiceGetClusterStatus] = config.NewErrMessage(message.DefaultMessageHeader, ErrMySQLServiceGetClusterStatus,
+		"mysql.Service: get cluster status failed. clusterName: %s")
+	message.Messages[ErrMySQLServiceStartInstance] = config.NewErrMessage(message.DefaultMessageHeader, ErrMySQLServiceStartInstance,
+		"mysql.Service: start instance failed. addrs: %s")
+	message.Messages[ErrMySQLServiceStopInstance] = config.NewErrMessage(message.DefaultMessageHeader, ErrMySQLServiceStopInstance,
+		"mysql.Service: stop instance failed. slowShutdown: %t, addrs: %s")
+	message.Messages[ErrMySQLServiceRestartInstance] = config.NewErrMessage(message.DefaultMessageHeader, ErrMySQLServiceRestartInstance,
+		"mysql.Service: restart instance failed. slowShutdown: %t, addrs: %s")
+	message.Messages[ErrMySQLServiceSetParameters] = config.NewErrMessage(message.DefaultMessageHeader, ErrMySQLServiceSetParameters,
+		"mysql.Service: set parameters failed. variables: %v, addrs: %s")
+	message.Messages[ErrMySQLServiceGetConfigDrift] = config.NewErrMessage(message.DefaultMessageHeader, ErrMySQLServiceGetConfigDrift,
+		"mysql.Service: get config drift failed. addr: %s")
+	message.Messages[ErrMySQLServiceGetParameterProfiles] = config.NewErrMessage(message.DefaultMessageHeader, ErrMySQLServiceGetParameterProfiles,
+		"mysql.Service: get parameter profiles failed.")
+	message.Messages[ErrMySQLServiceBackup] = config.NewErrMessage(message.DefaultMessageHeader, ErrMySQLServiceBackup,
+		"mysql.Service: backup failed. backupType: %d, backupMethod: %d, addrs: %s")
+	message.Messages[ErrMySQLServiceGetBackups] = config.NewErrMessage(message.DefaultMessageHeader, ErrMySQLServiceGetBackups,
+		"mysql.Service: get backups failed. addr: %s")
+	message.Messages[ErrMySQLServiceRestore] = config.NewErrMessage(message.DefaultMessageHeader, ErrMySQLServiceRestore,
+		"mysql.Service: restore failed. backupID: %d, stopGTID: %s, stopDatetime: %s, addr: %s")
+	message.Messages[ErrMySQLServiceGetLocks] = config.NewErrMessage(message.DefaultMessageHeader, ErrMySQLServiceGetLocks,
+		"mysql.Service: get locks failed.")
+	message.Messages[ErrMySQLServiceReleaseLock] = config.NewErrMessage(message.DefaultMessageHeader, ErrMySQLServiceReleaseLock,
+		"mysql.Service: release lock failed. addr: %s")
+	message.Messages[ErrMySQLServiceRecoverOrphanedOperations] = config.NewErrMessage(message.DefaultMessageHeader, ErrMySQLServiceRecoverOrphanedOperations,
+		"mysql.Service: recover orphaned operations failed.")
+	message.Messages[ErrMySQLServiceCancelOperation] = config.NewErrMessage(message.DefaultMessageHeader, ErrMySQLServiceCancelOperation,
+		"mysql.Service: cancel operation failed. operationID: %d")
+	message.Messages[ErrMySQLServiceNotCancellableOperation] = config.NewErrMessage(message.DefaultMessageHeader, ErrMySQLServiceNotCancellableOperation,
+		"mysql.Service: operation is not cancellable, only the running operation could be cancelled. operationID: %d, status: %d")
 }
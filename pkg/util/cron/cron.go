@@ -0,0 +1,195 @@
+package cron
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/romberli/go-util/constant"
+)
+
+const (
+	fieldNum       = 5
+	anyValue       = "*"
+	rangeSeparator = "-"
+	stepSeparator  = "/"
+	// the schedule which could not be matched in this period, e.g. 0 0 30 2 *, is treated as never matched
+	maxSearchYears = 5
+)
+
+type bounds struct {
+	min int
+	max int
+}
+
+var (
+	minuteBounds  = bounds{min: 0, max: 59}
+	hourBounds    = bounds{min: 0, max: 23}
+	dayBounds     = bounds{min: 1, max: 31}
+	monthBounds   = bounds{min: 1, max: 12}
+	weekdayBounds = bounds{min: 0, max: 7}
+)
+
+// Schedule is the parsed standard cron expression with 5 fields: minute, hour, day of month, month and day of week
+type Schedule struct {
+	expr     string
+	minutes  map[int]bool
+	hours    map[int]bool
+	days     map[int]bool
+	months   map[int]bool
+	weekdays map[int]bool
+	// the day matches if either the day of month or the day of week matches when both of them are restricted
+	dayRestricted     bool
+	weekdayRestricted bool
+}
+
+// Parse parses the cron expression, each field supports *, single value, range, list and step, e.g. */5 0-6,22,23 * * 1-5,
+// 0 and 7 both mean sunday in the day of week field
+func Parse(expr string) (*Schedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != fieldNum {
+		return nil, errors.Errorf("cron expression must contain %d fields. expr: %s", fieldNum, expr)
+	}
+
+	s := &Schedule{expr: expr}
+	var err error
+	s.minutes, err = parseField(fields[0], minuteBounds)
+	if err != nil {
+		return nil, err
+	}
+	s.hours, err = parseField(fields[1], hourBounds)
+	if err != nil {
+		return nil, err
+	}
+	s.days, err = parseField(fields[2], dayBounds)
+	if err != nil {
+		return nil, err
+	}
+	s.months, err = parseField(fields[3], monthBounds)
+	if err != nil {
+		return nil, err
+	}
+	s.weekdays, err = parseField(fields[4], weekdayBounds)
+	if err != nil {
+		return nil, err
+	}
+	if s.weekdays[weekdayBounds.max] {
+		s.weekdays[weekdayBounds.min] = true
+	}
+	s.dayRestricted = fields[2] != anyValue
+	s.weekdayRestricted = fields[4] != anyValue
+
+	return s, nil
+}
+
+// String returns the cron expression of the schedule
+func (s *Schedule) String() string {
+	return s.expr
+}
+
+// Next returns the first time which matches the schedule after the given time, the seconds are truncated,
+// it returns the zero time if no time matches in the following years
+func (s *Schedule) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(maxSearchYears, constant.ZeroInt, constant.ZeroInt)
+
+	for t.Before(limit) {
+		if !s.months[int(t.Month())] {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.matchDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.hours[t.Hour()] {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.minutes[t.Minute()] {
+			t = t.Add(time.Minute)
+			continue
+		}
+
+		return t
+	}
+
+	return time.Time{}
+}
+
+// matchDay returns if the day of the given time matches the day of month and the day of week of the schedule
+func (s *Schedule) matchDay(t time.Time) bool {
+	dayMatched := s.days[t.Day()]
+	weekdayMatched := s.weekdays[int(t.Weekday())]
+	if s.dayRestricted && s.weekdayRestricted {
+		return dayMatched || weekdayMatched
+	}
+
+	return dayMatched && weekdayMatched
+}
+
+// parseField parses the field of the cron expression and returns the matched values
+func parseField(field string, b bounds) (map[int]bool, error) {
+	values := make(map[int]bool)
+	for _, part := range strings.Split(field, constant.CommaString) {
+		rangePart := part
+		step := constant.OneInt
+		if strings.Contains(part, stepSeparator) {
+			stepParts := strings.SplitN(part, stepSeparator, constant.TwoInt)
+			rangePart = stepParts[0]
+			var err error
+			step, err = strconv.Atoi(stepParts[1])
+			if err != nil || step <= constant.ZeroInt {
+				return nil, errors.Errorf("step of the cron field must be a positive integer. field: %s", field)
+			}
+		}
+
+		start, end := b.min, b.max
+		switch {
+		case rangePart == anyValue:
+		case strings.Contains(rangePart, rangeSeparator):
+			rangeParts := strings.SplitN(rangePart, rangeSeparator, constant.TwoInt)
+			var err error
+			start, err = parseValue(rangeParts[0], b)
+			if err != nil {
+				return nil, err
+			}
+			end, err = parseValue(rangeParts[1], b)
+			if err != nil {
+				return nil, err
+			}
+			if start > end {
+				return nil, errors.Errorf("start of the cron range must not be larger than the end. field: %s", field)
+			}
+		default:
+			value, err := parseValue(rangePart, b)
+			if err != nil {
+				return nil, err
+			}
+			start = value
+			if step == constant.OneInt {
+				end = value
+			}
+		}
+
+		for i := start; i <= end; i += step {
+			values[i] = true
+		}
+	}
+
+	return values, nil
+}
+
+// parseValue parses the single value of the cron field and checks if it is within the bounds
+func parseValue(s string, b bounds) (int, error) {
+	value, err := strconv.Atoi(s)
+	if err != nil {
+		return constant.ZeroInt, errors.Errorf("value of the cron field must be an integer. value: %s", s)
+	}
+	if value < b.min || value > b.max {
+		return constant.ZeroInt, errors.Errorf("value of the cron field must be in [%d, %d]. value: %d", b.min, b.max, value)
+	}
+
+	return value, nil
+}
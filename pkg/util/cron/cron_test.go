@@ -0,0 +1,64 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/romberli/go-util/common"
+	"github.com/stretchr/testify/assert"
+)
+
+var testTime = time.Date(2024, time.January, 31, 23, 58, 30, 0, time.Local)
+
+func TestCron_All(t *testing.T) {
+	TestParse(t)
+	TestSchedule_Next(t)
+}
+
+func TestParse(t *testing.T) {
+	asst := assert.New(t)
+
+	_, err := Parse("*/5 0-6,22,23 * * 1-5")
+	asst.Nil(err, common.CombineMessageWithError("test Parse() failed", err))
+	_, err = Parse("* * * *")
+	asst.NotNil(err, "test Parse() failed")
+	_, err = Parse("60 * * * *")
+	asst.NotNil(err, "test Parse() failed")
+	_, err = Parse("*/0 * * * *")
+	asst.NotNil(err, "test Parse() failed")
+	_, err = Parse("5-1 * * * *")
+	asst.NotNil(err, "test Parse() failed")
+}
+
+func TestSchedule_Next(t *testing.T) {
+	asst := assert.New(t)
+
+	// every minute
+	s, err := Parse("* * * * *")
+	asst.Nil(err, common.CombineMessageWithError("test Next() failed", err))
+	asst.Equal(time.Date(2024, time.January, 31, 23, 59, 0, 0, time.Local), s.Next(testTime), "test Next() failed")
+	// every day at 02:30, the month is carried over
+	s, err = Parse("30 2 * * *")
+	asst.Nil(err, common.CombineMessageWithError("test Next() failed", err))
+	asst.Equal(time.Date(2024, time.February, 1, 2, 30, 0, 0, time.Local), s.Next(testTime), "test Next() failed")
+	// every 15 minutes
+	s, err = Parse("*/15 * * * *")
+	asst.Nil(err, common.CombineMessageWithError("test Next() failed", err))
+	asst.Equal(time.Date(2024, time.February, 1, 0, 0, 0, 0, time.Local), s.Next(testTime), "test Next() failed")
+	// sunday, 2024-02-04 is the first sunday after the test time
+	s, err = Parse("0 3 * * 7")
+	asst.Nil(err, common.CombineMessageWithError("test Next() failed", err))
+	asst.Equal(time.Date(2024, time.February, 4, 3, 0, 0, 0, time.Local), s.Next(testTime), "test Next() failed")
+	// the day of month or the day of week
+	s, err = Parse("0 0 15 * 5")
+	asst.Nil(err, common.CombineMessageWithError("test Next() failed", err))
+	asst.Equal(time.Date(2024, time.February, 2, 0, 0, 0, 0, time.Local), s.Next(testTime), "test Next() failed")
+	// leap day
+	s, err = Parse("0 0 29 2 *")
+	asst.Nil(err, common.CombineMessageWithError("test Next() failed", err))
+	asst.Equal(time.Date(2024, time.February, 29, 0, 0, 0, 0, time.Local), s.Next(testTime), "test Next() failed")
+	// never matched
+	s, err = Parse("0 0 30 2 *")
+	asst.Nil(err, common.CombineMessageWithError("test Next() failed", err))
+	asst.True(s.Next(testTime).IsZero(), "test Next() failed")
+}
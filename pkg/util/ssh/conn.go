@@ -1,11 +1,17 @@
 package ssh
 
 import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
 	"github.com/hashicorp/go-version"
 	"github.com/pingcap/errors"
 	"github.com/romberli/go-util/constant"
 	"github.com/romberli/go-util/linux"
-	"strings"
+	"github.com/romberli/log"
 )
 
 const (
@@ -15,21 +21,106 @@ const (
 	centOS     = "CentOS Linux"
 	almaLinux  = "AlmaLinux"
 	rockyLinux = "Rocky Linux"
+
+	// the pid of the remote shell is saved to the pid file, so that the command could be killed when the context is done,
+	// the command is separated by newlines instead of semicolons, so that the command which ends with & is still valid
+	pidFileTemplate        = "/tmp/dbo-command-%s.pid"
+	trackedCommandTemplate = "echo \\$\\$ > %s\n%s\nrc=\\$?\n/usr/bin/rm -f %s\nexit \\$rc"
+	killCommandTemplate    = `if [ -f %s ]; then /usr/bin/pkill -TERM -P \$(/usr/bin/cat %s); /usr/bin/kill -TERM \$(/usr/bin/cat %s); /usr/bin/rm -f %s; fi`
+	killWaitTimeout        = 10 * time.Second
 )
 
+type commandResult struct {
+	output string
+	err    error
+}
+
 type Conn struct {
 	*linux.SSHConn
+	ctx context.Context
 }
 
 // NewConn returns a new *Conn
 func NewConn(conn *linux.SSHConn) *Conn {
-	return newConn(conn)
+	return newConn(context.Background(), conn)
+}
+
+// NewConnWithContext returns a new *Conn, the running commands will be killed when the context is done
+func NewConnWithContext(ctx context.Context, conn *linux.SSHConn) *Conn {
+	return newConn(ctx, conn)
 }
 
 // newConn returns a new *Conn
-func newConn(conn *linux.SSHConn) *Conn {
+func newConn(ctx context.Context, conn *linux.SSHConn) *Conn {
 	return &Conn{
 		SSHConn: conn,
+		ctx:     ctx,
+	}
+}
+
+// SetContext sets the context of the connection, the commands which are executed later will be bound to the new context
+func (c *Conn) SetContext(ctx context.Context) {
+	c.ctx = ctx
+}
+
+// ExecuteCommand executes the command on the remote host and returns the output,
+// if the context is done before the command finishes, the command will be killed on the remote host
+func (c *Conn) ExecuteCommand(cmd string) (string, error) {
+	return c.executeCommand(cmd, c.SSHConn.ExecuteCommand)
+}
+
+// ExecuteCommandWithoutOutput executes the command on the remote host without returning the output,
+// if the context is done before the command finishes, the command will be killed on the remote host
+func (c *Conn) ExecuteCommandWithoutOutput(cmd string) error {
+	_, err := c.executeCommand(cmd, func(trackedCmd string) (string, error) {
+		return constant.EmptyString, c.SSHConn.ExecuteCommandWithoutOutput(trackedCmd)
+	})
+
+	return err
+}
+
+// executeCommand executes the command with the execute function and waits for the command to finish or the context to be done
+func (c *Conn) executeCommand(cmd string, execute func(cmd string) (string, error)) (string, error) {
+	if c.ctx == nil || c.ctx.Done() == nil {
+		return execute(cmd)
+	}
+	err := c.ctx.Err()
+	if err != nil {
+		return constant.EmptyString, errors.Trace(err)
+	}
+
+	pidFile := fmt.Sprintf(pidFileTemplate, uuid.New().String())
+	resultChan := make(chan *commandResult, constant.OneInt)
+	go func() {
+		output, err := execute(getTrackedCommand(pidFile, cmd))
+		resultChan <- &commandResult{output: output, err: err}
+	}()
+
+	select {
+	case result := <-resultChan:
+		return result.output, result.err
+	case <-c.ctx.Done():
+		c.killCommand(pidFile)
+		select {
+		case <-resultChan:
+		case <-time.After(killWaitTimeout):
+			log.Warnf("ssh Conn.executeCommand(): the killed command did not return in time. pidFile: %s, timeout: %s", pidFile, killWaitTimeout)
+		}
+
+		return constant.EmptyString, errors.Trace(c.ctx.Err())
+	}
+}
+
+// getTrackedCommand returns the command which saves the pid of the remote shell to the pid file before running the given command
+func getTrackedCommand(pidFile, cmd string) string {
+	return fmt.Sprintf(trackedCommandTemplate, pidFile, cmd, pidFile)
+}
+
+// killCommand kills the child processes of the remote shell which saved its pid to the pid file and the shell itself
+func (c *Conn) killCommand(pidFile string) {
+	err := c.SSHConn.ExecuteCommandWithoutOutput(fmt.Sprintf(killCommandTemplate, pidFile, pidFile, pidFile, pidFile))
+	if err != nil {
+		log.Errorf("ssh Conn.killCommand(): kill remote command failed. pidFile: %s, error:\n%+v", pidFile, err)
 	}
 }
 
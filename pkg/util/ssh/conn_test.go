@@ -0,0 +1,33 @@
+package ssh
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/romberli/go-util/common"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testPIDFile = "/tmp/dbo-command-test.pid"
+)
+
+func TestConn_All(t *testing.T) {
+	TestGetTrackedCommand(t)
+}
+
+func TestGetTrackedCommand(t *testing.T) {
+	asst := assert.New(t)
+
+	commands := []string{
+		"/usr/bin/uname -m",
+		"/usr/bin/ls -l /tmp ;",
+		"/usr/bin/ps -ef | /usr/bin/grep mysqld | /usr/bin/awk -F' ' '{print \\$2}'",
+		"/usr/local/mysql/bin/mysqld --defaults-file=/tmp/my.cnf.3306 --user=mysql &",
+	}
+	for _, cmd := range commands {
+		// check the syntax of the tracked command without running it
+		output, err := exec.Command("/bin/bash", "-n", "-c", getTrackedCommand(testPIDFile, cmd)).CombinedOutput()
+		asst.Nil(err, common.CombineMessageWithError("test getTrackedCommand() failed. output: "+string(output), err))
+	}
+}
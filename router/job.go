@@ -0,0 +1,15 @@
+package router
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/romberli/db-operator/api/v1/job"
+)
+
+// RegisterJob is the sub-router for job
+func RegisterJob(group *gin.RouterGroup) {
+	jobGroup := group.Group("/job")
+	{
+		jobGroup.GET("", job.GetJobs)
+		jobGroup.GET("/:id/history", job.GetJobHistories)
+	}
+}
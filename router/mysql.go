@@ -10,5 +10,28 @@ func RegisterMySQL(group *gin.RouterGroup) {
 	mysqlGroup := group.Group("/mysql")
 	{
 		mysqlGroup.POST("/install", mysql.Install)
+		mysqlGroup.POST("/remove", mysql.Remove)
+		mysqlGroup.POST("/upgrade", mysql.Upgrade)
+		mysqlGroup.POST("/add-replica", mysql.AddReplica)
+		mysqlGroup.POST("/switchover", mysql.Switchover)
+		mysqlGroup.POST("/failover", mysql.Failover)
+		mysqlGroup.POST("/instance/start", mysql.StartInstance)
+		mysqlGroup.POST("/instance/stop", mysql.StopInstance)
+		mysqlGroup.POST("/instance/restart", mysql.RestartInstance)
+		mysqlGroup.GET("/instance/:addr/drift", mysql.GetConfigDrift)
+		mysqlGroup.GET("/instance/:addr/backup", mysql.GetBackups)
+		mysqlGroup.POST("/backup", mysql.Backup)
+		mysqlGroup.POST("/restore", mysql.Restore)
+		mysqlGroup.POST("/parameter/set", mysql.SetParameters)
+		mysqlGroup.GET("/parameter/profile", mysql.GetParameterProfiles)
+		mysqlGroup.GET("/cluster", mysql.GetClusters)
+		mysqlGroup.GET("/cluster/:name", mysql.GetCluster)
+		mysqlGroup.GET("/cluster/:name/status", mysql.GetClusterStatus)
+		mysqlGroup.GET("/operation/:id", mysql.GetOperation)
+		mysqlGroup.GET("/operation/:id/detail", mysql.GetOperationDetail)
+		mysqlGroup.POST("/operation/:id/resume", mysql.ResumeOperation)
+		mysqlGroup.POST("/operation/:id/cancel", mysql.CancelOperation)
+		mysqlGroup.GET("/lock", mysql.GetLocks)
+		mysqlGroup.DELETE("/lock", mysql.ReleaseLock)
 	}
 }
@@ -88,4 +88,6 @@ func RegisterAll(group *gin.RouterGroup) {
 	RegisterHealth(group)
 	// mysql
 	RegisterMySQL(group)
+	// job
+	RegisterJob(group)
 }
@@ -39,6 +39,8 @@ type Server interface {
 	Router() router.Router
 	// Run runs server
 	Run()
+	// RegisterStopFunc registers the function which will be called after the server is stopped
+	RegisterStopFunc(stopFunc func() error)
 	// Stop stops server
 	Stop() error
 }
@@ -50,6 +52,8 @@ type server struct {
 	addr    string
 	pidFile string
 	router  router.Router
+	// stopFuncs are called in order after the http server is shut down
+	stopFuncs []func() error
 }
 
 // NewServer returns new *server
@@ -92,7 +96,13 @@ func (s *server) Run() {
 	}
 }
 
-// Stop stops server
+// RegisterStopFunc registers the function which will be called after the server is stopped,
+// it is used to stop the background services, e.g. the job scheduler
+func (s *server) RegisterStopFunc(stopFunc func() error) {
+	s.stopFuncs = append(s.stopFuncs, stopFunc)
+}
+
+// Stop stops server, and then calls the registered stop functions
 func (s *server) Stop() error {
 	ctx, cancel := context.WithTimeout(context.Background(), defaultGracefulShutdownTimeout)
 	defer cancel()
@@ -102,5 +112,12 @@ func (s *server) Stop() error {
 		return errors.Trace(err)
 	}
 
+	for _, stopFunc := range s.stopFuncs {
+		err = stopFunc()
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
@@ -0,0 +1,3 @@
+ALTER TABLE `t_mysql_operation_info`
+    ADD COLUMN `cancel_flag` tinyint(4) NOT NULL DEFAULT '0' COMMENT '取消标记: 0-未请求取消, 1-已请求取消' AFTER `status`,
+    MODIFY COLUMN `status` tinyint(4) NOT NULL DEFAULT '0' COMMENT '运行状态: 0-未运行, 1-运行中, 2-已完成, 3-已失败, 4-已取消';
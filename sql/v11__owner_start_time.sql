@@ -0,0 +1,8 @@
+ALTER TABLE `t_mysql_operation_info`
+    MODIFY COLUMN `owner` varchar(200) NOT NULL DEFAULT '' COMMENT '运行该操作的db-operator进程, 格式: 主机名:进程号:启动时间';
+
+ALTER TABLE `t_mysql_operation_lock`
+    MODIFY COLUMN `owner` varchar(200) NOT NULL DEFAULT '' COMMENT '持有该锁的db-operator进程, 格式: 主机名:进程号:启动时间';
+
+ALTER TABLE `t_sys_job_history`
+    MODIFY COLUMN `owner` varchar(200) NOT NULL COMMENT '运行该任务的db-operator进程, 格式: 主机名:进程号:启动时间';
@@ -0,0 +1,2 @@
+ALTER TABLE `t_mysql_instance`
+    ADD COLUMN `mysql_server_param` mediumtext DEFAULT NULL COMMENT '实例安装时生效的MySQL参数, 用于检查配置漂移' AFTER `pmm_service_name`;
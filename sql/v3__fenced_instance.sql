@@ -0,0 +1,17 @@
+ALTER TABLE `t_mysql_operation_step`
+    MODIFY COLUMN `step` tinyint(4) NOT NULL COMMENT '安装步骤: 1-初始化操作系统, 2-安装二进制, 3-初始化实例, 4-初始化用户, 5-启动mysqld_multi, 6-配置复制, 7-配置PMM, 8-克隆数据';
+
+CREATE TABLE `t_mysql_fenced_instance`
+(
+    `id`               int(11)      NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+    `operation_id`     int(11)      NOT NULL COMMENT '隔离该实例的操作ID',
+    `host_ip`          varchar(100) NOT NULL COMMENT 'MySQL服务器IP',
+    `port_num`         int(11)      NOT NULL COMMENT 'MySQL服务器端口',
+    `reason`           varchar(500)          DEFAULT NULL COMMENT '隔离原因',
+    `del_flag`         tinyint(4)   NOT NULL DEFAULT '0' COMMENT '删除标记: 0-未删除, 1-已删除',
+    `create_time`      datetime(6)  NOT NULL DEFAULT CURRENT_TIMESTAMP(6) COMMENT '创建时间',
+    `last_update_time` datetime(6)  NOT NULL DEFAULT CURRENT_TIMESTAMP(6) ON UPDATE CURRENT_TIMESTAMP(6) COMMENT '最后更新时间',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `idx01_host_ip_port_num` (`host_ip`, `port_num`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4 COMMENT = 'MySQL隔离实例表';
@@ -0,0 +1,33 @@
+CREATE TABLE `t_mysql_cluster`
+(
+    `id`               int(11)      NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+    `cluster_name`     varchar(100) NOT NULL COMMENT '集群名称',
+    `mode`             tinyint(4)   NOT NULL COMMENT '集群模式: 1-单实例, 2-异步复制, 3-半同步复制, 4-组复制',
+    `version`          varchar(100) NOT NULL COMMENT 'MySQL版本',
+    `del_flag`         tinyint(4)   NOT NULL DEFAULT '0' COMMENT '删除标记: 0-未删除, 1-已删除',
+    `create_time`      datetime(6)  NOT NULL DEFAULT CURRENT_TIMESTAMP(6) COMMENT '创建时间',
+    `last_update_time` datetime(6)  NOT NULL DEFAULT CURRENT_TIMESTAMP(6) ON UPDATE CURRENT_TIMESTAMP(6) COMMENT '最后更新时间',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `idx01_cluster_name` (`cluster_name`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4 COMMENT = 'MySQL集群表';
+
+CREATE TABLE `t_mysql_instance`
+(
+    `id`               int(11)      NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+    `cluster_id`       int(11)      NOT NULL COMMENT '集群ID',
+    `host_ip`          varchar(100) NOT NULL COMMENT 'MySQL服务器IP',
+    `port_num`         int(11)      NOT NULL COMMENT 'MySQL服务器端口',
+    `role`             tinyint(4)   NOT NULL COMMENT '实例角色: 1-主库, 2-从库',
+    `server_id`        int(11)      NOT NULL COMMENT 'MySQL server_id',
+    `data_dir_base`    varchar(200) NOT NULL COMMENT '数据目录',
+    `log_dir_base`     varchar(200) NOT NULL COMMENT '日志目录',
+    `pmm_service_name` varchar(200)          DEFAULT NULL COMMENT 'PMM服务名称',
+    `del_flag`         tinyint(4)   NOT NULL DEFAULT '0' COMMENT '删除标记: 0-未删除, 1-已删除',
+    `create_time`      datetime(6)  NOT NULL DEFAULT CURRENT_TIMESTAMP(6) COMMENT '创建时间',
+    `last_update_time` datetime(6)  NOT NULL DEFAULT CURRENT_TIMESTAMP(6) ON UPDATE CURRENT_TIMESTAMP(6) COMMENT '最后更新时间',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `idx01_host_ip_port_num` (`host_ip`, `port_num`),
+    KEY `idx02_cluster_id` (`cluster_id`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4 COMMENT = 'MySQL实例表';
@@ -0,0 +1,21 @@
+CREATE TABLE `t_mysql_parameter_profile`
+(
+    `id`               int(11)      NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+    `profile_name`     varchar(100) NOT NULL COMMENT '参数模板名称',
+    `description`      varchar(500)          DEFAULT NULL COMMENT '参数模板描述',
+    `parameters`       text         NOT NULL COMMENT '参数, JSON格式: {"参数名": "参数值"}',
+    `del_flag`         tinyint(4)   NOT NULL DEFAULT '0' COMMENT '删除标记: 0-未删除, 1-已删除',
+    `create_time`      datetime(6)  NOT NULL DEFAULT CURRENT_TIMESTAMP(6) COMMENT '创建时间',
+    `last_update_time` datetime(6)  NOT NULL DEFAULT CURRENT_TIMESTAMP(6) ON UPDATE CURRENT_TIMESTAMP(6) COMMENT '最后更新时间',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `idx01_profile_name` (`profile_name`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4 COMMENT = 'MySQL参数模板表';
+
+INSERT INTO `t_mysql_parameter_profile`(`profile_name`, `description`, `parameters`)
+VALUES ('oltp', '高并发短事务场景',
+        '{"sort_buffer_size": "2M", "join_buffer_size": "2M", "read_buffer_size": "2M", "read_rnd_buffer_size": "2M", "long_query_time": "0.1", "innodb_flush_log_at_trx_commit": "1", "sync_binlog": "1", "thread_cache_size": "512"}'),
+       ('analytics', '大查询分析场景',
+        '{"sort_buffer_size": "32M", "join_buffer_size": "32M", "read_buffer_size": "16M", "read_rnd_buffer_size": "16M", "tmp_table_size": "256M", "max_heap_table_size": "256M", "long_query_time": "2", "max_allowed_packet": "256M"}'),
+       ('small-memory', '小内存服务器场景',
+        '{"innodb_buffer_pool_size": "256M", "innodb_buffer_pool_instances": "1", "max_connections": "200", "sort_buffer_size": "256K", "join_buffer_size": "256K", "read_buffer_size": "256K", "read_rnd_buffer_size": "256K", "tmp_table_size": "16M", "max_heap_table_size": "16M", "key_buffer_size": "8M", "innodb_log_buffer_size": "8M", "table_open_cache": "512", "thread_cache_size": "16", "performance_schema": "OFF", "innodb_numa_interleave": "0"}');
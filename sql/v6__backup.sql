@@ -0,0 +1,29 @@
+CREATE TABLE `t_mysql_backup`
+(
+    `id`               int(11)       NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+    `operation_id`     int(11)       NOT NULL COMMENT '操作ID',
+    `host_ip`          varchar(100)  NOT NULL COMMENT 'MySQL服务器IP',
+    `port_num`         int(11)       NOT NULL COMMENT 'MySQL服务器端口',
+    `backup_type`      tinyint(4)    NOT NULL COMMENT '备份类型: 1-全量备份, 2-增量备份',
+    `backup_method`    tinyint(4)    NOT NULL COMMENT '备份方式: 1-xtrabackup, 2-clone插件',
+    `base_backup_id`   int(11)       NOT NULL DEFAULT '0' COMMENT '增量备份的基础备份ID, 全量备份为0',
+    `backup_dir`       varchar(500)  NOT NULL COMMENT '备份目录',
+    `from_lsn`         bigint(20)    NOT NULL DEFAULT '0' COMMENT '起始LSN',
+    `to_lsn`           bigint(20)    NOT NULL DEFAULT '0' COMMENT '结束LSN',
+    `gtid_set`         text                   DEFAULT NULL COMMENT '备份对应的GTID集合',
+    `backup_size`      bigint(20)    NOT NULL DEFAULT '0' COMMENT '备份大小, 单位: 字节',
+    `checksum`         varchar(100)           DEFAULT NULL COMMENT '备份校验值',
+    `status`           tinyint(4)    NOT NULL COMMENT '备份状态: 1-运行中, 2-成功, 3-失败',
+    `message`          varchar(1000)          DEFAULT NULL COMMENT '备份信息',
+    `start_time`       datetime(6)   NOT NULL DEFAULT CURRENT_TIMESTAMP(6) COMMENT '备份开始时间',
+    `end_time`         datetime(6)   NOT NULL DEFAULT CURRENT_TIMESTAMP(6) COMMENT '备份结束时间, 备份完成前与开始时间相同',
+    `del_flag`         tinyint(4)    NOT NULL DEFAULT '0' COMMENT '删除标记: 0-未删除, 1-已删除',
+    `create_time`      datetime(6)   NOT NULL DEFAULT CURRENT_TIMESTAMP(6) COMMENT '创建时间',
+    `last_update_time` datetime(6)   NOT NULL DEFAULT CURRENT_TIMESTAMP(6) ON UPDATE CURRENT_TIMESTAMP(6) COMMENT '最后更新时间',
+    PRIMARY KEY (`id`),
+    KEY `idx01_host_ip_port_num` (`host_ip`, `port_num`),
+    KEY `idx02_operation_id` (`operation_id`),
+    KEY `idx03_base_backup_id` (`base_backup_id`),
+    KEY `idx04_end_time` (`end_time`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4 COMMENT = 'MySQL备份表';
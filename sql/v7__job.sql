@@ -0,0 +1,40 @@
+CREATE TABLE `t_sys_job`
+(
+    `id`               int(11)       NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+    `job_name`         varchar(100)  NOT NULL COMMENT '任务名称',
+    `job_type`         tinyint(4)    NOT NULL COMMENT '任务类型: 1-备份, 2-清理binlog, 3-配置漂移检查, 4-清理操作历史',
+    `cron_expr`        varchar(100)  NOT NULL COMMENT 'cron表达式: 分 时 日 月 周',
+    `job_param`        varchar(4000) NOT NULL DEFAULT '{}' COMMENT '任务参数, json格式',
+    `enabled`          tinyint(4)    NOT NULL DEFAULT '0' COMMENT '是否启用: 0-未启用, 1-已启用',
+    `last_run_time`    datetime(6)   NOT NULL DEFAULT '1970-01-01 00:00:00.000000' COMMENT '上次运行时间',
+    `next_run_time`    datetime(6)   NOT NULL DEFAULT '1970-01-01 00:00:00.000000' COMMENT '下次运行时间, 早于创建时间时会根据cron表达式重新计算, 修改cron表达式后需重置',
+    `del_flag`         tinyint(4)    NOT NULL DEFAULT '0' COMMENT '删除标记: 0-未删除, 1-已删除',
+    `create_time`      datetime(6)   NOT NULL DEFAULT CURRENT_TIMESTAMP(6) COMMENT '创建时间',
+    `last_update_time` datetime(6)   NOT NULL DEFAULT CURRENT_TIMESTAMP(6) ON UPDATE CURRENT_TIMESTAMP(6) COMMENT '最后更新时间',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `idx01_job_name` (`job_name`),
+    KEY `idx02_enabled_next_run_time` (`enabled`, `next_run_time`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4 COMMENT = '定时任务表';
+
+CREATE TABLE `t_sys_job_history`
+(
+    `id`               int(11)       NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+    `job_id`           int(11)       NOT NULL COMMENT '任务ID',
+    `scheduled_time`   datetime(6)   NOT NULL COMMENT '计划运行时间',
+    `owner`            varchar(200)  NOT NULL COMMENT '运行该任务的db-operator进程, 格式: 主机名:进程号',
+    `status`           tinyint(4)    NOT NULL COMMENT '运行状态: 1-运行中, 2-已完成, 3-已失败',
+    `message`          mediumtext             DEFAULT NULL COMMENT '运行日志',
+    `start_time`       datetime(6)   NOT NULL DEFAULT CURRENT_TIMESTAMP(6) COMMENT '开始时间',
+    `end_time`         datetime(6)   NOT NULL DEFAULT CURRENT_TIMESTAMP(6) COMMENT '结束时间, 运行完成前与开始时间相同',
+    `del_flag`         tinyint(4)    NOT NULL DEFAULT '0' COMMENT '删除标记: 0-未删除, 1-已删除',
+    `create_time`      datetime(6)   NOT NULL DEFAULT CURRENT_TIMESTAMP(6) COMMENT '创建时间',
+    `last_update_time` datetime(6)   NOT NULL DEFAULT CURRENT_TIMESTAMP(6) ON UPDATE CURRENT_TIMESTAMP(6) COMMENT '最后更新时间',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `idx01_job_id_scheduled_time` (`job_id`, `scheduled_time`),
+    KEY `idx02_create_time` (`create_time`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4 COMMENT = '定时任务运行历史表, 同一计划运行时间只允许一个进程运行';
+
+INSERT INTO `t_sys_job`(`job_name`, `job_type`, `cron_expr`, `job_param`, `enabled`)
+VALUES ('cleanup-operation-history', 4, '0 3 * * *', '{"retention_days": 30}', 1);
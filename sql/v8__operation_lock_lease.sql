@@ -0,0 +1,5 @@
+ALTER TABLE `t_mysql_operation_lock`
+    ADD COLUMN `owner` varchar(200) NOT NULL DEFAULT '' COMMENT '持有该锁的db-operator进程, 格式: 主机名:进程号' AFTER `addr`,
+    MODIFY COLUMN `last_update_time` datetime(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6) ON UPDATE CURRENT_TIMESTAMP(6) COMMENT '最后心跳时间, 超过租约时间未更新的锁会被清理',
+    ADD KEY `idx02_operation_id` (`operation_id`),
+    ADD KEY `idx03_last_update_time` (`last_update_time`);
@@ -0,0 +1,3 @@
+ALTER TABLE `t_mysql_operation_info`
+    ADD COLUMN `owner` varchar(200) NOT NULL DEFAULT '' COMMENT '运行该操作的db-operator进程, 格式: 主机名:进程号' AFTER `addrs`,
+    ADD KEY `idx02_status` (`status`);